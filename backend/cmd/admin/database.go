@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+func migrateUp(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("migrate-up", flag.ExitOnError)
+	flags.Parse(args)
+
+	store := postgres.NewStore(config)
+	if err := store.MigrateUp(); err != nil {
+		return err
+	}
+
+	return logMigrationVersion(store)
+}
+
+func migrateDown(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("migrate-down", flag.ExitOnError)
+	steps := flags.Int("steps", 1, "number of migrations to roll back")
+	flags.Parse(args)
+
+	store := postgres.NewStore(config)
+	if err := store.MigrateDown(*steps); err != nil {
+		return err
+	}
+
+	return logMigrationVersion(store)
+}
+
+func logMigrationVersion(store *postgres.Store) error {
+	version, dirty, err := store.MigrationVersion()
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		log.Printf("the schema is at migration %d, which failed halfway and needs fixing by hand", version)
+	} else {
+		log.Printf("the schema is at migration %d", version)
+	}
+
+	return nil
+}
+
+func recountCategories(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("recount-categories", flag.ExitOnError)
+	flags.Parse(args)
+
+	store, repo, err := openRepo(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer store.CloseDB()
+
+	repaired, err := repo.CategoryRepository.RecountProductCounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("repaired the product count of %d categories", repaired)
+
+	return nil
+}
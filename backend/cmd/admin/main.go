@@ -0,0 +1,111 @@
+// Command admin runs operational tasks against the server's database, with
+// the server's config:
+//
+//	go run ./cmd/admin [-config dir] <command> [flags]
+//
+// Run it without a command to list the commands, and with -h after a command
+// for its flags.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, config pkg.Config, args []string) error
+}
+
+var commands = []command{
+	{"create-admin", "create an admin user", createAdmin},
+	{"reset-password", "set a new password for a user", resetPassword},
+	{"migrate-up", "apply the migrations not applied yet", migrateUp},
+	{"migrate-down", "roll back the last migrations applied", migrateDown},
+	{"recount-categories", "recompute the product counts of the categories", recountCategories},
+	{"reverify-payment", "verify a paystack payment again and store its status", reverifyPayment},
+	{"replay-event", "apply a logged paystack event again", replayEvent},
+	{"seed", "create demo categories and products", seed},
+}
+
+func main() {
+	configPath := flag.String("config", ".envs/.local", "directory of the config.env file")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := flag.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		// load config
+		config, err := pkg.LoadConfig(*configPath)
+		if err != nil {
+			log.Fatalf("Error loading config: %v", err)
+		}
+
+		if err := cmd.run(context.Background(), config, flag.Args()[1:]); err != nil {
+			log.Fatalf("Error running %s: %s", name, pkg.ErrorMessage(err))
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: admin [-config dir] <command> [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-20s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// openRepo connects to the database without migrating it, schema changes are
+// only applied by migrate-up.
+func openRepo(ctx context.Context, config pkg.Config) (*postgres.Store, *postgres.PostgresRepo, error) {
+	store := postgres.NewStore(config)
+	if err := store.ConnectDB(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return store, postgres.NewPostgresRepo(store), nil
+}
+
+// readPassword returns the password given in a flag or, when it is empty,
+// reads it from the first line of stdin so it stays out of the shell history.
+func readPassword(password string) (string, error) {
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", pkg.Errorf(pkg.INVALID_ERROR, "could not read the password: %s", err.Error())
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if len(password) < 8 {
+		return "", pkg.Errorf(pkg.INVALID_ERROR, "the password must be at least 8 characters long")
+	}
+
+	return password, nil
+}
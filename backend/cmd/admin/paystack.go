@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/paystack"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+func reverifyPayment(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("reverify-payment", flag.ExitOnError)
+	reference := flags.String("reference", "", "reference of the paystack payment")
+	flags.Parse(args)
+
+	if *reference == "" {
+		return pkg.Errorf(pkg.INVALID_ERROR, "-reference is required")
+	}
+
+	store, repo, err := openRepo(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer store.CloseDB()
+
+	payment, err := repo.PaystackRepository.GetPaymentByReference(ctx, *reference)
+	if err != nil {
+		return err
+	}
+
+	status, err := verifyPayment(config, payment)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.PaystackRepository.UpdatePaymentStatus(ctx, payment.Reference, status); err != nil {
+		return err
+	}
+
+	log.Printf("payment %s went from %s to %s", payment.Reference, payment.Status, status)
+
+	return nil
+}
+
+func replayEvent(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("replay-event", flag.ExitOnError)
+	id := flags.Int64("id", 0, "ID of the logged paystack event")
+	flags.Parse(args)
+
+	if *id <= 0 {
+		return pkg.Errorf(pkg.INVALID_ERROR, "-id is required")
+	}
+
+	store, repo, err := openRepo(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer store.CloseDB()
+
+	event, err := repo.PaystackRepository.GetPaystackEvent(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(event.Event, "charge.") {
+		return pkg.Errorf(pkg.INVALID_ERROR, "%s events do not change payments", event.Event)
+	}
+
+	data, _ := event.Data.(map[string]any)
+	reference, _ := data["reference"].(string)
+	eventStatus, _ := data["status"].(string)
+	if reference == "" || eventStatus == "" {
+		return pkg.Errorf(pkg.INVALID_ERROR, "%s event %d has no reference or status", event.Event, event.ID)
+	}
+
+	payment, err := repo.PaystackRepository.GetPaymentByReference(ctx, reference)
+	if err != nil {
+		return err
+	}
+
+	// the event is only applied when paystack still reports it for the
+	// amount the payment was started with
+	status, err := verifyPayment(config, payment)
+	if err != nil {
+		return err
+	}
+	if status != eventStatus {
+		return pkg.Errorf(pkg.INVALID_ERROR, "event %d says payment %s is %s but paystack reports %s", event.ID, reference, eventStatus, status)
+	}
+
+	if err := repo.PaystackRepository.UpdatePaymentStatus(ctx, reference, status); err != nil {
+		return err
+	}
+
+	log.Printf("replayed %s event %d logged at %s, payment %s went from %s to %s", event.Event, event.ID, event.CreatedAt.Format("2006-01-02 15:04:05"), reference, payment.Status, status)
+
+	return nil
+}
+
+// verifyPayment returns the status paystack reports for the payment, failing
+// when the amount or currency paid differ from the ones it was started with.
+func verifyPayment(config pkg.Config, payment repository.PaystackPayment) (string, error) {
+	// amounts are stored in the currency's subunit
+	minor, err := strconv.ParseInt(payment.Amount, 10, 64)
+	if err != nil {
+		return "", pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amount %q of payment %s", payment.Amount, payment.Reference)
+	}
+
+	ps := paystack.NewPaystack(config.PAYSTACK_SECRET_KEY, config.PAYSTACK_CALLBACK_URL)
+
+	return ps.VerifyPayment(payment.Reference, pkg.NewMoney(minor, payment.Currency))
+}
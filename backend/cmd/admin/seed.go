@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/imports"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+// seedProducts are imported by sku, so seeding again updates the demo
+// products rather than adding more.
+//
+//go:embed seed_products.csv
+var seedProducts []byte
+
+var seedCategories = []repository.Category{
+	{Name: "Flowers", Description: "Fresh flowers by the stem"},
+	{Name: "Bouquets", Description: "Arranged bouquets"},
+	{Name: "Add-ons", Description: "Gifts to send with flowers"},
+	{Name: "Message Cards", Description: "Cards with a personal message"},
+}
+
+func seed(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	flags.Parse(args)
+
+	rows, err := imports.ReadProductRows(bytes.NewReader(seedProducts))
+	if err != nil {
+		return err
+	}
+
+	store, repo, err := openRepo(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer store.CloseDB()
+
+	tree, err := repo.CategoryRepository.GetCategoryTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	var walk func(categories []*repository.Category)
+	walk = func(categories []*repository.Category) {
+		for _, category := range categories {
+			existing[strings.ToLower(category.Name)] = true
+			walk(category.Children)
+		}
+	}
+	walk(tree)
+
+	for _, category := range seedCategories {
+		if existing[strings.ToLower(category.Name)] {
+			continue
+		}
+
+		category.ImageUrl = []string{}
+		created, err := repo.CategoryRepository.CreateCategory(ctx, &category)
+		if err != nil {
+			return err
+		}
+		log.Printf("created category %d %s", created.ID, created.Name)
+	}
+
+	result, err := repo.ProductImportRepository.ImportProducts(ctx, rows, repository.ProductImportOptions{
+		Mode: repository.ImportModePerRow,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rowError := range result.Errors {
+		log.Printf("line %d (%s): %s", rowError.Line, rowError.SKU, rowError.Message)
+	}
+	log.Printf("created %d and updated %d demo products", len(result.Created), len(result.Updated))
+
+	return nil
+}
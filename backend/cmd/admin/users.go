@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+func createAdmin(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	name := flags.String("name", "", "name of the admin")
+	email := flags.String("email", "", "email the admin logs in with")
+	phoneNumber := flags.String("phone", "", "phone number of the admin")
+	password := flags.String("password", "", "password of the admin, read from stdin when empty")
+	flags.Parse(args)
+
+	if *name == "" || *email == "" || *phoneNumber == "" {
+		return pkg.Errorf(pkg.INVALID_ERROR, "-name, -email and -phone are required")
+	}
+
+	plain, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	hashPassword, err := pkg.GenerateHashPassword(plain, config.PASSWORD_COST)
+	if err != nil {
+		return err
+	}
+
+	store, repo, err := openRepo(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer store.CloseDB()
+
+	user, err := repo.UserRepository.CreateUser(ctx, &repository.User{
+		Name:        *name,
+		Email:       *email,
+		PhoneNumber: *phoneNumber,
+		Password:    &hashPassword,
+		IsAdmin:     true,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("created admin %d <%s>", user.ID, user.Email)
+
+	return nil
+}
+
+func resetPassword(ctx context.Context, config pkg.Config, args []string) error {
+	flags := flag.NewFlagSet("reset-password", flag.ExitOnError)
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "new password, read from stdin when empty")
+	flags.Parse(args)
+
+	if *email == "" {
+		return pkg.Errorf(pkg.INVALID_ERROR, "-email is required")
+	}
+
+	plain, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	hashPassword, err := pkg.GenerateHashPassword(plain, config.PASSWORD_COST)
+	if err != nil {
+		return err
+	}
+
+	store, repo, err := openRepo(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer store.CloseDB()
+
+	user, err := repo.UserRepository.GetUserByEmail(ctx, *email)
+	if err != nil {
+		return err
+	}
+
+	if _, err := repo.UserRepository.UpdateUser(ctx, &repository.UpdateUser{
+		ID:       user.ID,
+		Password: &hashPassword,
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("reset the password of user %d <%s>", user.ID, user.Email)
+
+	return nil
+}
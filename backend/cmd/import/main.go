@@ -0,0 +1,80 @@
+// Command import creates or updates, keyed by SKU, the products in a CSV file
+// the same way as POST /products/import:
+//
+//	go run ./cmd/import -file products.csv [-mode atomic|per_row] [-dry-run]
+//
+// The result is printed as JSON and the command exits with status 1 when any
+// row has an error.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/flexGURU/flower-haven/backend/internal/imports"
+	"github.com/flexGURU/flower-haven/backend/internal/postgres"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+func main() {
+	configPath := flag.String("config", ".envs/.local", "directory of the config.env file")
+	path := flag.String("file", "", "CSV file to import")
+	mode := flag.String("mode", repository.ImportModeAtomic, "atomic to import all the products or none, per_row to import each on its own")
+	dryRun := flag.Bool("dry-run", false, "check the file without saving anything")
+	userID := flag.Uint("user", 0, "ID of the user recorded with the stock set")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Error opening file: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := imports.ReadProductRows(file)
+	if err != nil {
+		log.Fatalf("Error reading file: %v", pkg.ErrorMessage(err))
+	}
+
+	// load config
+	config, err := pkg.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	// open database
+	store := postgres.NewStore(config)
+	if err := store.OpenDB(context.Background()); err != nil {
+		log.Fatalf("Error opening database: %v", pkg.ErrorMessage(err))
+	}
+	defer store.CloseDB()
+
+	importRepo := postgres.NewProductImportRepository(store)
+	result, err := importRepo.ImportProducts(context.Background(), rows, repository.ProductImportOptions{
+		Mode:   *mode,
+		DryRun: *dryRun,
+		UserID: uint32(*userID),
+	})
+	if err != nil {
+		log.Fatalf("Error importing products: %v", pkg.ErrorMessage(err))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Fatalf("Error writing result: %v", err)
+	}
+
+	if len(result.Errors) > 0 {
+		store.CloseDB()
+		os.Exit(1)
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"time"
 
 	"github.com/flexGURU/flower-haven/backend/internal/handlers"
+	"github.com/flexGURU/flower-haven/backend/internal/notifier"
 	"github.com/flexGURU/flower-haven/backend/internal/postgres"
+	"github.com/flexGURU/flower-haven/backend/internal/scheduler"
+	"github.com/flexGURU/flower-haven/backend/internal/worker"
 	"github.com/flexGURU/flower-haven/backend/pkg"
 )
 
@@ -38,14 +41,31 @@ func main() {
 	// initialize repo
 	postgresRepo := postgres.NewPostgresRepo(store)
 
+	// start background jobs
+	jobs := worker.NewWorker(100)
+	jobs.Start()
+
 	// start server
-	server := handlers.NewServer(config, tokenMaker, postgresRepo)
+	server := handlers.NewServer(config, tokenMaker, postgresRepo, jobs)
 
 	log.Println("starting server at address: ", config.SERVER_ADDRESS)
 	if err := server.Start(); err != nil {
 		log.Fatalf("Error starting server: %v", err)
 	}
 
+	// start scheduled jobs
+	scheduled, err := scheduler.NewScheduler(
+		config,
+		postgresRepo.StockBatchRepository,
+		postgresRepo.LowStockRepository,
+		postgresRepo.UserRepository,
+		notifier.NewLogNotifier(),
+	)
+	if err != nil {
+		log.Fatalf("Error creating scheduler: %v", err)
+	}
+	scheduled.Start()
+
 	// token, _ := tokenMaker.CreateToken(1, "test@test.com", true, 10*time.Hour)
 	// log.Println("token: ", token)
 
@@ -60,5 +80,14 @@ func main() {
 		log.Fatalf("Error stopping server: %v", err)
 	}
 
+	if err := scheduled.Stop(ctx); err != nil {
+		log.Fatalf("Error stopping scheduler: %v", err)
+	}
+
+	// the server is stopped, so no more jobs are queued
+	if err := jobs.Stop(ctx); err != nil {
+		log.Fatalf("Error stopping worker: %v", err)
+	}
+
 	os.Exit(0)
 }
@@ -0,0 +1,51 @@
+package blobstore
+
+import (
+	"context"
+	"log"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+// NewBlobStore creates the blob store selected by BLOB_STORE, local or s3.
+func NewBlobStore(config pkg.Config) (services.IBlobStore, error) {
+	switch config.BLOB_STORE {
+	case "", "local":
+		return NewLocalBlobStore(config.UPLOAD_DIR, config.UPLOAD_URL), nil
+	case "s3":
+		return NewS3BlobStore(config.S3_ENDPOINT, config.S3_ACCESS_KEY, config.S3_SECRET_KEY, config.S3_BUCKET, config.S3_REGION, config.S3_USE_SSL, config.S3_PUBLIC_URL)
+	default:
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "unknown blob store %s", config.BLOB_STORE)
+	}
+}
+
+// RemoveOrphanImages deletes the images their owner no longer uses from the
+// store and the images table, of every owner when ownerType is empty. It
+// carries on past images it fails to delete and returns how many it removed.
+func RemoveOrphanImages(ctx context.Context, store services.IBlobStore, repo repository.ImageRepository, ownerType string, ownerID uint32) (int, error) {
+	orphans, err := repo.ListOrphanImages(ctx, ownerType, ownerID)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, image := range orphans {
+		if err := store.Delete(ctx, image.ThumbnailKey); err != nil {
+			log.Printf("error removing orphan image %d: %v", image.ID, err)
+			continue
+		}
+		if err := store.Delete(ctx, image.Key); err != nil {
+			log.Printf("error removing orphan image %d: %v", image.ID, err)
+			continue
+		}
+		if err := repo.DeleteImage(ctx, int64(image.ID)); err != nil {
+			log.Printf("error removing orphan image %d: %v", image.ID, err)
+			continue
+		}
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,90 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+var _ services.IBlobStore = (*LocalBlobStore)(nil)
+
+// LocalBlobStore keeps files in a directory on disk, the server serves the
+// directory at baseURL.
+type LocalBlobStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalBlobStore(dir, baseURL string) services.IBlobStore {
+	return &LocalBlobStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (ls *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating upload directory: %s", err.Error())
+	}
+
+	// write next to the target and rename, so a failed upload never leaves a
+	// partial file behind
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating file: %s", err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error writing file: %s", err.Error())
+	}
+	if err := tmp.Close(); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error writing file: %s", err.Error())
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error saving file: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (ls *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting file: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (ls *LocalBlobStore) URL(key string) string {
+	return ls.baseURL + "/" + key
+}
+
+// path maps a key to a file in the store's directory, refusing keys that
+// would escape it.
+func (ls *LocalBlobStore) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", pkg.Errorf(pkg.INVALID_ERROR, "invalid blob key %q", key)
+	}
+
+	return filepath.Join(ls.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,77 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+var _ services.IBlobStore = (*S3BlobStore)(nil)
+
+// S3BlobStore keeps files in a bucket of an S3 compatible service such as AWS
+// S3 or MinIO. Objects are served from publicURL, which defaults to the
+// bucket's path on the endpoint.
+type S3BlobStore struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+func NewS3BlobStore(endpoint, accessKey, secretKey, bucket, region string, useSSL bool, publicURL string) (services.IBlobStore, error) {
+	if endpoint == "" || bucket == "" {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "s3 endpoint and bucket cannot be empty")
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating s3 client: %s", err.Error())
+	}
+
+	if publicURL == "" {
+		scheme := "http"
+		if useSSL {
+			scheme = "https"
+		}
+		publicURL = fmt.Sprintf("%s://%s/%s", scheme, endpoint, bucket)
+	}
+
+	return &S3BlobStore{
+		client:    client,
+		bucket:    bucket,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}, nil
+}
+
+func (ss *S3BlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := ss.client.PutObject(ctx, ss.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error uploading %s: %s", key, err.Error())
+	}
+
+	return nil
+}
+
+func (ss *S3BlobStore) Delete(ctx context.Context, key string) error {
+	if err := ss.client.RemoveObject(ctx, ss.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting %s: %s", key, err.Error())
+	}
+
+	return nil
+}
+
+func (ss *S3BlobStore) URL(key string) string {
+	return ss.publicURL + "/" + key
+}
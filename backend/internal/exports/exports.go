@@ -0,0 +1,183 @@
+package exports
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ContentType is the media type of format, empty when it is not one of the
+// formats supported.
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// NewTableWriter writes an export in format to w. XLSX exports are named
+// after sheet.
+func NewTableWriter(format string, w io.Writer, sheet string) (services.ITableWriter, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheet)
+	default:
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "format must be one of %s or %s", FormatCSV, FormatXLSX)
+	}
+}
+
+var _ services.ITableWriter = (*csvWriter)(nil)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch value := deref(value).(type) {
+		case nil:
+			record[i] = ""
+		case string:
+			record[i] = escapeFormula(value)
+		case time.Time:
+			record[i] = value.Format(time.RFC3339)
+		default:
+			record[i] = fmt.Sprint(value)
+		}
+	}
+
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+var _ services.ITableWriter = (*xlsxWriter)(nil)
+
+// xlsxWriter writes through excelize's stream writer, which moves the rows
+// written to a temporary file once they outgrow its buffer.
+type xlsxWriter struct {
+	w         io.Writer
+	file      *excelize.File
+	stream    *excelize.StreamWriter
+	timeStyle int
+	row       int
+}
+
+func newXLSXWriter(w io.Writer, sheet string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error naming sheet: %s", err.Error())
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating sheet writer: %s", err.Error())
+	}
+
+	timeFormat := "yyyy-mm-dd hh:mm"
+	timeStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &timeFormat})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating time style: %s", err.Error())
+	}
+
+	return &xlsxWriter{
+		w:         w,
+		file:      file,
+		stream:    stream,
+		timeStyle: timeStyle,
+		row:       0,
+	}, nil
+}
+
+func (xw *xlsxWriter) WriteRow(values ...any) error {
+	cells := make([]any, len(values))
+	for i, value := range values {
+		switch value := deref(value).(type) {
+		case time.Time:
+			cells[i] = excelize.Cell{StyleID: xw.timeStyle, Value: value.UTC()}
+		case pkg.Money:
+			// amounts are numbers so that they can be summed in the sheet
+			amount, err := strconv.ParseFloat(value.String(), 64)
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amount %s: %s", value, err.Error())
+			}
+			cells[i] = amount
+		case pkg.Currency:
+			cells[i] = string(value)
+		case string:
+			cells[i] = escapeFormula(value)
+		default:
+			cells[i] = value
+		}
+	}
+
+	xw.row++
+	cell, err := excelize.CoordinatesToCellName(1, xw.row)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error addressing row %d: %s", xw.row, err.Error())
+	}
+
+	if err := xw.stream.SetRow(cell, cells); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error writing row %d: %s", xw.row, err.Error())
+	}
+
+	return nil
+}
+
+func (xw *xlsxWriter) Close() error {
+	defer xw.file.Close()
+
+	if err := xw.stream.Flush(); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error completing sheet: %s", err.Error())
+	}
+
+	if err := xw.file.Write(xw.w); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error writing workbook: %s", err.Error())
+	}
+
+	return nil
+}
+
+// escapeFormula keeps text that a spreadsheet would read as a formula, such as
+// a customer named "=HYPERLINK(...)", as text by prefixing it with a quote.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// deref turns pointers into the values they point to, or nil.
+func deref(value any) any {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Pointer {
+		return value
+	}
+	if v.IsNil() {
+		return nil
+	}
+
+	return v.Elem().Interface()
+}
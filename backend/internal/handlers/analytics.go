@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) getDashboardHandler(ctx *gin.Context) {
+	dashboard, err := s.repo.AnalyticsRepository.GetDashboard(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": dashboard})
+}
+
+func (s *Server) getRevenueAnalyticsHandler(ctx *gin.Context) {
+	filter, err := bindAnalyticsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	revenue, err := s.repo.AnalyticsRepository.GetRevenue(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": revenue, "granularity": filter.Granularity})
+}
+
+func (s *Server) getOrdersByStatusAnalyticsHandler(ctx *gin.Context) {
+	filter, err := bindAnalyticsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	counts, err := s.repo.AnalyticsRepository.GetOrdersByStatus(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": counts})
+}
+
+func (s *Server) getAverageOrderValueAnalyticsHandler(ctx *gin.Context) {
+	filter, err := bindAnalyticsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	values, err := s.repo.AnalyticsRepository.GetAverageOrderValues(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": values})
+}
+
+func (s *Server) getTopProductsAnalyticsHandler(ctx *gin.Context) {
+	filter, err := bindAnalyticsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	products, err := s.repo.AnalyticsRepository.GetTopProducts(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": products})
+}
+
+func (s *Server) getTopCategoriesAnalyticsHandler(ctx *gin.Context) {
+	filter, err := bindAnalyticsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	categories, err := s.repo.AnalyticsRepository.GetTopCategories(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": categories})
+}
+
+func (s *Server) getSubscriptionAnalyticsHandler(ctx *gin.Context) {
+	filter, err := bindAnalyticsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	metrics, err := s.repo.AnalyticsRepository.GetSubscriptionMetrics(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": metrics})
+}
+
+func (s *Server) getCustomerAnalyticsHandler(ctx *gin.Context) {
+	filter, err := bindAnalyticsFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	metrics, err := s.repo.AnalyticsRepository.GetCustomerMetrics(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": metrics})
+}
+
+// bindAnalyticsFilter reads the days "from" through "to", both YYYY-MM-DD and
+// by default the last 30 days, the granularity (day by default) and the limit
+// (10 by default) of an analytics endpoint.
+func bindAnalyticsFilter(ctx *gin.Context) (*repository.AnalyticsFilter, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	from, to := today.AddDate(0, 0, -29), today
+
+	for key, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		if value := ctx.Query(key); value != "" {
+			day, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid %s format, expected YYYY-MM-DD", key)
+			}
+			*target = day
+		}
+	}
+
+	limit, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid limit: %s", err.Error())
+	}
+
+	return &repository.AnalyticsFilter{
+		From:        from,
+		To:          to.AddDate(0, 0, 1),
+		Granularity: ctx.DefaultQuery("granularity", repository.GranularityDay),
+		Limit:       limit,
+	}, nil
+}
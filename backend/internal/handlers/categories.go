@@ -12,6 +12,8 @@ type createCategoryReq struct {
 	Name        string   `json:"name" binding:"required"`
 	Description string   `json:"description" binding:"required"`
 	ImageUrl    []string `json:"image_url" binding:"required"`
+	TaxClassID  *uint32  `json:"tax_class_id,omitempty"`
+	ParentID    *uint32  `json:"parent_id,omitempty"`
 }
 
 func (s *Server) createCategoryHandler(ctx *gin.Context) {
@@ -25,6 +27,8 @@ func (s *Server) createCategoryHandler(ctx *gin.Context) {
 		Name:        req.Name,
 		Description: req.Description,
 		ImageUrl:    req.ImageUrl,
+		TaxClassID:  req.TaxClassID,
+		ParentID:    req.ParentID,
 	}
 
 	newCategory, err := s.repo.CategoryRepository.CreateCategory(ctx, category)
@@ -89,6 +93,16 @@ func (s *Server) listCategoriesHandler(ctx *gin.Context) {
 	})
 }
 
+func (s *Server) getCategoryTreeHandler(ctx *gin.Context) {
+	tree, err := s.repo.CategoryRepository.GetCategoryTree(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": tree})
+}
+
 func (s *Server) updateCategoryHandler(ctx *gin.Context) {
 	id, err := pkg.StringToUint32(ctx.Param("id"))
 	if err != nil {
@@ -109,6 +123,10 @@ func (s *Server) updateCategoryHandler(ctx *gin.Context) {
 		return
 	}
 
+	if req.ImageUrl != nil {
+		s.removeOrphanImages(ctx, repository.ImageOwnerCategory, req.ID)
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": updatedCategory})
 }
 
@@ -127,3 +145,13 @@ func (s *Server) deleteCategoryHandler(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
 }
+
+func (s *Server) recountCategoryProductsHandler(ctx *gin.Context) {
+	repaired, err := s.repo.CategoryRepository.RecountProductCounts(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": gin.H{"repaired_categories": repaired}})
+}
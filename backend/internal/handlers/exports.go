@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/exports"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exportPageSize = 500
+	// exports of large ranges outlast the server's write timeout
+	exportWriteTimeout = 10 * time.Minute
+)
+
+// exportOrdersHandler exports the orders matching the filters of the order
+// list as CSV or XLSX.
+func (s *Server) exportOrdersHandler(ctx *gin.Context) {
+	filter, err := bindOrderFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	header := []string{"id", "created_at", "status", "payment_status", "currency", "net_amount", "tax_amount", "total_amount", "buyer_name", "buyer_phone_number", "buyer_email", "recipient_name", "recipient_phone_number", "delivery_date", "time_slot", "address_area", "address_street", "payment_reference"}
+	exportTable(ctx, "orders", header, filter.Pagination, func() ([]*repository.Order, *pkg.Pagination, error) {
+		return s.repo.OrderRepository.ListOrders(ctx, filter)
+	}, func(order *repository.Order) []any {
+		return []any{
+			order.ID,
+			order.CreatedAt,
+			order.Status,
+			order.PaymentStatus,
+			order.Currency,
+			order.NetAmount,
+			order.TaxAmount,
+			order.TotalAmount,
+			order.Buyer.Name,
+			order.Buyer.PhoneNumber,
+			order.Buyer.Email,
+			order.Recipient.Name,
+			order.Recipient.PhoneNumber,
+			order.DeliveryDate,
+			order.TimeSlot,
+			order.DeliveryAddress.Area,
+			order.DeliveryAddress.Street,
+			order.PaymentReference,
+		}
+	})
+}
+
+// exportPaymentsHandler exports the payments matching the filters of the
+// payment list as CSV or XLSX.
+func (s *Server) exportPaymentsHandler(ctx *gin.Context) {
+	filter, err := bindPaymentFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	header := []string{"id", "paid_at", "payment_method", "currency", "amount", "order_id", "user_subscription_id", "description", "created_at"}
+	exportTable(ctx, "payments", header, filter.Pagination, func() ([]*repository.Payment, *pkg.Pagination, error) {
+		return s.repo.PaymentRepository.ListPayments(ctx, filter)
+	}, func(payment *repository.Payment) []any {
+		return []any{
+			payment.ID,
+			payment.PaidAt,
+			payment.PaymentMethod,
+			payment.Currency,
+			payment.Amount,
+			payment.OrderID,
+			payment.UserSubscriptionID,
+			payment.Description,
+			payment.CreatedAt,
+		}
+	})
+}
+
+// exportCustomersHandler exports the users matching the filters of the user
+// list as CSV or XLSX, leaving out admins unless is_admin is given.
+func (s *Server) exportCustomersHandler(ctx *gin.Context) {
+	filter, err := bindUserFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if filter.IsAdmin == nil {
+		isAdmin := false
+		filter.IsAdmin = &isAdmin
+	}
+
+	header := []string{"id", "name", "email", "phone_number", "address", "is_admin", "is_active", "created_at"}
+	exportTable(ctx, "customers", header, filter.Pagination, func() ([]*repository.User, *pkg.Pagination, error) {
+		return s.repo.UserRepository.ListUsers(ctx, filter)
+	}, func(user *repository.User) []any {
+		return []any{
+			user.ID,
+			user.Name,
+			user.Email,
+			user.PhoneNumber,
+			user.Address,
+			user.IsAdmin,
+			user.IsActive,
+			user.CreatedAt,
+		}
+	})
+}
+
+// exportTable writes the rows of a list as a CSV or XLSX file, picked by the
+// "format" query parameter. The list is read a page at a time following its
+// cursor, without counting it, so an export of any size is never held in
+// memory.
+func exportTable[T any](ctx *gin.Context, name string, header []string, pagination *pkg.Pagination, list func() ([]T, *pkg.Pagination, error), row func(T) []any) {
+	format := ctx.DefaultQuery("format", exports.FormatCSV)
+	table, err := exports.NewTableWriter(format, ctx.Writer, name)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil {
+		log.Printf("failed to extend the write deadline of the %s export: %v", name, err)
+	}
+
+	ctx.Header("Content-Type", exports.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-%s.%s", name, time.Now().Format("2006-01-02"), format)))
+	ctx.Status(http.StatusOK)
+
+	pagination.Page = 1
+	pagination.PageSize = exportPageSize
+	pagination.Cursor = nil
+	pagination.SkipTotal = true
+
+	err = func() error {
+		values := make([]any, len(header))
+		for i, column := range header {
+			values[i] = column
+		}
+		if err := table.WriteRow(values...); err != nil {
+			return err
+		}
+
+		for {
+			rows, page, err := list()
+			if err != nil {
+				return err
+			}
+
+			for _, r := range rows {
+				if err := table.WriteRow(row(r)...); err != nil {
+					return err
+				}
+			}
+
+			if page.NextCursor == "" {
+				return table.Close()
+			}
+
+			if pagination.Cursor, err = pkg.DecodeCursor(page.NextCursor, pagination.Sort); err != nil {
+				return err
+			}
+		}
+	}()
+	if err == nil {
+		return
+	}
+
+	// XLSX, and CSV until its buffer fills, is only written out at the end
+	if !ctx.Writer.Written() {
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	// the client is left with a truncated file
+	log.Printf("failed to export %s: %v", name, err)
+	ctx.Abort()
+}
@@ -1,18 +1,36 @@
 package handlers
 
 import (
-	"net/http"
-
 	"github.com/flexGURU/flower-haven/backend/pkg"
 	"github.com/gin-gonic/gin"
 )
 
-func (s *Server) getDashboardDataHandler(ctx *gin.Context) {
-	data, err := s.repo.ProductRepository.GetDashboardData(ctx)
+// authUserID is the id of the signed in user, 0 on public routes.
+func authUserID(ctx *gin.Context) uint32 {
+	authPayload, _ := ctx.Get(authorizationPayloadKey)
+	if payload, ok := authPayload.(*pkg.Payload); ok {
+		return payload.UserID
+	}
+
+	return 0
+}
+
+// bindListOrder reads the sort and cursor query parameters of a list endpoint
+// into pagination, sort fields must be one of fields.
+func bindListOrder(ctx *gin.Context, pagination *pkg.Pagination, fields []string) error {
+	sort, err := pkg.ParseSort(ctx.Query("sort"), fields...)
 	if err != nil {
-		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
-		return
+		return err
+	}
+	pagination.Sort = sort
+
+	if raw := ctx.Query("cursor"); raw != "" {
+		cursor, err := pkg.DecodeCursor(raw, sort)
+		if err != nil {
+			return err
+		}
+		pagination.Cursor = cursor
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"data": data})
+	return nil
 }
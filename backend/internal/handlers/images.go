@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/internal/blobstore"
+	"github.com/flexGURU/flower-haven/backend/internal/images"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (s *Server) uploadProductImagesHandler(ctx *gin.Context) {
+	s.uploadImages(ctx, repository.ImageOwnerProduct, "products")
+}
+
+func (s *Server) uploadCategoryImagesHandler(ctx *gin.Context) {
+	s.uploadImages(ctx, repository.ImageOwnerCategory, "categories")
+}
+
+func (s *Server) listProductImagesHandler(ctx *gin.Context) {
+	s.listImages(ctx, repository.ImageOwnerProduct)
+}
+
+func (s *Server) listCategoryImagesHandler(ctx *gin.Context) {
+	s.listImages(ctx, repository.ImageOwnerCategory)
+}
+
+// uploadImages stores the files of the multipart "images" field with their
+// thumbnails and appends them to the image_url of the product or category.
+func (s *Server) uploadImages(ctx *gin.Context, ownerType, dir string) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid %s ID: %s", ownerType, err.Error())))
+		return
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid multipart form: %s", err.Error())))
+		return
+	}
+
+	files := form.File["images"]
+	if len(files) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "no files in the images field")))
+		return
+	}
+
+	// blobs already stored are removed again when a later step fails
+	var keys []string
+	removeBlobs := func() {
+		for _, key := range keys {
+			if err := s.blobs.Delete(context.Background(), key); err != nil {
+				log.Printf("error removing blob %s: %v", key, err)
+			}
+		}
+	}
+
+	uploaded := make([]*repository.Image, len(files))
+	for i, file := range files {
+		image, err := s.storeImage(ctx, dir, id, file)
+		if image != nil {
+			keys = append(keys, image.Key, image.ThumbnailKey)
+		}
+		if err != nil {
+			removeBlobs()
+			ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+			return
+		}
+		uploaded[i] = image
+	}
+
+	newImages, err := s.repo.ImageRepository.AddImages(ctx, ownerType, id, uploaded)
+	if err != nil {
+		removeBlobs()
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": newImages})
+}
+
+// storeImage validates an uploaded image and stores it and its thumbnail. The
+// returned image holds the keys of whatever was stored, even on error.
+func (s *Server) storeImage(ctx context.Context, dir string, ownerID uint32, file *multipart.FileHeader) (*repository.Image, error) {
+	maxBytes := s.config.UPLOAD_MAX_BYTES
+	if file.Size > maxBytes {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "image %s is larger than %d bytes", file.Filename, maxBytes)
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "error reading image %s: %s", file.Filename, err.Error())
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxBytes+1))
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "error reading image %s: %s", file.Filename, err.Error())
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "image %s is larger than %d bytes", file.Filename, maxBytes)
+	}
+
+	processed, err := images.Process(data)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.ErrorCode(err), "image %s: %s", file.Filename, pkg.ErrorMessage(err))
+	}
+
+	name := uuid.NewString()
+	image := &repository.Image{
+		Key:          fmt.Sprintf("%s/%d/%s%s", dir, ownerID, name, images.Extensions[processed.ContentType]),
+		ThumbnailKey: fmt.Sprintf("%s/%d/%s_thumb%s", dir, ownerID, name, images.Extensions[processed.ThumbnailContentType]),
+		ContentType:  processed.ContentType,
+		Size:         int64(len(data)),
+		Width:        int32(processed.Width),
+		Height:       int32(processed.Height),
+	}
+	image.URL = s.blobs.URL(image.Key)
+	image.ThumbnailURL = s.blobs.URL(image.ThumbnailKey)
+
+	if err := s.blobs.Put(ctx, image.Key, bytes.NewReader(data), int64(len(data)), processed.ContentType); err != nil {
+		return nil, err
+	}
+	if err := s.blobs.Put(ctx, image.ThumbnailKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), processed.ThumbnailContentType); err != nil {
+		return image, err
+	}
+
+	return image, nil
+}
+
+func (s *Server) listImages(ctx *gin.Context, ownerType string) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid %s ID: %s", ownerType, err.Error())))
+		return
+	}
+
+	ownerImages, err := s.repo.ImageRepository.ListImages(ctx, ownerType, id)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": ownerImages})
+}
+
+// removeOrphanImages deletes the uploaded images a product or category update
+// dropped from its image_url. Failures are only logged, the update stands.
+func (s *Server) removeOrphanImages(ctx context.Context, ownerType string, ownerID uint32) {
+	if _, err := blobstore.RemoveOrphanImages(ctx, s.blobs, s.repo.ImageRepository, ownerType, ownerID); err != nil {
+		log.Printf("error removing orphan images of %s %d: %v", ownerType, ownerID, err)
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/internal/imports"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+// importProductsHandler creates or updates, keyed by SKU, the products in the
+// CSV file of the multipart "file" field. The "mode" is atomic (by default)
+// or per_row, and with dry_run=true nothing is saved. Rows with problems are
+// listed in the errors of the result rather than failing the request.
+func (s *Server) importProductsHandler(ctx *gin.Context) {
+	dryRun := false
+	if value := ctx.Query("dry_run"); value != "" {
+		var err error
+		if dryRun, err = pkg.StringToBool(value); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid dry_run: %s", value)))
+			return
+		}
+	}
+
+	// leave room for the multipart headers around the file
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, imports.MaxProductFileSize+1<<20)
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "a csv file is required in the file field: %s", err.Error())))
+		return
+	}
+	if header.Size > imports.MaxProductFileSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "the file is larger than %d MB", imports.MaxProductFileSize>>20)))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(pkg.Errorf(pkg.INTERNAL_ERROR, "error opening the uploaded file: %s", err.Error())))
+		return
+	}
+	defer file.Close()
+
+	rows, err := imports.ReadProductRows(file)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	result, err := s.repo.ProductImportRepository.ImportProducts(ctx, rows, repository.ProductImportOptions{
+		Mode:   ctx.DefaultQuery("mode", repository.ImportModeAtomic),
+		DryRun: dryRun,
+		UserID: authUserID(ctx),
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	if !result.DryRun {
+		for _, product := range result.Updated {
+			s.removeOrphanImages(ctx, repository.ImageOwnerProduct, product.ID)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": result})
+}
@@ -68,6 +68,24 @@ func authMiddleware(maker pkg.JWTMaker) gin.HandlerFunc {
 	}
 }
 
+// adminMiddleware lets only admins through, it runs after authMiddleware.
+func adminMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload, _ := ctx.Get(authorizationPayloadKey)
+		payload, ok := authPayload.(*pkg.Payload)
+		if !ok || !payload.IsAdmin {
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				errorResponse(pkg.Errorf(pkg.FORBIDDEN_ERROR, "only admins can access this resource")),
+			)
+
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
 func CORSmiddleware(frontendUrls []string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		origin := ctx.Request.Header.Get("Origin")
@@ -9,26 +9,53 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type orderContactReq struct {
+	Name        string  `json:"name" binding:"required"`
+	PhoneNumber string  `json:"phone_number" binding:"required"`
+	Email       *string `json:"email,omitempty" binding:"omitempty,email"`
+}
+
+type orderAddressReq struct {
+	Area      string   `json:"area" binding:"required"`
+	Street    *string  `json:"street,omitempty"`
+	Building  *string  `json:"building,omitempty"`
+	Landmark  *string  `json:"landmark,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty" binding:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude,omitempty" binding:"omitempty,longitude"`
+}
+
 type createOrderReq struct {
-	UserName        string  `json:"user_name" binding:"required"`
-	UserPhoneNumber string  `json:"user_phone_number" binding:"required"`
-	PaymentStatus   bool    `json:"payment_status"`
-	Status          string  `json:"status" binding:"required"`
-	DeliveryDate    string  `json:"delivery_date" binding:"required"` // parse into time.Time
-	TimeSlot        string  `json:"time_slot" binding:"required"`
-	ShippingAddress *string `json:"shipping_address,omitempty"`
-	ByAdmin         bool    `json:"by_admin"`
-	Total           float64 `json:"total" binding:"required"`
-	Reference       string  `json:"reference" binding:"required"`
+	Buyer           orderContactReq `json:"buyer" binding:"required"`
+	Recipient       orderContactReq `json:"recipient" binding:"required"`
+	Status          string          `json:"status" binding:"required"`
+	DeliveryDate    string          `json:"delivery_date" binding:"required"` // parse into time.Time
+	TimeSlot        string          `json:"time_slot" binding:"required"`
+	DeliveryAddress orderAddressReq `json:"delivery_address" binding:"required"`
+	ByAdmin         bool            `json:"by_admin"`
+	Total           pkg.Money       `json:"total"`
+	Currency        string          `json:"currency"`
+	Reference       string          `json:"reference" binding:"required"`
 
 	Items []struct {
-		ProductID     uint32  `json:"product_id" binding:"required"`
-		StemID        *uint32 `json:"stem_id,omitempty"` // flowers only
-		PaymentMethod string  `json:"payment_method" binding:"required,oneof=normal subscription"`
-		Frequency     string  `json:"frequency,omitempty"` // required if subscription
-		Quantity      int32   `json:"quantity" binding:"required"`
-		Amount        float64 `json:"amount" binding:"required"`
-	} `json:"items" binding:"required"`
+		ProductID     uint32                  `json:"product_id" binding:"required"`
+		VariantID     *uint32                 `json:"variant_id,omitempty"` // products with variants only
+		PaymentMethod string                  `json:"payment_method" binding:"required,oneof=normal subscription"`
+		Frequency     string                  `json:"frequency,omitempty"` // required if subscription
+		Quantity      int32                   `json:"quantity" binding:"required,gt=0"`
+		Amount        pkg.Money               `json:"amount"`
+		MessageCard   *repository.MessageCard `json:"message_card,omitempty"` // message card products only
+		Children      []orderChildItemReq     `json:"children,omitempty" binding:"dive"`
+	} `json:"items" binding:"required,dive"`
+}
+
+// orderChildItemReq is an add-on or message card attached to an order item. It
+// is paid for the same way as its parent.
+type orderChildItemReq struct {
+	ProductID   uint32                  `json:"product_id" binding:"required"`
+	VariantID   *uint32                 `json:"variant_id,omitempty"`
+	Quantity    int32                   `json:"quantity" binding:"required,gt=0"`
+	Amount      pkg.Money               `json:"amount"`                 // not needed for quotes
+	MessageCard *repository.MessageCard `json:"message_card,omitempty"` // message card products only
 }
 
 func (s *Server) createOrderHandler(ctx *gin.Context) {
@@ -38,7 +65,19 @@ func (s *Server) createOrderHandler(ctx *gin.Context) {
 		return
 	}
 
-	paymentStatus, err := s.ps.VerifyPayment(req.Reference, int64(req.Total*100))
+	if err := validateOrderPhoneNumbers(&req.Buyer.PhoneNumber, &req.Recipient.PhoneNumber); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	currency, err := pkg.ParseCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	total := req.Total.WithCurrency(currency)
+	paymentStatus, err := s.ps.VerifyPayment(req.Reference, total)
 	if err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
 		return
@@ -56,14 +95,31 @@ func (s *Server) createOrderHandler(ctx *gin.Context) {
 	}
 
 	order := &repository.Order{
-		UserName:        req.UserName,
-		UserPhoneNumber: req.UserPhoneNumber,
-		PaymentStatus:   req.PaymentStatus,
-		Status:          req.Status,
-		DeliveryDate:    deliveryDate,
-		TimeSlot:        req.TimeSlot,
-		ByAdmin:         req.ByAdmin,
-		ShippingAddress: req.ShippingAddress,
+		Buyer: repository.Contact{
+			Name:        req.Buyer.Name,
+			PhoneNumber: req.Buyer.PhoneNumber,
+			Email:       req.Buyer.Email,
+		},
+		Recipient: repository.Contact{
+			Name:        req.Recipient.Name,
+			PhoneNumber: req.Recipient.PhoneNumber,
+		},
+		Currency:      currency,
+		TotalAmount:   total,
+		PaymentStatus: true, // verified above
+		Status:        req.Status,
+		DeliveryDate:  deliveryDate,
+		TimeSlot:      req.TimeSlot,
+		ByAdmin:       req.ByAdmin,
+		DeliveryAddress: repository.Address{
+			Area:      req.DeliveryAddress.Area,
+			Street:    req.DeliveryAddress.Street,
+			Building:  req.DeliveryAddress.Building,
+			Landmark:  req.DeliveryAddress.Landmark,
+			Latitude:  req.DeliveryAddress.Latitude,
+			Longitude: req.DeliveryAddress.Longitude,
+		},
+		PaymentReference: &req.Reference,
 	}
 
 	// Build order items
@@ -78,13 +134,15 @@ func (s *Server) createOrderHandler(ctx *gin.Context) {
 		orderItem := repository.OrderItem{
 			ProductID:     item.ProductID,
 			Quantity:      item.Quantity,
-			Amount:        item.Amount,
+			Amount:        item.Amount.WithCurrency(currency),
 			PaymentMethod: item.PaymentMethod,
 			Frequency:     item.Frequency,
+			MessageCard:   item.MessageCard,
+			Children:      childOrderItems(item.Children, currency),
 		}
 
-		if item.StemID != nil {
-			orderItem.StemID = *item.StemID
+		if item.VariantID != nil {
+			orderItem.VariantID = *item.VariantID
 		}
 
 		orderItems = append(orderItems, orderItem)
@@ -96,15 +154,68 @@ func (s *Server) createOrderHandler(ctx *gin.Context) {
 		return
 	}
 
+	s.notifyOrderPaid(newOrder)
+
 	ctx.JSON(http.StatusOK, gin.H{"data": newOrder})
 }
 
+type quoteOrderReq struct {
+	Currency string `json:"currency"`
+
+	Items []struct {
+		ProductID     uint32              `json:"product_id" binding:"required"`
+		VariantID     *uint32             `json:"variant_id,omitempty"` // products with variants only
+		PaymentMethod string              `json:"payment_method" binding:"required,oneof=normal subscription"`
+		Frequency     string              `json:"frequency,omitempty"`
+		Quantity      int32               `json:"quantity" binding:"required,gt=0"`
+		Children      []orderChildItemReq `json:"children,omitempty" binding:"dive"`
+	} `json:"items" binding:"required,dive"`
+}
+
+func (s *Server) quoteOrderHandler(ctx *gin.Context) {
+	var req quoteOrderReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	currency, err := pkg.ParseCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var orderItems []repository.OrderItem
+	for _, item := range req.Items {
+		orderItem := repository.OrderItem{
+			ProductID:     item.ProductID,
+			Quantity:      item.Quantity,
+			PaymentMethod: item.PaymentMethod,
+			Frequency:     item.Frequency,
+			Children:      childOrderItems(item.Children, currency),
+		}
+
+		if item.VariantID != nil {
+			orderItem.VariantID = *item.VariantID
+		}
+
+		orderItems = append(orderItems, orderItem)
+	}
+
+	quote, err := s.repo.OrderRepository.QuoteOrder(ctx, currency, orderItems)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": quote})
+}
+
 func (s *Server) listOrdersHandler(ctx *gin.Context) {
-	filter := &repository.OrderFilter{
-		Pagination:    &pkg.Pagination{},
-		Search:        nil,
-		PaymentStatus: nil,
-		Status:        nil,
+	filter, err := bindOrderFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
 	pageNoStr := ctx.DefaultQuery("page", "1")
@@ -125,6 +236,30 @@ func (s *Server) listOrdersHandler(ctx *gin.Context) {
 	}
 	filter.Pagination.PageSize = pageSize
 
+	orders, pagination, err := s.repo.OrderRepository.ListOrders(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":       orders,
+		"pagination": pagination,
+	})
+}
+
+// bindOrderFilter reads the filters and sort of the order list, start_date and
+// end_date are the YYYY-MM-DD days the orders were placed from and through.
+func bindOrderFilter(ctx *gin.Context) (*repository.OrderFilter, error) {
+	filter := &repository.OrderFilter{
+		Pagination:    &pkg.Pagination{},
+		Search:        nil,
+		PaymentStatus: nil,
+		Status:        nil,
+		StartDate:     nil,
+		EndDate:       nil,
+	}
+
 	if search := ctx.Query("search"); search != "" {
 		filter.Search = &search
 	}
@@ -136,30 +271,53 @@ func (s *Server) listOrdersHandler(ctx *gin.Context) {
 	if paymentStatus := ctx.Query("payment_status"); paymentStatus != "" {
 		paymentStatusBool, err := pkg.StringToBool(paymentStatus)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, errorResponse(err))
-			return
+			return nil, err
 		}
 		filter.PaymentStatus = &paymentStatusBool
 	}
 
-	orders, pagination, err := s.repo.OrderRepository.ListOrders(ctx, filter)
-	if err != nil {
-		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
-		return
+	for key, target := range map[string]**time.Time{"start_date": &filter.StartDate, "end_date": &filter.EndDate} {
+		if value := ctx.Query(key); value != "" {
+			day, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid %s format, expected YYYY-MM-DD", key)
+			}
+			*target = &day
+		}
+	}
+	if filter.EndDate != nil {
+		endDate := filter.EndDate.AddDate(0, 0, 1)
+		filter.EndDate = &endDate
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"data":       orders,
-		"pagination": pagination,
-	})
+	if err := bindListOrder(ctx, filter.Pagination, repository.OrderSortFields); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+type updateOrderContactReq struct {
+	Name        *string `json:"name"`
+	PhoneNumber *string `json:"phone_number"`
+	Email       *string `json:"email" binding:"omitempty,email"`
+}
+
+type updateOrderAddressReq struct {
+	Area      *string  `json:"area"`
+	Street    *string  `json:"street"`
+	Building  *string  `json:"building"`
+	Landmark  *string  `json:"landmark"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,longitude"`
 }
 
 type updateOrderReq struct {
-	UserName        *string `json:"user_name"`
-	UserPhoneNumber *string `json:"user_phone_number"`
-	PaymentStatus   *string `json:"payment_status"`
-	Status          *string `json:"status"`
-	ShippingAddress *string `json:"shipping_address,omitempty"`
+	Buyer           *updateOrderContactReq `json:"buyer"`
+	Recipient       *updateOrderContactReq `json:"recipient"`
+	PaymentStatus   *string                `json:"payment_status"`
+	Status          *string                `json:"status"`
+	DeliveryAddress *updateOrderAddressReq `json:"delivery_address"`
 }
 
 func (s *Server) updateOrderHandler(ctx *gin.Context) {
@@ -177,18 +335,33 @@ func (s *Server) updateOrderHandler(ctx *gin.Context) {
 
 	params := repository.UpdateOrder{
 		ID:              id,
-		UserName:        nil,
-		UserPhoneNumber: nil,
+		Buyer:           nil,
+		Recipient:       nil,
 		PaymentStatus:   nil,
 		Status:          nil,
-		ShippingAddress: nil,
+		DeliveryAddress: nil,
+		UpdatedBy:       authUserID(ctx),
 	}
 
-	if req.UserName != nil {
-		params.UserName = req.UserName
+	var buyerPhoneNumber, recipientPhoneNumber *string
+	if req.Buyer != nil {
+		params.Buyer = &repository.UpdateContact{
+			Name:        req.Buyer.Name,
+			PhoneNumber: req.Buyer.PhoneNumber,
+			Email:       req.Buyer.Email,
+		}
+		buyerPhoneNumber = req.Buyer.PhoneNumber
 	}
-	if req.UserPhoneNumber != nil {
-		params.UserPhoneNumber = req.UserPhoneNumber
+	if req.Recipient != nil {
+		params.Recipient = &repository.UpdateContact{
+			Name:        req.Recipient.Name,
+			PhoneNumber: req.Recipient.PhoneNumber,
+		}
+		recipientPhoneNumber = req.Recipient.PhoneNumber
+	}
+	if err := validateOrderPhoneNumbers(buyerPhoneNumber, recipientPhoneNumber); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 	if req.PaymentStatus != nil {
 		paymentStatus, err := pkg.StringToBool(*req.PaymentStatus)
@@ -201,8 +374,15 @@ func (s *Server) updateOrderHandler(ctx *gin.Context) {
 	if req.Status != nil {
 		params.Status = req.Status
 	}
-	if req.ShippingAddress != nil {
-		params.ShippingAddress = req.ShippingAddress
+	if req.DeliveryAddress != nil {
+		params.DeliveryAddress = &repository.UpdateAddress{
+			Area:      req.DeliveryAddress.Area,
+			Street:    req.DeliveryAddress.Street,
+			Building:  req.DeliveryAddress.Building,
+			Landmark:  req.DeliveryAddress.Landmark,
+			Latitude:  req.DeliveryAddress.Latitude,
+			Longitude: req.DeliveryAddress.Longitude,
+		}
 	}
 
 	updatedOrder, err := s.repo.OrderRepository.UpdateOrder(ctx, &params)
@@ -237,7 +417,7 @@ func (s *Server) deleteOrderHandler(ctx *gin.Context) {
 		return
 	}
 
-	err = s.repo.OrderRepository.DeleteOrder(ctx, int64(id))
+	err = s.repo.OrderRepository.DeleteOrder(ctx, int64(id), authUserID(ctx))
 	if err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
 		return
@@ -245,3 +425,122 @@ func (s *Server) deleteOrderHandler(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Order deleted successfully"})
 }
+
+type workSheetItem struct {
+	ProductName string                  `json:"product_name"`
+	Variant     string                  `json:"variant,omitempty"`
+	Quantity    int32                   `json:"quantity"`
+	IsAddOn     bool                    `json:"is_add_on"`
+	MessageCard *repository.MessageCard `json:"message_card,omitempty"`
+	Children    []workSheetItem         `json:"children,omitempty"` // packed with this item
+}
+
+type workSheetOrder struct {
+	OrderID         uint32             `json:"order_id"`
+	Status          string             `json:"status"`
+	TimeSlot        string             `json:"time_slot"`
+	Recipient       repository.Contact `json:"recipient"`
+	DeliveryAddress repository.Address `json:"delivery_address"`
+	Items           []workSheetItem    `json:"items"`
+}
+
+// getWorkSheetHandler lists what the florist has to prepare for the deliveries
+// of a day (today by default), including the text of every message card and
+// the components needed for the items made from a recipe.
+func (s *Server) getWorkSheetHandler(ctx *gin.Context) {
+	date := time.Now().UTC()
+	if dateStr := ctx.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid date format, expected YYYY-MM-DD")))
+			return
+		}
+		date = parsed
+	}
+
+	orders, err := s.repo.OrderRepository.ListOrdersByDeliveryDate(ctx, date)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	workSheet := make([]workSheetOrder, len(orders))
+	for i, order := range orders {
+		workSheet[i] = workSheetOrder{
+			OrderID:         order.ID,
+			Status:          order.Status,
+			TimeSlot:        order.TimeSlot,
+			Recipient:       order.Recipient,
+			DeliveryAddress: order.DeliveryAddress,
+			Items:           make([]workSheetItem, len(order.OrderItemsData)),
+		}
+
+		for j, item := range order.OrderItemsData {
+			workSheet[i].Items[j] = newWorkSheetItem(item)
+		}
+	}
+
+	components, err := s.repo.RecipeRepository.ListComponentsNeeded(ctx, date)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": workSheet, "components": components, "date": date.Format("2006-01-02")})
+}
+
+func newWorkSheetItem(item repository.OrderItem) workSheetItem {
+	workSheetItem := workSheetItem{
+		Quantity:    item.Quantity,
+		MessageCard: item.MessageCard,
+	}
+	if product := item.CurrentProductDetails; product != nil {
+		workSheetItem.ProductName = product.Name
+		workSheetItem.IsAddOn = product.IsAddOn
+		if len(product.Variants) > 0 {
+			workSheetItem.Variant = product.Variants[0].Label()
+		}
+	}
+
+	for _, child := range item.Children {
+		workSheetItem.Children = append(workSheetItem.Children, newWorkSheetItem(child))
+	}
+
+	return workSheetItem
+}
+
+// validateOrderPhoneNumbers checks the contact numbers given on an order, nil
+// numbers are the ones left unchanged on update.
+func validateOrderPhoneNumbers(buyerPhoneNumber, recipientPhoneNumber *string) error {
+	if buyerPhoneNumber != nil {
+		if err := pkg.ValidatePhoneNumber("buyer phone_number", *buyerPhoneNumber); err != nil {
+			return err
+		}
+	}
+
+	if recipientPhoneNumber != nil {
+		if err := pkg.ValidatePhoneNumber("recipient phone_number", *recipientPhoneNumber); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func childOrderItems(children []orderChildItemReq, currency pkg.Currency) []repository.OrderItem {
+	items := make([]repository.OrderItem, len(children))
+	for i, child := range children {
+		items[i] = repository.OrderItem{
+			ProductID:   child.ProductID,
+			Quantity:    child.Quantity,
+			Amount:      child.Amount.WithCurrency(currency),
+			MessageCard: child.MessageCard,
+		}
+
+		if child.VariantID != nil {
+			items[i].VariantID = *child.VariantID
+		}
+	}
+
+	return items
+}
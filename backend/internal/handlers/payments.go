@@ -10,12 +10,13 @@ import (
 )
 
 type createPaymentReq struct {
-	OrderID        *uint32 `json:"order_id"`
-	SubscriptionId *uint32 `json:"subscription_id"`
-	Description    *string `json:"description"`
-	PaymentMethod  string  `json:"payment_method" binding:"required"`
-	Amount         float64 `json:"amount" binding:"required"`
-	PaidAt         *string `json:"paid_at"`
+	OrderID        *uint32   `json:"order_id"`
+	SubscriptionId *uint32   `json:"subscription_id"`
+	Description    *string   `json:"description"`
+	PaymentMethod  string    `json:"payment_method" binding:"required"`
+	Amount         pkg.Money `json:"amount"`
+	Currency       string    `json:"currency"`
+	PaidAt         *string   `json:"paid_at"`
 }
 
 func (s *Server) createPaymentHandler(ctx *gin.Context) {
@@ -25,12 +26,19 @@ func (s *Server) createPaymentHandler(ctx *gin.Context) {
 		return
 	}
 
+	currency, err := pkg.ParseCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	payment := &repository.Payment{
 		OrderID:            req.OrderID,
 		UserSubscriptionID: req.SubscriptionId,
 		Description:        req.Description,
 		PaymentMethod:      req.PaymentMethod,
-		Amount:             req.Amount,
+		Amount:             req.Amount.WithCurrency(currency),
+		Currency:           currency,
 	}
 
 	if req.PaidAt != nil {
@@ -45,6 +53,10 @@ func (s *Server) createPaymentHandler(ctx *gin.Context) {
 		return
 	}
 
+	if newPayment.UserSubscriptionID != nil {
+		s.notifySubscriptionPaid(newPayment)
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": newPayment})
 }
 
@@ -65,10 +77,10 @@ func (s *Server) getPaymentHandler(ctx *gin.Context) {
 }
 
 type updatePaymentReq struct {
-	Description   *string  `json:"description,omitempty"`
-	PaymentMethod *string  `json:"payment_method"`
-	Amount        *float64 `json:"amount"`
-	PaidAt        *string  `json:"paid_at"`
+	Description   *string    `json:"description,omitempty"`
+	PaymentMethod *string    `json:"payment_method"`
+	Amount        *pkg.Money `json:"amount"`
+	PaidAt        *string    `json:"paid_at"`
 }
 
 func (s *Server) updatePaymentHandler(ctx *gin.Context) {
@@ -116,11 +128,10 @@ func (s *Server) updatePaymentHandler(ctx *gin.Context) {
 }
 
 func (s *Server) listPaymentsHandler(ctx *gin.Context) {
-	filter := &repository.PaymentFilter{
-		Pagination:    &pkg.Pagination{},
-		PaymentMethod: nil,
-		StartDate:     nil,
-		EndDate:       nil,
+	filter, err := bindPaymentFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
 	pageNoStr := ctx.DefaultQuery("page", "1")
@@ -141,6 +152,24 @@ func (s *Server) listPaymentsHandler(ctx *gin.Context) {
 	}
 	filter.Pagination.PageSize = pageSize
 
+	payments, pagination, err := s.repo.PaymentRepository.ListPayments(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": payments, "pagination": pagination})
+}
+
+// bindPaymentFilter reads the filters and sort of the payment list.
+func bindPaymentFilter(ctx *gin.Context) (*repository.PaymentFilter, error) {
+	filter := &repository.PaymentFilter{
+		Pagination:    &pkg.Pagination{},
+		PaymentMethod: nil,
+		StartDate:     nil,
+		EndDate:       nil,
+	}
+
 	if paymentMethod := ctx.Query("payment_method"); paymentMethod != "" {
 		filter.PaymentMethod = &paymentMethod
 	}
@@ -154,11 +183,9 @@ func (s *Server) listPaymentsHandler(ctx *gin.Context) {
 	endDate = endDate.Add(24 * time.Hour)
 	filter.EndDate = &endDate
 
-	payments, pagination, err := s.repo.PaymentRepository.ListPayments(ctx, filter)
-	if err != nil {
-		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
-		return
+	if err := bindListOrder(ctx, filter.Pagination, repository.PaymentSortFields); err != nil {
+		return nil, err
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"data": payments, "pagination": pagination})
+	return filter, nil
 }
@@ -14,8 +14,9 @@ import (
 )
 
 type initializePaystackPaymentReq struct {
-	Email  string  `json:"email" binding:"required,email"`
-	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Email    string    `json:"email" binding:"required,email"`
+	Amount   pkg.Money `json:"amount"`
+	Currency string    `json:"currency"`
 }
 
 func (s *Server) initializePaystackPayment(ctx *gin.Context) {
@@ -25,7 +26,17 @@ func (s *Server) initializePaystackPayment(ctx *gin.Context) {
 		return
 	}
 
-	amount := int64(req.Amount * 100) // convert to kobo
+	currency, err := pkg.ParseCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount := req.Amount.WithCurrency(currency)
+	if !amount.IsPositive() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "amount must be greater than 0")))
+		return
+	}
 
 	accessCode, reference, err := s.ps.InitializePayment(req.Email, amount)
 	if err != nil {
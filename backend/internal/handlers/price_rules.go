@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type createPriceRuleReq struct {
+	Name       string     `json:"name" binding:"required"`
+	Kind       string     `json:"kind" binding:"required,oneof=absolute percentage"`
+	Price      *pkg.Money `json:"price"`
+	UpliftBps  *int32     `json:"uplift_bps"`
+	ProductID  *uint32    `json:"product_id"`
+	VariantID  *uint32    `json:"variant_id"`
+	CategoryID *uint32    `json:"category_id"`
+	TagID      *uint32    `json:"tag_id"`
+	StartsAt   time.Time  `json:"starts_at" binding:"required"` // RFC 3339
+	EndsAt     *time.Time `json:"ends_at"`
+	Priority   int32      `json:"priority"`
+}
+
+func (s *Server) createPriceRuleHandler(ctx *gin.Context) {
+	var req createPriceRuleReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	priceRule := &repository.PriceRule{
+		Name:       req.Name,
+		Kind:       req.Kind,
+		Price:      req.Price,
+		UpliftBps:  req.UpliftBps,
+		ProductID:  req.ProductID,
+		VariantID:  req.VariantID,
+		CategoryID: req.CategoryID,
+		TagID:      req.TagID,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		Priority:   req.Priority,
+	}
+
+	newPriceRule, err := s.repo.PriceRuleRepository.CreatePriceRule(ctx, priceRule)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": newPriceRule})
+}
+
+func (s *Server) getPriceRuleHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid price rule ID: %s", err.Error())))
+		return
+	}
+
+	priceRule, err := s.repo.PriceRuleRepository.GetPriceRuleByID(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": priceRule})
+}
+
+func (s *Server) listPriceRulesHandler(ctx *gin.Context) {
+	filter := &repository.PriceRuleFilter{
+		IncludeDeleted: ctx.Query("include_deleted") == "true",
+	}
+
+	for key, target := range map[string]**uint32{
+		"product_id":  &filter.ProductID,
+		"category_id": &filter.CategoryID,
+		"tag_id":      &filter.TagID,
+	} {
+		if value := ctx.Query(key); value != "" {
+			id, err := pkg.StringToUint32(value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid %s: %s", key, err.Error())))
+				return
+			}
+			*target = &id
+		}
+	}
+
+	if activeAt := ctx.Query("active_at"); activeAt != "" {
+		at, err := time.Parse(time.RFC3339, activeAt)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid active_at format, expected RFC 3339")))
+			return
+		}
+		filter.ActiveAt = &at
+	}
+
+	priceRules, err := s.repo.PriceRuleRepository.ListPriceRules(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": priceRules})
+}
+
+func (s *Server) endPriceRuleHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid price rule ID: %s", err.Error())))
+		return
+	}
+
+	if err := s.repo.PriceRuleRepository.EndPriceRule(ctx, int64(id)); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Price rule ended successfully"})
+}
+
+func (s *Server) getProductPriceHistoryHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid product ID: %s", err.Error())))
+		return
+	}
+
+	history, err := s.repo.PriceRuleRepository.GetPriceHistory(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": history})
+}
@@ -9,19 +9,25 @@ import (
 )
 
 type createProductReq struct {
-	Name          string   `json:"name" binding:"required"`
-	Description   string   `json:"description" binding:"required"`
-	Price         float64  `json:"price" binding:"required"`
-	ImageUrl      []string `json:"image_url" binding:"required"`
-	CategoryId    uint32   `json:"category_id" binding:"required"`
-	HasStems      bool     `json:"has_stems"`
-	IsMessageCard bool     `json:"is_message_card"`
-	IsFlowers     bool     `json:"is_flowers"`
-	IsAddOn       bool     `json:"is_add_on"`
-	StockQuantity int64    `json:"stock_quantity" binding:"required"`
+	Name             string    `json:"name" binding:"required"`
+	SKU              *string   `json:"sku,omitempty"`
+	Description      string    `json:"description" binding:"required"`
+	Price            pkg.Money `json:"price"`
+	Currency         string    `json:"currency"`
+	ImageUrl         []string  `json:"image_url" binding:"required"`
+	CategoryId       uint32    `json:"category_id" binding:"required"`
+	HasVariants      bool      `json:"has_variants"`
+	IsMessageCard    bool      `json:"is_message_card"`
+	IsFlowers        bool      `json:"is_flowers"`
+	IsAddOn          bool      `json:"is_add_on"`
+	StockQuantity    int64     `json:"stock_quantity" binding:"required"`
+	ReorderThreshold *int64    `json:"reorder_threshold,omitempty" binding:"omitempty,gte=0"`
+	TaxClassID       *uint32   `json:"tax_class_id,omitempty"`
 
 	// extended fields
-	Stems []repository.ProductStem `json:"stems,omitempty"`
+	Options  []repository.ProductOption  `json:"options,omitempty"`
+	Variants []repository.ProductVariant `json:"variants,omitempty"`
+	TagIDs   []uint32                    `json:"tag_ids,omitempty"`
 }
 
 func (s *Server) createProductHandler(ctx *gin.Context) {
@@ -31,27 +37,48 @@ func (s *Server) createProductHandler(ctx *gin.Context) {
 		return
 	}
 
-	// if has stems make price to the first stem price
-	if req.HasStems && len(req.Stems) <= 0 {
-		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "product has stems but no stems provided")))
+	// if has variants make price to the first variant price
+	if req.HasVariants && len(req.Variants) <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "product has variants but no variants provided")))
 		return
 	}
-	if req.HasStems {
-		req.Price = req.Stems[0].Price
+	if req.HasVariants {
+		req.Price = req.Variants[0].Price
+	}
+	if !req.Price.IsPositive() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "price must be greater than 0")))
+		return
+	}
+
+	currency, err := pkg.ParseCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	for i := range req.Variants {
+		req.Variants[i].Price = req.Variants[i].Price.WithCurrency(currency)
 	}
 
 	product := &repository.Product{
-		Name:          req.Name,
-		Description:   req.Description,
-		Price:         req.Price,
-		ImageUrl:      req.ImageUrl,
-		HasStems:      req.HasStems,
-		IsMessageCard: req.IsMessageCard,
-		IsFlowers:     req.IsFlowers,
-		IsAddOn:       req.IsAddOn,
-		CategoryID:    req.CategoryId,
-		StockQuantity: req.StockQuantity,
-		Stems:         req.Stems,
+		Name:             req.Name,
+		SKU:              req.SKU,
+		Description:      req.Description,
+		Price:            req.Price.WithCurrency(currency),
+		Currency:         currency,
+		ImageUrl:         req.ImageUrl,
+		HasVariants:      req.HasVariants,
+		IsMessageCard:    req.IsMessageCard,
+		IsFlowers:        req.IsFlowers,
+		IsAddOn:          req.IsAddOn,
+		CategoryID:       req.CategoryId,
+		StockQuantity:    req.StockQuantity,
+		ReorderThreshold: req.ReorderThreshold,
+		TaxClassID:       req.TaxClassID,
+		Options:          req.Options,
+		Variants:         req.Variants,
+		TagIDs:           req.TagIDs,
+		CreatedBy:        authUserID(ctx),
 	}
 
 	newProduct, err := s.repo.ProductRepository.CreateProduct(ctx, product)
@@ -77,6 +104,16 @@ func (s *Server) updateProductHandler(ctx *gin.Context) {
 	}
 
 	req.ID = id
+	req.UpdatedBy = authUserID(ctx)
+
+	if req.Currency != nil {
+		currency, err := pkg.ParseCurrency(*req.Currency)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		*req.Currency = string(currency)
+	}
 
 	updatedProduct, err := s.repo.ProductRepository.UpdateProduct(ctx, &req)
 	if err != nil {
@@ -84,6 +121,10 @@ func (s *Server) updateProductHandler(ctx *gin.Context) {
 		return
 	}
 
+	if req.ImageURL != nil || req.Variants != nil {
+		s.removeOrphanImages(ctx, repository.ImageOwnerProduct, req.ID)
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": updatedProduct})
 }
 
@@ -97,6 +138,8 @@ func (s *Server) listProductsHandler(ctx *gin.Context) {
 		IsMessageCard: nil,
 		IsFlowers:     nil,
 		IsAddOn:       nil,
+		InStock:       nil,
+		TagIDs:        nil,
 	}
 
 	pageNoStr := ctx.DefaultQuery("page", "1")
@@ -134,6 +177,19 @@ func (s *Server) listProductsHandler(ctx *gin.Context) {
 		filter.CategoryIDs = &categoryIds
 	}
 
+	if tag := ctx.QueryArray("tag_id"); len(tag) > 0 {
+		tagIds := make([]int64, len(tag))
+		for i, t := range tag {
+			tagId, err := pkg.StringToUint32(t)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tag ID: %s should be a number", err.Error())))
+				return
+			}
+			tagIds[i] = int64(tagId)
+		}
+		filter.TagIDs = &tagIds
+	}
+
 	if priceFrom := ctx.Query("price_from"); priceFrom != "" {
 		priceFromFloat, err := pkg.StringToFloat64(priceFrom)
 		if err != nil {
@@ -179,13 +235,41 @@ func (s *Server) listProductsHandler(ctx *gin.Context) {
 		filter.IsAddOn = &isAddOnBool
 	}
 
-	products, pagination, err := s.repo.ProductRepository.ListProducts(ctx, filter)
+	if inStock := ctx.Query("in_stock"); inStock != "" {
+		inStockBool, err := pkg.StringToBool(inStock)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		filter.InStock = &inStockBool
+	}
+
+	if facets := ctx.Query("facets"); facets != "" {
+		withFacets, err := pkg.StringToBool(facets)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		filter.WithFacets = withFacets
+	}
+
+	if err := bindListOrder(ctx, filter.Pagination, repository.ProductSortFields); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	products, pagination, facets, err := s.repo.ProductRepository.ListProducts(ctx, filter)
 	if err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"data": products, "pagination": pagination})
+	response := gin.H{"data": products, "pagination": pagination}
+	if facets != nil {
+		response["facets"] = facets
+	}
+
+	ctx.JSON(http.StatusOK, response)
 }
 
 func (s *Server) listAddOnProductsHandler(ctx *gin.Context) {
@@ -240,6 +324,22 @@ func (s *Server) deleteProductHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully"})
 }
 
+func (s *Server) restoreProductHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid product ID: %s", err.Error())))
+		return
+	}
+
+	product, err := s.repo.ProductRepository.RestoreProduct(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": product})
+}
+
 func (s *Server) listProductOrderItemsHandler(ctx *gin.Context) {
 	id, err := pkg.StringToUint32(ctx.Param("id"))
 	if err != nil {
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type purchaseOrderItemReq struct {
+	ProductID uint32    `json:"product_id" binding:"required"`
+	VariantID *uint32   `json:"variant_id"`
+	Quantity  int64     `json:"quantity" binding:"required,gt=0"`
+	UnitCost  pkg.Money `json:"unit_cost"`
+}
+
+type createPurchaseOrderReq struct {
+	SupplierID uint32                 `json:"supplier_id" binding:"required"`
+	Currency   string                 `json:"currency"`
+	ExpectedAt *time.Time             `json:"expected_at"` // RFC 3339
+	Notes      *string                `json:"notes"`
+	Items      []purchaseOrderItemReq `json:"items" binding:"dive"`
+}
+
+func (s *Server) createPurchaseOrderHandler(ctx *gin.Context) {
+	var req createPurchaseOrderReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	currency, err := pkg.ParseCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	createdBy := authUserID(ctx)
+	order, err := s.repo.PurchaseOrderRepository.CreatePurchaseOrder(ctx, &repository.PurchaseOrder{
+		SupplierID: req.SupplierID,
+		Currency:   currency,
+		ExpectedAt: req.ExpectedAt,
+		Notes:      req.Notes,
+		CreatedBy:  &createdBy,
+		Items:      purchaseOrderItems(req.Items, currency),
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+func (s *Server) getPurchaseOrderHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid purchase order ID: %s", err.Error())))
+		return
+	}
+
+	order, err := s.repo.PurchaseOrderRepository.GetPurchaseOrderByID(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+func (s *Server) listPurchaseOrdersHandler(ctx *gin.Context) {
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	filter := &repository.PurchaseOrderFilter{
+		Pagination: &pkg.Pagination{Page: pageNo, PageSize: pageSize},
+		SupplierID: nil,
+		Status:     nil,
+	}
+
+	if status := ctx.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	if value := ctx.Query("supplier_id"); value != "" {
+		supplierID, err := pkg.StringToUint32(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid supplier_id: %s", err.Error())))
+			return
+		}
+		filter.SupplierID = &supplierID
+	}
+
+	orders, pagination, err := s.repo.PurchaseOrderRepository.ListPurchaseOrders(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":       orders,
+		"pagination": pagination,
+	})
+}
+
+type updatePurchaseOrderReq struct {
+	SupplierID *uint32                 `json:"supplier_id"`
+	Currency   *string                 `json:"currency"`
+	Status     *string                 `json:"status" binding:"omitempty,oneof=ordered cancelled"`
+	ExpectedAt *time.Time              `json:"expected_at"`
+	Notes      *string                 `json:"notes"`
+	Items      *[]purchaseOrderItemReq `json:"items" binding:"omitempty,dive"`
+}
+
+func (s *Server) updatePurchaseOrderHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid purchase order ID: %s", err.Error())))
+		return
+	}
+
+	var req updatePurchaseOrderReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	order := &repository.UpdatePurchaseOrder{
+		ID:         id,
+		SupplierID: req.SupplierID,
+		Currency:   nil,
+		Status:     req.Status,
+		ExpectedAt: req.ExpectedAt,
+		Notes:      req.Notes,
+		Items:      nil,
+	}
+
+	if req.Currency != nil {
+		currency, err := pkg.ParseCurrency(*req.Currency)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		order.Currency = &currency
+	}
+	if req.Items != nil {
+		// unit costs are stored in the purchase order's currency
+		items := purchaseOrderItems(*req.Items, pkg.DefaultCurrency)
+		order.Items = &items
+	}
+
+	updatedOrder, err := s.repo.PurchaseOrderRepository.UpdatePurchaseOrder(ctx, order)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": updatedOrder})
+}
+
+type receivePurchaseOrderReq struct {
+	Items []repository.PurchaseOrderReceiptItem `json:"items" binding:"required,min=1"`
+}
+
+// receivePurchaseOrderHandler receives a delivery against a purchase order,
+// items with an expires_at are received as stock batches.
+func (s *Server) receivePurchaseOrderHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid purchase order ID: %s", err.Error())))
+		return
+	}
+
+	var req receivePurchaseOrderReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	order, err := s.repo.PurchaseOrderRepository.ReceivePurchaseOrder(ctx, &repository.PurchaseOrderReceipt{
+		PurchaseOrderID: id,
+		Items:           req.Items,
+		UserID:          authUserID(ctx),
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+// getMarginReportHandler reports the margins on the orders paid from the day
+// "from" through the day "to", both YYYY-MM-DD, by default over the last 30
+// days.
+func (s *Server) getMarginReportHandler(ctx *gin.Context) {
+	today := time.Now().Truncate(24 * time.Hour)
+	from, to := today.AddDate(0, 0, -29), today
+
+	for key, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		if value := ctx.Query(key); value != "" {
+			day, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid %s format, expected YYYY-MM-DD", key)))
+				return
+			}
+			*target = day
+		}
+	}
+
+	report, err := s.repo.PurchaseOrderRepository.GetMarginReport(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+func purchaseOrderItems(items []purchaseOrderItemReq, currency pkg.Currency) []repository.PurchaseOrderItem {
+	orderItems := make([]repository.PurchaseOrderItem, len(items))
+	for i, item := range items {
+		orderItems[i] = repository.PurchaseOrderItem{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+			UnitCost:  item.UnitCost.WithCurrency(currency),
+		}
+	}
+
+	return orderItems
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) getOrderReceiptHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid order ID: %s", err.Error())))
+		return
+	}
+
+	order, err := s.repo.OrderRepository.GetOrderByID(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	invoice, receipt, err := s.orderReceipt(ctx, order)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", invoice.Number+".pdf"))
+	ctx.Data(http.StatusOK, "application/pdf", receipt)
+}
+
+// getPaymentReceiptHandler serves the receipt of a subscription billing cycle.
+// Payments made against an order get the order's receipt.
+func (s *Server) getPaymentReceiptHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid payment ID: %s", err.Error())))
+		return
+	}
+
+	payment, err := s.repo.PaymentRepository.GetPaymentByID(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	invoice, receipt, err := s.paymentReceipt(ctx, payment)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", invoice.Number+".pdf"))
+	ctx.Data(http.StatusOK, "application/pdf", receipt)
+}
+
+func (s *Server) orderReceipt(ctx context.Context, order *repository.Order) (*repository.Invoice, []byte, error) {
+	invoice, err := s.repo.InvoiceRepository.GetOrCreateOrderInvoice(ctx, int64(order.ID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := s.receipts.OrderReceipt(invoice, order)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return invoice, receipt, nil
+}
+
+func (s *Server) paymentReceipt(ctx context.Context, payment *repository.Payment) (*repository.Invoice, []byte, error) {
+	if payment.OrderID != nil {
+		order, err := s.repo.OrderRepository.GetOrderByID(ctx, int64(*payment.OrderID))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return s.orderReceipt(ctx, order)
+	}
+
+	if payment.UserSubscriptionID == nil {
+		return nil, nil, pkg.Errorf(pkg.INVALID_ERROR, "payment with id %d has no order or subscription to issue a receipt for", payment.ID)
+	}
+
+	userSubscription, err := s.repo.UserSubscriptionRepository.GetUserSubscriptionByID(ctx, int64(*payment.UserSubscriptionID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invoice, err := s.repo.InvoiceRepository.GetOrCreatePaymentInvoice(ctx, int64(payment.ID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := s.receipts.SubscriptionReceipt(invoice, payment, userSubscription)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return invoice, receipt, nil
+}
+
+// notifyOrderPaid queues the paid notification with the receipt attached on
+// the worker, so the request creating the order does not wait on it.
+func (s *Server) notifyOrderPaid(order *repository.Order) {
+	s.worker.Enqueue(fmt.Sprintf("paid notification for order %d", order.ID), func(ctx context.Context) error {
+		invoice, receipt, err := s.orderReceipt(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to generate receipt: %w", err)
+		}
+
+		notification := services.Notification{
+			PhoneNumber: order.Buyer.PhoneNumber,
+			Subject:     fmt.Sprintf("Payment received for order #%d", order.ID),
+			Message:     fmt.Sprintf("Hi %s, we have received your payment of %s %s for order #%d. Your receipt %s is attached.", order.Buyer.Name, order.Currency, order.TotalAmount, order.ID, invoice.Number),
+			Attachments: []services.Attachment{receiptAttachment(invoice, receipt)},
+		}
+
+		if order.Buyer.Email != nil {
+			notification.Email = *order.Buyer.Email
+		} else if order.PaymentReference != nil {
+			if paystackPayment, err := s.repo.PaystackRepository.GetPaymentByReference(ctx, *order.PaymentReference); err == nil {
+				notification.Email = paystackPayment.Email
+			}
+		}
+
+		return s.notifier.Send(ctx, notification)
+	})
+}
+
+// notifySubscriptionPaid is the billing cycle counterpart of notifyOrderPaid.
+func (s *Server) notifySubscriptionPaid(payment *repository.Payment) {
+	s.worker.Enqueue(fmt.Sprintf("paid notification for payment %d", payment.ID), func(ctx context.Context) error {
+		invoice, receipt, err := s.paymentReceipt(ctx, payment)
+		if err != nil {
+			return fmt.Errorf("failed to generate receipt: %w", err)
+		}
+
+		notification := services.Notification{
+			Subject:     fmt.Sprintf("Payment received for subscription #%d", *payment.UserSubscriptionID),
+			Message:     fmt.Sprintf("We have received your subscription payment of %s %s. Your receipt %s is attached.", payment.Currency, payment.Amount, invoice.Number),
+			Attachments: []services.Attachment{receiptAttachment(invoice, receipt)},
+		}
+
+		if userSubscription, err := s.repo.UserSubscriptionRepository.GetUserSubscriptionByID(ctx, int64(*payment.UserSubscriptionID)); err == nil && userSubscription.UserData != nil {
+			notification.Email = userSubscription.UserData.Email
+			notification.PhoneNumber = userSubscription.UserData.PhoneNumber
+		}
+
+		return s.notifier.Send(ctx, notification)
+	})
+}
+
+func receiptAttachment(invoice *repository.Invoice, receipt []byte) services.Attachment {
+	return services.Attachment{
+		Filename:    invoice.Number + ".pdf",
+		ContentType: "application/pdf",
+		Data:        receipt,
+	}
+}
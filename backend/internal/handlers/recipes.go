@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type recipeComponentReq struct {
+	ProductID uint32  `json:"product_id" binding:"required"`
+	VariantID *uint32 `json:"variant_id"`
+	Quantity  int64   `json:"quantity" binding:"required,gt=0"`
+}
+
+type setRecipeReq struct {
+	// the recipe of a variant is used instead of the product's when it has one
+	VariantID *uint32 `json:"variant_id"`
+	// an empty list removes the recipe
+	Components []recipeComponentReq `json:"components" binding:"dive"`
+}
+
+func (s *Server) getRecipeHandler(ctx *gin.Context) {
+	productID, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid product ID: %s", err.Error())))
+		return
+	}
+
+	var variantID *uint32
+	if value := ctx.Query("variant_id"); value != "" {
+		id, err := pkg.StringToUint32(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid variant ID: %s", err.Error())))
+			return
+		}
+		variantID = &id
+	}
+
+	recipe, err := s.repo.RecipeRepository.GetRecipe(ctx, productID, variantID)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": recipe})
+}
+
+func (s *Server) setRecipeHandler(ctx *gin.Context) {
+	productID, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid product ID: %s", err.Error())))
+		return
+	}
+
+	var req setRecipeReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	recipe := &repository.Recipe{
+		ProductID:  productID,
+		VariantID:  req.VariantID,
+		Components: make([]repository.RecipeComponent, len(req.Components)),
+	}
+	for i, component := range req.Components {
+		recipe.Components[i] = repository.RecipeComponent{
+			ProductID: component.ProductID,
+			VariantID: component.VariantID,
+			Quantity:  component.Quantity,
+		}
+	}
+
+	recipe, err = s.repo.RecipeRepository.SetRecipe(ctx, recipe)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": recipe})
+}
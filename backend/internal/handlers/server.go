@@ -7,8 +7,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/flexGURU/flower-haven/backend/internal/blobstore"
+	"github.com/flexGURU/flower-haven/backend/internal/notifier"
 	"github.com/flexGURU/flower-haven/backend/internal/paystack"
 	"github.com/flexGURU/flower-haven/backend/internal/postgres"
+	"github.com/flexGURU/flower-haven/backend/internal/receipts"
 	"github.com/flexGURU/flower-haven/backend/internal/services"
 	"github.com/flexGURU/flower-haven/backend/pkg"
 	"github.com/gin-gonic/gin"
@@ -23,15 +26,24 @@ type Server struct {
 	tokenMaker pkg.JWTMaker
 	repo       *postgres.PostgresRepo
 	ps         services.IPayStack
+	receipts   services.IReceipt
+	notifier   services.INotifier
+	blobs      services.IBlobStore
+	worker     services.IWorker
 }
 
-func NewServer(config pkg.Config, tokenMaker pkg.JWTMaker, repo *postgres.PostgresRepo) *Server {
+func NewServer(config pkg.Config, tokenMaker pkg.JWTMaker, repo *postgres.PostgresRepo, worker services.IWorker) *Server {
 	if config.ENVIRONMENT == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	ps := paystack.NewPaystack(config.PAYSTACK_SECRET_KEY, config.PAYSTACK_CALLBACK_URL)
 
+	blobs, err := blobstore.NewBlobStore(config)
+	if err != nil {
+		log.Fatalf("Error creating blob store: %v", err)
+	}
+
 	r := gin.Default()
 
 	s := &Server{
@@ -42,6 +54,10 @@ func NewServer(config pkg.Config, tokenMaker pkg.JWTMaker, repo *postgres.Postgr
 		tokenMaker: tokenMaker,
 		repo:       repo,
 		ps:         ps,
+		receipts:   receipts.NewReceipt(config.BUSINESS_NAME, config.BUSINESS_DETAILS),
+		notifier:   notifier.NewLogNotifier(),
+		blobs:      blobs,
+		worker:     worker,
 	}
 
 	s.setUpRoutes()
@@ -59,6 +75,11 @@ func (s *Server) setUpRoutes() {
 	// health check
 	s.router.GET("/health-check", s.healthCheckHandler)
 
+	// files uploaded to the local blob store
+	if s.config.BLOB_STORE == "" || s.config.BLOB_STORE == "local" {
+		s.router.Static("/uploads", s.config.UPLOAD_DIR)
+	}
+
 	// User routes
 	v1.POST("/user/login", s.login)
 	v1.GET("/user/logout", s.logout)
@@ -67,6 +88,7 @@ func (s *Server) setUpRoutes() {
 	v1.POST("/users", s.createUserHandler)
 	authRoute.GET("/users/:id", s.getUserHandler)
 	authRoute.GET("/users", s.listUsersHandler)
+	authRoute.GET("/users/export", adminMiddleware(), s.exportCustomersHandler)
 	authRoute.PUT("/users/:id", s.updateUserHandler)
 
 	// v1.GET("/users/:id/orders", s.getUserOrdersHandler)
@@ -74,19 +96,67 @@ func (s *Server) setUpRoutes() {
 
 	// Category routes
 	authRoute.POST("/categories", s.createCategoryHandler)
+	v1.GET("/categories/tree", s.getCategoryTreeHandler)
 	v1.GET("/categories/:id", s.getCategoryHandler)
 	v1.GET("/categories", s.listCategoriesHandler)
 	authRoute.PUT("/categories/:id", s.updateCategoryHandler)
 	authRoute.DELETE("/categories/:id", s.deleteCategoryHandler)
+	authRoute.POST("/categories/recount-products", adminMiddleware(), s.recountCategoryProductsHandler)
+	authRoute.POST("/categories/:id/images", s.uploadCategoryImagesHandler)
+	v1.GET("/categories/:id/images", s.listCategoryImagesHandler)
 
 	// Product routes
 	authRoute.POST("/products", s.createProductHandler)
+	authRoute.POST("/products/import", adminMiddleware(), s.importProductsHandler)
 	v1.GET("/products/:id", s.getProductHandler)
 	v1.GET("/products", s.listProductsHandler)
 	authRoute.PUT("/products/:id", s.updateProductHandler)
 	authRoute.DELETE("/products/:id", s.deleteProductHandler)
+	authRoute.POST("/products/:id/restore", s.restoreProductHandler)
+	authRoute.POST("/products/:id/images", s.uploadProductImagesHandler)
+	v1.GET("/products/:id/images", s.listProductImagesHandler)
 
 	authRoute.GET("/products/:id/order-items", s.listProductOrderItemsHandler)
+	authRoute.GET("/products/:id/price-history", s.getProductPriceHistoryHandler)
+	authRoute.GET("/products/:id/recipe", s.getRecipeHandler)
+	authRoute.PUT("/products/:id/recipe", adminMiddleware(), s.setRecipeHandler)
+
+	// Price rule routes
+	authRoute.POST("/price-rules", adminMiddleware(), s.createPriceRuleHandler)
+	authRoute.GET("/price-rules/:id", adminMiddleware(), s.getPriceRuleHandler)
+	authRoute.GET("/price-rules", adminMiddleware(), s.listPriceRulesHandler)
+	authRoute.DELETE("/price-rules/:id", adminMiddleware(), s.endPriceRuleHandler)
+
+	// Stock batch routes
+	authRoute.POST("/stock-batches", adminMiddleware(), s.createStockBatchHandler)
+	authRoute.GET("/stock-batches/:id", adminMiddleware(), s.getStockBatchHandler)
+	authRoute.GET("/stock-batches", adminMiddleware(), s.listStockBatchesHandler)
+	authRoute.GET("/reports/wastage", adminMiddleware(), s.getWastageReportHandler)
+	authRoute.POST("/stock-movements", adminMiddleware(), s.createStockMovementHandler)
+	authRoute.GET("/stock-movements", adminMiddleware(), s.listStockMovementsHandler)
+	authRoute.GET("/stock-movements/discrepancies", adminMiddleware(), s.listStockDiscrepanciesHandler)
+	authRoute.POST("/suppliers", adminMiddleware(), s.createSupplierHandler)
+	authRoute.GET("/suppliers/:id", adminMiddleware(), s.getSupplierHandler)
+	authRoute.GET("/suppliers", adminMiddleware(), s.listSuppliersHandler)
+	authRoute.PUT("/suppliers/:id", adminMiddleware(), s.updateSupplierHandler)
+	authRoute.DELETE("/suppliers/:id", adminMiddleware(), s.deleteSupplierHandler)
+	authRoute.POST("/purchase-orders", adminMiddleware(), s.createPurchaseOrderHandler)
+	authRoute.GET("/purchase-orders/:id", adminMiddleware(), s.getPurchaseOrderHandler)
+	authRoute.GET("/purchase-orders", adminMiddleware(), s.listPurchaseOrdersHandler)
+	authRoute.PUT("/purchase-orders/:id", adminMiddleware(), s.updatePurchaseOrderHandler)
+	authRoute.POST("/purchase-orders/:id/receive", adminMiddleware(), s.receivePurchaseOrderHandler)
+	authRoute.GET("/reports/margins", adminMiddleware(), s.getMarginReportHandler)
+
+	// Tag routes
+	authRoute.POST("/tag-groups", s.createTagGroupHandler)
+	v1.GET("/tag-groups", s.listTagGroupsHandler)
+	authRoute.PUT("/tag-groups/:id", s.updateTagGroupHandler)
+	authRoute.DELETE("/tag-groups/:id", s.deleteTagGroupHandler)
+	authRoute.POST("/tags", s.createTagHandler)
+	v1.GET("/tags/:slug", s.getTagPageHandler)
+	v1.GET("/tags", s.listTagsHandler)
+	authRoute.PUT("/tags/:id", s.updateTagHandler)
+	authRoute.DELETE("/tags/:id", s.deleteTagHandler)
 
 	// Subscription routes
 	authRoute.POST("/subscriptions", s.createSubscriptionHandler)
@@ -111,16 +181,22 @@ func (s *Server) setUpRoutes() {
 
 	// Order routes
 	v1.POST("/orders", s.createOrderHandler)
+	v1.POST("/orders/quote", s.quoteOrderHandler)
 	authRoute.GET("/orders/:id", s.getOrderHandler)
 	authRoute.GET("/orders", s.listOrdersHandler)
+	authRoute.GET("/orders/work-sheet", s.getWorkSheetHandler)
+	authRoute.GET("/orders/export", adminMiddleware(), s.exportOrdersHandler)
 	authRoute.PUT("/orders/:id", s.updateOrderHandler)
 	authRoute.DELETE("/orders/:id", s.deleteOrderHandler)
+	authRoute.GET("/orders/:id/receipt", s.getOrderReceiptHandler)
 
 	// Payment routes
 	authRoute.POST("/payments", s.createPaymentHandler)
 	authRoute.GET("/payments/:id", s.getPaymentHandler)
 	authRoute.PUT("/payments/:id", s.updatePaymentHandler)
 	authRoute.GET("/payments", s.listPaymentsHandler)
+	authRoute.GET("/payments/export", adminMiddleware(), s.exportPaymentsHandler)
+	authRoute.GET("/payments/:id/receipt", s.getPaymentReceiptHandler)
 
 	// Paystack routes
 	v1.POST("/paystack/webhook", s.handlePaystackWebhook)
@@ -129,8 +205,26 @@ func (s *Server) setUpRoutes() {
 	authRoute.GET("/paystack/payments", s.listPaystackPayments)
 	authRoute.GET("/paystack/events", s.listPaystackEvents)
 
+	// tax routes
+	authRoute.POST("/tax-classes", adminMiddleware(), s.createTaxClassHandler)
+	authRoute.GET("/tax-classes/:id", s.getTaxClassHandler)
+	authRoute.GET("/tax-classes", s.listTaxClassesHandler)
+	authRoute.PUT("/tax-classes/:id", adminMiddleware(), s.updateTaxClassHandler)
+	authRoute.DELETE("/tax-classes/:id", adminMiddleware(), s.deleteTaxClassHandler)
+	authRoute.POST("/tax-classes/:id/rates", adminMiddleware(), s.createTaxRateHandler)
+	authRoute.DELETE("/tax-rates/:id", adminMiddleware(), s.deleteTaxRateHandler)
+
+	// analytics routes
+	authRoute.GET("/dashboard", adminMiddleware(), s.getDashboardHandler)
+	authRoute.GET("/analytics/revenue", adminMiddleware(), s.getRevenueAnalyticsHandler)
+	authRoute.GET("/analytics/orders-by-status", adminMiddleware(), s.getOrdersByStatusAnalyticsHandler)
+	authRoute.GET("/analytics/average-order-value", adminMiddleware(), s.getAverageOrderValueAnalyticsHandler)
+	authRoute.GET("/analytics/top-products", adminMiddleware(), s.getTopProductsAnalyticsHandler)
+	authRoute.GET("/analytics/top-categories", adminMiddleware(), s.getTopCategoriesAnalyticsHandler)
+	authRoute.GET("/analytics/subscriptions", adminMiddleware(), s.getSubscriptionAnalyticsHandler)
+	authRoute.GET("/analytics/customers", adminMiddleware(), s.getCustomerAnalyticsHandler)
+
 	// helpers routes
-	authRoute.GET("/dashboard", s.getDashboardDataHandler)
 	v1.GET("/products/add-ons", s.listAddOnProductsHandler)
 	v1.GET("/products/message-cards", s.listMessageCardProductsHandler)
 
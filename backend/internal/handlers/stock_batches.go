@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type createStockBatchReq struct {
+	ProductID  uint32     `json:"product_id" binding:"required"`
+	VariantID  *uint32    `json:"variant_id"`
+	Supplier   *string    `json:"supplier"`
+	ReceivedAt *time.Time `json:"received_at"` // RFC 3339, defaults to now
+	ExpiresAt  time.Time  `json:"expires_at" binding:"required"`
+	Quantity   int64      `json:"quantity" binding:"required,gt=0"`
+}
+
+func (s *Server) createStockBatchHandler(ctx *gin.Context) {
+	var req createStockBatchReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	batch := &repository.StockBatch{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Supplier:  req.Supplier,
+		ExpiresAt: req.ExpiresAt,
+		Quantity:  req.Quantity,
+	}
+	if req.ReceivedAt != nil {
+		batch.ReceivedAt = *req.ReceivedAt
+	}
+
+	newBatch, err := s.repo.StockBatchRepository.CreateStockBatch(ctx, batch, authUserID(ctx))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": newBatch})
+}
+
+func (s *Server) getStockBatchHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid stock batch ID: %s", err.Error())))
+		return
+	}
+
+	batch, err := s.repo.StockBatchRepository.GetStockBatchByID(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+func (s *Server) listStockBatchesHandler(ctx *gin.Context) {
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	filter := &repository.StockBatchFilter{
+		Pagination: &pkg.Pagination{Page: pageNo, PageSize: pageSize},
+		Live:       ctx.Query("live") == "true",
+	}
+
+	for key, target := range map[string]**uint32{
+		"product_id": &filter.ProductID,
+		"variant_id": &filter.VariantID,
+	} {
+		if value := ctx.Query(key); value != "" {
+			id, err := pkg.StringToUint32(value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid %s: %s", key, err.Error())))
+				return
+			}
+			*target = &id
+		}
+	}
+
+	if expiresBefore := ctx.Query("expires_before"); expiresBefore != "" {
+		at, err := time.Parse(time.RFC3339, expiresBefore)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid expires_before format, expected RFC 3339")))
+			return
+		}
+		filter.ExpiresBefore = &at
+	}
+
+	batches, pagination, err := s.repo.StockBatchRepository.ListStockBatches(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":       batches,
+		"pagination": pagination,
+	})
+}
+
+// getWastageReportHandler reports the stock written off from the day "from"
+// through the day "to", both YYYY-MM-DD, by default over the last 30 days.
+func (s *Server) getWastageReportHandler(ctx *gin.Context) {
+	today := time.Now().Truncate(24 * time.Hour)
+	from, to := today.AddDate(0, 0, -29), today
+
+	for key, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		if value := ctx.Query(key); value != "" {
+			day, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid %s format, expected YYYY-MM-DD", key)))
+				return
+			}
+			*target = day
+		}
+	}
+
+	report, err := s.repo.StockBatchRepository.GetWastageReport(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type createStockMovementReq struct {
+	ProductID uint32  `json:"product_id" binding:"required"`
+	VariantID *uint32 `json:"variant_id"`
+	Kind      string  `json:"kind" binding:"omitempty,oneof=adjustment return wastage"`
+	// negative to take stock out
+	Quantity int64  `json:"quantity" binding:"required"`
+	Reason   string `json:"reason" binding:"required"`
+}
+
+func (s *Server) createStockMovementHandler(ctx *gin.Context) {
+	var req createStockMovementReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	if req.Kind == "" {
+		req.Kind = repository.StockMovementAdjustment
+	}
+
+	movements, err := s.repo.StockMovementRepository.AdjustStock(ctx, &repository.StockAdjustment{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Kind:      req.Kind,
+		Quantity:  req.Quantity,
+		Reason:    req.Reason,
+		UserID:    authUserID(ctx),
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": movements})
+}
+
+func (s *Server) listStockMovementsHandler(ctx *gin.Context) {
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	filter := &repository.StockMovementFilter{
+		Pagination: &pkg.Pagination{Page: pageNo, PageSize: pageSize},
+	}
+
+	if kind := ctx.Query("kind"); kind != "" {
+		filter.Kind = &kind
+	}
+
+	for key, target := range map[string]**uint32{
+		"product_id": &filter.ProductID,
+		"variant_id": &filter.VariantID,
+		"order_id":   &filter.OrderID,
+	} {
+		if value := ctx.Query(key); value != "" {
+			id, err := pkg.StringToUint32(value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid %s: %s", key, err.Error())))
+				return
+			}
+			*target = &id
+		}
+	}
+
+	movements, pagination, err := s.repo.StockMovementRepository.ListStockMovements(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":       movements,
+		"pagination": pagination,
+	})
+}
+
+// listStockDiscrepanciesHandler lists the products and variants whose
+// stock_quantity has drifted from their stock ledger.
+func (s *Server) listStockDiscrepanciesHandler(ctx *gin.Context) {
+	discrepancies, err := s.repo.StockMovementRepository.ListStockDiscrepancies(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": discrepancies})
+}
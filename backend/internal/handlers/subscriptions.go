@@ -9,11 +9,13 @@ import (
 )
 
 type createSubscriptionReq struct {
-	Name        string   `json:"name" binding:"required"`
-	Description string   `json:"description" binding:"required"`
-	ProductIds  []uint32 `json:"product_ids" binding:"required"`
-	AddOns      []uint32 `json:"add_ons"`
-	Price       float64  `json:"price" binding:"required"`
+	Name        string    `json:"name" binding:"required"`
+	Description string    `json:"description" binding:"required"`
+	ProductIds  []uint32  `json:"product_ids" binding:"required"`
+	AddOns      []uint32  `json:"add_ons"`
+	VariantIds  []uint32  `json:"variant_ids"`
+	Price       pkg.Money `json:"price"`
+	Currency    string    `json:"currency"`
 }
 
 func (s *Server) createSubscriptionHandler(ctx *gin.Context) {
@@ -23,12 +25,20 @@ func (s *Server) createSubscriptionHandler(ctx *gin.Context) {
 		return
 	}
 
+	currency, err := pkg.ParseCurrency(req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	subscription := &repository.Subscription{
 		Name:        req.Name,
 		Description: req.Description,
 		ProductIds:  req.ProductIds,
 		AddOns:      req.AddOns,
-		Price:       req.Price,
+		VariantIds:  req.VariantIds,
+		Price:       req.Price.WithCurrency(currency),
+		Currency:    currency,
 	}
 
 	if len(subscription.ProductIds) == 0 {
@@ -109,6 +119,11 @@ func (s *Server) listSubscriptionsHandler(ctx *gin.Context) {
 		filter.PriceTo = &priceToFloat
 	}
 
+	if err := bindListOrder(ctx, filter.Pagination, repository.SubscriptionSortFields); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	subscriptions, pagination, err := s.repo.SubscriptionRepository.ListSubscriptions(ctx, filter)
 	if err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
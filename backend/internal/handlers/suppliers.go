@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type createSupplierReq struct {
+	Name        string  `json:"name" binding:"required"`
+	ContactName *string `json:"contact_name"`
+	Email       *string `json:"email" binding:"omitempty,email"`
+	PhoneNumber *string `json:"phone_number"`
+	Address     *string `json:"address"`
+	Notes       *string `json:"notes"`
+}
+
+func (s *Server) createSupplierHandler(ctx *gin.Context) {
+	var req createSupplierReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	supplier, err := s.repo.SupplierRepository.CreateSupplier(ctx, &repository.Supplier{
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		Address:     req.Address,
+		Notes:       req.Notes,
+	})
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": supplier})
+}
+
+func (s *Server) getSupplierHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid supplier ID: %s", err.Error())))
+		return
+	}
+
+	supplier, err := s.repo.SupplierRepository.GetSupplierByID(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": supplier})
+}
+
+func (s *Server) listSuppliersHandler(ctx *gin.Context) {
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	filter := &repository.SupplierFilter{
+		Pagination: &pkg.Pagination{Page: pageNo, PageSize: pageSize},
+		Search:     nil,
+	}
+	if search := ctx.Query("search"); search != "" {
+		filter.Search = &search
+	}
+
+	suppliers, pagination, err := s.repo.SupplierRepository.ListSuppliers(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":       suppliers,
+		"pagination": pagination,
+	})
+}
+
+func (s *Server) updateSupplierHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid supplier ID: %s", err.Error())))
+		return
+	}
+
+	var req repository.UpdateSupplier
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+	req.ID = id
+
+	supplier, err := s.repo.SupplierRepository.UpdateSupplier(ctx, &req)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": supplier})
+}
+
+func (s *Server) deleteSupplierHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid supplier ID: %s", err.Error())))
+		return
+	}
+
+	if err := s.repo.SupplierRepository.DeleteSupplier(ctx, int64(id)); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Supplier deleted successfully"})
+}
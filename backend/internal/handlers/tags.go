@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type createTagGroupReq struct {
+	Name     string `json:"name" binding:"required"`
+	Slug     string `json:"slug"`
+	Position int32  `json:"position"`
+}
+
+func (s *Server) createTagGroupHandler(ctx *gin.Context) {
+	var req createTagGroupReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	tagGroup := &repository.TagGroup{
+		Name:     req.Name,
+		Slug:     pkg.Slugify(req.Slug),
+		Position: req.Position,
+	}
+
+	newTagGroup, err := s.repo.TagRepository.CreateTagGroup(ctx, tagGroup)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": newTagGroup})
+}
+
+func (s *Server) listTagGroupsHandler(ctx *gin.Context) {
+	tagGroups, err := s.repo.TagRepository.ListTagGroups(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": tagGroups})
+}
+
+func (s *Server) updateTagGroupHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tag group ID: %s", err.Error())))
+		return
+	}
+
+	var req repository.UpdateTagGroup
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+	req.ID = id
+
+	updatedTagGroup, err := s.repo.TagRepository.UpdateTagGroup(ctx, &req)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": updatedTagGroup})
+}
+
+func (s *Server) deleteTagGroupHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tag group ID: %s", err.Error())))
+		return
+	}
+
+	err = s.repo.TagRepository.DeleteTagGroup(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tag group deleted successfully"})
+}
+
+type createTagReq struct {
+	TagGroupID  uint32   `json:"tag_group_id" binding:"required"`
+	Name        string   `json:"name" binding:"required"`
+	Slug        string   `json:"slug"`
+	Description string   `json:"description"`
+	ImageUrl    []string `json:"image_url"`
+	Position    int32    `json:"position"`
+}
+
+func (s *Server) createTagHandler(ctx *gin.Context) {
+	var req createTagReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	tag := &repository.Tag{
+		TagGroupID:  req.TagGroupID,
+		Name:        req.Name,
+		Slug:        pkg.Slugify(req.Slug),
+		Description: req.Description,
+		ImageUrl:    req.ImageUrl,
+		Position:    req.Position,
+	}
+
+	newTag, err := s.repo.TagRepository.CreateTag(ctx, tag)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": newTag})
+}
+
+func (s *Server) listTagsHandler(ctx *gin.Context) {
+	var tagGroupID *uint32
+	if groupID := ctx.Query("tag_group_id"); groupID != "" {
+		id, err := pkg.StringToUint32(groupID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tag group ID: %s", err.Error())))
+			return
+		}
+		tagGroupID = &id
+	}
+
+	tags, err := s.repo.TagRepository.ListTags(ctx, tagGroupID)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": tags})
+}
+
+// getTagPageHandler serves a tag landing page, the tag with a page of its
+// products.
+func (s *Server) getTagPageHandler(ctx *gin.Context) {
+	tag, err := s.repo.TagRepository.GetTagBySlug(ctx, ctx.Param("slug"))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	pageNo, err := pkg.StringToUint32(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	pageSize, err := pkg.StringToUint32(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	tagIds := []int64{int64(tag.ID)}
+	filter := &repository.ProductFilter{
+		Pagination: &pkg.Pagination{Page: pageNo, PageSize: pageSize},
+		TagIDs:     &tagIds,
+	}
+
+	if err := bindListOrder(ctx, filter.Pagination, repository.ProductSortFields); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	products, pagination, _, err := s.repo.ProductRepository.ListProducts(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":       tag,
+		"products":   products,
+		"pagination": pagination,
+	})
+}
+
+func (s *Server) updateTagHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tag ID: %s", err.Error())))
+		return
+	}
+
+	var req repository.UpdateTag
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+	req.ID = id
+
+	updatedTag, err := s.repo.TagRepository.UpdateTag(ctx, &req)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": updatedTag})
+}
+
+func (s *Server) deleteTagHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tag ID: %s", err.Error())))
+		return
+	}
+
+	err = s.repo.TagRepository.DeleteTag(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type createTaxClassReq struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+func (s *Server) createTaxClassHandler(ctx *gin.Context) {
+	var req createTaxClassReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	taxClass := &repository.TaxClass{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	newTaxClass, err := s.repo.TaxRepository.CreateTaxClass(ctx, taxClass)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": newTaxClass})
+}
+
+func (s *Server) getTaxClassHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tax class ID: %s", err.Error())))
+		return
+	}
+
+	taxClass, err := s.repo.TaxRepository.GetTaxClassByID(ctx, int64(id))
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": taxClass})
+}
+
+func (s *Server) listTaxClassesHandler(ctx *gin.Context) {
+	taxClasses, err := s.repo.TaxRepository.ListTaxClasses(ctx)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": taxClasses})
+}
+
+func (s *Server) updateTaxClassHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tax class ID: %s", err.Error())))
+		return
+	}
+
+	var req repository.UpdateTaxClass
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+	req.ID = id
+
+	updatedTaxClass, err := s.repo.TaxRepository.UpdateTaxClass(ctx, &req)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": updatedTaxClass})
+}
+
+func (s *Server) deleteTaxClassHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tax class ID: %s", err.Error())))
+		return
+	}
+
+	if err := s.repo.TaxRepository.DeleteTaxClass(ctx, int64(id)); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tax class deleted successfully"})
+}
+
+type createTaxRateReq struct {
+	RateBps       uint32 `json:"rate_bps"`
+	EffectiveFrom string `json:"effective_from" binding:"required"` // parse into time.Time
+}
+
+func (s *Server) createTaxRateHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tax class ID: %s", err.Error())))
+		return
+	}
+
+	var req createTaxRateReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, err.Error())))
+		return
+	}
+
+	effectiveFrom, err := time.Parse("2006-01-02", req.EffectiveFrom)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid effective_from format, expected YYYY-MM-DD")))
+		return
+	}
+
+	taxRate := &repository.TaxRate{
+		TaxClassID:    id,
+		RateBps:       req.RateBps,
+		EffectiveFrom: effectiveFrom,
+	}
+
+	newTaxRate, err := s.repo.TaxRepository.CreateTaxRate(ctx, taxRate)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": newTaxRate})
+}
+
+func (s *Server) deleteTaxRateHandler(ctx *gin.Context) {
+	id, err := pkg.StringToUint32(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(pkg.Errorf(pkg.INVALID_ERROR, "invalid tax rate ID: %s", err.Error())))
+		return
+	}
+
+	if err := s.repo.TaxRepository.DeleteTaxRate(ctx, int64(id)); err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tax rate deleted successfully"})
+}
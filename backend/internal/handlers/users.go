@@ -26,6 +26,13 @@ func (s *Server) createUserHandler(ctx *gin.Context) {
 		return
 	}
 
+	// signing up is public, admins are created with cmd/admin or promoted by
+	// another admin
+	if strings.ToLower(req.IsAdmin) == "true" {
+		ctx.JSON(http.StatusForbidden, errorResponse(pkg.Errorf(pkg.FORBIDDEN_ERROR, "admins cannot sign up, ask an admin to promote the user")))
+		return
+	}
+
 	hashPassword, err := pkg.GenerateHashPassword(req.Password, s.config.PASSWORD_COST)
 	if err != nil {
 		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
@@ -39,7 +46,7 @@ func (s *Server) createUserHandler(ctx *gin.Context) {
 		Email:        req.Email,
 		PhoneNumber:  req.PhoneNumber,
 		Password:     &hashPassword,
-		IsAdmin:      strings.ToLower(req.IsAdmin) == "true",
+		IsAdmin:      false,
 		RefreshToken: &defaultRefreshToken,
 		Address:      req.Address,
 	}
@@ -99,6 +106,12 @@ func (s *Server) getUserHandler(ctx *gin.Context) {
 }
 
 func (s *Server) listUsersHandler(ctx *gin.Context) {
+	filter, err := bindUserFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	pageNoStr := ctx.DefaultQuery("page", "1")
 	pageNo, err := pkg.StringToUint32(pageNoStr)
 	if err != nil {
@@ -106,6 +119,7 @@ func (s *Server) listUsersHandler(ctx *gin.Context) {
 
 		return
 	}
+	filter.Pagination.Page = pageNo
 
 	pageSizeStr := ctx.DefaultQuery("limit", "10")
 	pageSize, err := pkg.StringToUint32(pageSizeStr)
@@ -114,15 +128,24 @@ func (s *Server) listUsersHandler(ctx *gin.Context) {
 
 		return
 	}
+	filter.Pagination.PageSize = pageSize
 
+	users, pagination, err := s.repo.UserRepository.ListUsers(ctx, filter)
+	if err != nil {
+		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": users, "pagination": pagination})
+}
+
+// bindUserFilter reads the filters and sort of the user list.
+func bindUserFilter(ctx *gin.Context) (*repository.UserFilter, error) {
 	filter := &repository.UserFilter{
-		Pagination: &pkg.Pagination{
-			Page:     pageNo,
-			PageSize: pageSize,
-		},
-		Search:   nil,
-		IsAdmin:  nil,
-		IsActive: nil,
+		Pagination: &pkg.Pagination{},
+		Search:     nil,
+		IsAdmin:    nil,
+		IsActive:   nil,
 	}
 
 	if search := ctx.Query("search"); search != "" {
@@ -132,8 +155,7 @@ func (s *Server) listUsersHandler(ctx *gin.Context) {
 	if isAdmin := ctx.Query("is_admin"); isAdmin != "" {
 		isAdminBool, err := pkg.StringToBool(isAdmin)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, errorResponse(err))
-			return
+			return nil, err
 		}
 		filter.IsAdmin = &isAdminBool
 	}
@@ -141,19 +163,16 @@ func (s *Server) listUsersHandler(ctx *gin.Context) {
 	if isActive := ctx.Query("is_active"); isActive != "" {
 		isActiveBool, err := pkg.StringToBool(isActive)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, errorResponse(err))
-			return
+			return nil, err
 		}
 		filter.IsActive = &isActiveBool
 	}
 
-	users, pagination, err := s.repo.UserRepository.ListUsers(ctx, filter)
-	if err != nil {
-		ctx.JSON(pkg.ErrorToStatusCode(err), errorResponse(err))
-		return
+	if err := bindListOrder(ctx, filter.Pagination, repository.UserSortFields); err != nil {
+		return nil, err
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"data": users, "pagination": pagination})
+	return filter, nil
 }
 
 func (s *Server) updateUserHandler(ctx *gin.Context) {
@@ -0,0 +1,100 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// ThumbnailSize bounds the width and height of thumbnails.
+	ThumbnailSize = 400
+	// maxPixels refuses images that would take too much memory to decode.
+	maxPixels = 40_000_000
+)
+
+// Extensions are the accepted content types with the extension stored files
+// get.
+var Extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// Image is an uploaded image checked by Process, with its thumbnail.
+type Image struct {
+	ContentType string
+	Width       int
+	Height      int
+
+	Thumbnail            []byte
+	ThumbnailContentType string
+}
+
+// Process checks from its content that data is a JPEG, PNG or WebP image and
+// scales it down to a thumbnail that fits in ThumbnailSize. PNG thumbnails stay
+// PNG to keep transparency, the others are JPEG.
+func Process(data []byte) (*Image, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := Extensions[contentType]; !ok {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "unsupported image type %s, expected JPEG, PNG or WebP", contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid image: %s", err.Error())
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "image of %dx%d pixels is too large", config.Width, config.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid image: %s", err.Error())
+	}
+
+	width, height := thumbnailBounds(config.Width, config.Height)
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), src, src.Bounds(), draw.Src, nil)
+
+	img := &Image{
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+	}
+
+	var buf bytes.Buffer
+	if contentType == "image/png" {
+		err = png.Encode(&buf, thumbnail)
+		img.ThumbnailContentType = "image/png"
+	} else {
+		err = jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 85})
+		img.ThumbnailContentType = "image/jpeg"
+	}
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error encoding thumbnail: %s", err.Error())
+	}
+	img.Thumbnail = buf.Bytes()
+
+	return img, nil
+}
+
+// thumbnailBounds scales width and height down to fit in ThumbnailSize,
+// keeping the aspect ratio. Smaller images keep their size.
+func thumbnailBounds(width, height int) (int, int) {
+	if width <= ThumbnailSize && height <= ThumbnailSize {
+		return width, height
+	}
+
+	if width >= height {
+		return ThumbnailSize, max(1, height*ThumbnailSize/width)
+	}
+
+	return max(1, width*ThumbnailSize/height), ThumbnailSize
+}
@@ -0,0 +1,234 @@
+package imports
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+// listSeparator separates the image URLs and the options within a cell.
+const listSeparator = "|"
+
+const (
+	// MaxProductFileSize is the largest product import file accepted, in bytes.
+	MaxProductFileSize = 5 << 20
+	// MaxProductRows is the most rows a product import can have below its header.
+	MaxProductRows = 5000
+)
+
+// ProductColumns are the columns of a product import. Only the sku column is
+// required in the header; new products also need a name, a category and,
+// unless they have variant rows, a price. Products that already exist only
+// change in the cells that are filled, and keep their variants unless the
+// file has variant rows for them.
+// A row with a product_sku is a variant of the product on the row with that
+// sku, and takes its options as "Name=Value" pairs, e.g. "Stems=12|Colour=Red".
+// Image URLs are separated by "|" too.
+var ProductColumns = []string{
+	"sku",
+	"product_sku",
+	"name",
+	"description",
+	"category",
+	"price",
+	"currency",
+	"is_flowers",
+	"is_add_on",
+	"is_message_card",
+	"stock_quantity",
+	"reorder_threshold",
+	"image_urls",
+	"options",
+}
+
+// ReadProductRows reads the rows of a product import from CSV with a header
+// naming its columns. Rows that cannot be read carry their problems in
+// Errors, the file is only rejected when it is not CSV, its header is wrong
+// or it has more than MaxProductRows rows.
+func ReadProductRows(r io.Reader) ([]repository.ProductImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "the file is empty")
+		}
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid csv header: %s", err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheets may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(ProductColumns, name) {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "unknown column %q, expected some of %s", name, strings.Join(ProductColumns, ", "))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "column %q is listed more than once", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["sku"]; !ok {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "the sku column is required")
+	}
+
+	var rows []repository.ProductImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows) == MaxProductRows {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "the file has more than %d rows, split it into smaller imports", MaxProductRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			// rows with a different number of fields are still returned
+			if !errors.Is(err, csv.ErrFieldCount) {
+				return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid csv: %s", err.Error())
+			}
+			rows = append(rows, repository.ProductImportRow{
+				Line:   line,
+				SKU:    cell(record, columns, "sku"),
+				Errors: []string{fmt.Sprintf("the row has %d fields, the header %d", len(record), len(header))},
+			})
+			continue
+		}
+
+		rows = append(rows, readProductRow(line, record, columns))
+	}
+
+	if len(rows) == 0 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "the file has no rows below its header")
+	}
+
+	return rows, nil
+}
+
+func readProductRow(line int, record []string, columns map[string]int) repository.ProductImportRow {
+	row := repository.ProductImportRow{
+		Line:       line,
+		SKU:        cell(record, columns, "sku"),
+		ProductSKU: cell(record, columns, "product_sku"),
+		Name:       cell(record, columns, "name"),
+		Category:   cell(record, columns, "category"),
+	}
+
+	if value := cell(record, columns, "description"); value != "" {
+		row.Description = &value
+	}
+
+	if value := cell(record, columns, "currency"); value != "" {
+		currency, err := pkg.ParseCurrency(value)
+		if err != nil {
+			row.Errors = append(row.Errors, pkg.ErrorMessage(err))
+		}
+		row.Currency = currency
+	}
+
+	if value := cell(record, columns, "price"); value != "" {
+		currency := row.Currency
+		if currency == "" {
+			currency = pkg.DefaultCurrency
+		}
+		price, err := pkg.ParseMoney(value, currency)
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid price %q", value))
+		} else {
+			row.Price = &price
+		}
+	}
+
+	row.IsFlowers = readBool(&row, record, columns, "is_flowers")
+	row.IsAddOn = readBool(&row, record, columns, "is_add_on")
+	row.IsMessageCard = readBool(&row, record, columns, "is_message_card")
+	row.StockQuantity = readInt(&row, record, columns, "stock_quantity")
+	row.ReorderThreshold = readInt(&row, record, columns, "reorder_threshold")
+
+	if value := cell(record, columns, "image_urls"); value != "" {
+		row.ImageUrl = splitList(value)
+	}
+
+	if value := cell(record, columns, "options"); value != "" {
+		for _, pair := range splitList(value) {
+			name, value, ok := strings.Cut(pair, "=")
+			name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+			if !ok || name == "" || value == "" {
+				row.Errors = append(row.Errors, fmt.Sprintf("invalid option %q, expected Name=Value", pair))
+				continue
+			}
+			row.Options = append(row.Options, repository.VariantOption{Name: name, Value: value})
+		}
+	}
+
+	return row
+}
+
+// readBool reads a true or false cell, nil when it is empty.
+func readBool(row *repository.ProductImportRow, record []string, columns map[string]int, column string) *bool {
+	value := cell(record, columns, column)
+	if value == "" {
+		return nil
+	}
+
+	var flag bool
+	switch strings.ToLower(value) {
+	case "yes", "y":
+		flag = true
+	case "no", "n":
+		flag = false
+	default:
+		var err error
+		if flag, err = strconv.ParseBool(value); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid %s %q, expected true or false", column, value))
+			return nil
+		}
+	}
+
+	return &flag
+}
+
+// readInt reads a whole number cell, nil when it is empty.
+func readInt(row *repository.ProductImportRow, record []string, columns map[string]int, column string) *int64 {
+	value := cell(record, columns, column)
+	if value == "" {
+		return nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		row.Errors = append(row.Errors, fmt.Sprintf("invalid %s %q, expected a whole number", column, value))
+		return nil
+	}
+
+	return &n
+}
+
+func cell(record []string, columns map[string]int, column string) string {
+	i, ok := columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
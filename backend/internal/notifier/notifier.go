@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+	"log"
+
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+)
+
+var _ services.INotifier = (*LogNotifier)(nil)
+
+// LogNotifier writes notifications to the server log. It is used until an
+// email or SMS provider is configured.
+type LogNotifier struct{}
+
+func NewLogNotifier() services.INotifier {
+	return &LogNotifier{}
+}
+
+func (ln *LogNotifier) Send(ctx context.Context, notification services.Notification) error {
+	attachments := make([]string, len(notification.Attachments))
+	for i, attachment := range notification.Attachments {
+		attachments[i] = attachment.Filename
+	}
+
+	log.Printf("notification to email=%q phone=%q: %s (attachments: %v)", notification.Email, notification.PhoneNumber, notification.Subject, attachments)
+
+	return nil
+}
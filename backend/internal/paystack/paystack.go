@@ -26,10 +26,12 @@ func NewPaystack(secretKey string, callbackUrl string) services.IPayStack {
 	}
 }
 
-func (ps Paystack) InitializePayment(email string, amount int64) (string, string, error) {
+func (ps Paystack) InitializePayment(email string, amount pkg.Money) (string, string, error) {
+	// paystack expects amounts in the currency's subunit
 	payload := map[string]string{
 		"email":        email,
-		"amount":       fmt.Sprintf("%d", amount),
+		"amount":       fmt.Sprintf("%d", amount.MinorUnits()),
+		"currency":     string(amount.Currency()),
 		"callback_url": ps.CallbackURL,
 	}
 
@@ -74,7 +76,7 @@ func (ps Paystack) InitializePayment(email string, amount int64) (string, string
 	return result.Data.AccessCode, result.Data.Reference, nil
 }
 
-func (ps Paystack) VerifyPayment(reference string, amount int64) (string, error) {
+func (ps Paystack) VerifyPayment(reference string, amount pkg.Money) (string, error) {
 	req, err := http.NewRequest(http.MethodGet, ps.BaseURL+"/transaction/verify/"+reference, nil)
 	if err != nil {
 		return "", pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create request: %s", err.Error())
@@ -94,8 +96,9 @@ func (ps Paystack) VerifyPayment(reference string, amount int64) (string, error)
 		Status  bool   `json:"status"`
 		Message string `json:"message"`
 		Data    struct {
-			Status string `json:"status"`
-			Amount int64  `json:"amount"`
+			Status   string `json:"status"`
+			Amount   int64  `json:"amount"`
+			Currency string `json:"currency"`
 		} `json:"data"`
 	}
 
@@ -107,8 +110,13 @@ func (ps Paystack) VerifyPayment(reference string, amount int64) (string, error)
 		return "", pkg.Errorf(pkg.NOT_FOUND_ERROR, "failed to verify payment: %s", result.Message)
 	}
 
-	if result.Data.Amount != amount {
-		return "", pkg.Errorf(pkg.INVALID_ERROR, "amount mismatch: expected %d, got %d", amount, result.Data.Amount)
+	if pkg.Currency(result.Data.Currency) != amount.Currency() {
+		return "", pkg.Errorf(pkg.INVALID_ERROR, "currency mismatch: expected %s, got %s", amount.Currency(), result.Data.Currency)
+	}
+
+	paid := pkg.NewMoney(result.Data.Amount, pkg.Currency(result.Data.Currency))
+	if !paid.Equal(amount) {
+		return "", pkg.Errorf(pkg.INVALID_ERROR, "amount mismatch: expected %s, got %s", amount, paid)
 	}
 
 	return result.Data.Status, nil
@@ -0,0 +1,379 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+var _ repository.AnalyticsRepository = (*AnalyticsRepository)(nil)
+
+// deliveriesPerYear turns the price per delivery of a subscription into its
+// monthly value.
+var deliveriesPerYear = map[string]int64{
+	"weekly":    52,
+	"bi_weekly": 26,
+	"monthly":   12,
+}
+
+type AnalyticsRepository struct {
+	queries *generated.Queries
+}
+
+func NewAnalyticsRepository(queries *generated.Queries) *AnalyticsRepository {
+	return &AnalyticsRepository{queries: queries}
+}
+
+func (ar *AnalyticsRepository) GetDashboard(ctx context.Context) (*repository.Dashboard, error) {
+	revenueRows, err := ar.queries.TotalRevenue(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching total revenue: %s", err.Error())
+	}
+	totalRevenue := make(map[pkg.Currency]pkg.Money, len(revenueRows))
+	for _, row := range revenueRows {
+		revenue, err := pkg.NumericToMoney(row.TotalRevenue, pkg.Currency(row.Currency))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid total revenue: %s", err.Error())
+		}
+		totalRevenue[revenue.Currency()] = revenue
+	}
+	totalProducts, err := ar.queries.TotalProducts(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching total products: %s", err.Error())
+	}
+	totalOrders, err := ar.queries.TotalOrders(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching total orders: %s", err.Error())
+	}
+	activeSubscriptions, err := ar.queries.ActiveSubscriptions(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching active subscriptions: %s", err.Error())
+	}
+	recentOrders, err := ar.queries.GetRecentOrders(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching recent orders: %s", err.Error())
+	}
+	categories, err := ar.queries.GetCategoriesWithProductCount(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching categories with product count: %s", err.Error())
+	}
+	lowStock, err := ar.queries.ListLowStockItems(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching low stock: %s", err.Error())
+	}
+
+	dashboard := &repository.Dashboard{
+		TotalRevenue:        totalRevenue,
+		TotalProducts:       totalProducts,
+		TotalOrders:         totalOrders,
+		ActiveSubscriptions: activeSubscriptions,
+		RecentOrders:        make([]*repository.Order, len(recentOrders)),
+		Categories:          make([]*repository.Category, len(categories)),
+		LowStock:            generatedLowStockItemsToRepo(lowStock),
+	}
+
+	for i, order := range recentOrders {
+		if dashboard.RecentOrders[i], err = generatedOrderToRepo(order); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, category := range categories {
+		dashboard.Categories[i] = generatedCategoryToRepo(category)
+	}
+
+	return dashboard, nil
+}
+
+func (ar *AnalyticsRepository) GetRevenue(ctx context.Context, filter *repository.AnalyticsFilter) ([]repository.RevenuePoint, error) {
+	if err := validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.queries.GetRevenueByPeriod(ctx, generated.GetRevenueByPeriodParams{
+		Granularity: filter.Granularity,
+		From:        filter.From,
+		To:          filter.To,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting revenue: %s", err.Error())
+	}
+
+	points := make([]repository.RevenuePoint, len(rows))
+	for i, row := range rows {
+		revenue, err := pkg.NumericToMoney(row.Revenue, pkg.Currency(row.Currency))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid revenue for %s: %s", row.Period.Format(time.DateOnly), err.Error())
+		}
+
+		points[i] = repository.RevenuePoint{
+			Period:   row.Period,
+			Currency: revenue.Currency(),
+			Payments: row.Payments,
+			Revenue:  revenue,
+		}
+	}
+
+	return points, nil
+}
+
+func (ar *AnalyticsRepository) GetOrdersByStatus(ctx context.Context, filter *repository.AnalyticsFilter) ([]repository.OrderStatusCount, error) {
+	if err := validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.queries.GetOrdersByStatus(ctx, generated.GetOrdersByStatusParams{
+		From: filter.From,
+		To:   filter.To,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting orders by status: %s", err.Error())
+	}
+
+	counts := make([]repository.OrderStatusCount, len(rows))
+	for i, row := range rows {
+		counts[i] = repository.OrderStatusCount{
+			Status: row.Status,
+			Orders: row.Orders,
+		}
+	}
+
+	return counts, nil
+}
+
+func (ar *AnalyticsRepository) GetAverageOrderValues(ctx context.Context, filter *repository.AnalyticsFilter) ([]repository.AverageOrderValue, error) {
+	if err := validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.queries.GetAverageOrderValues(ctx, generated.GetAverageOrderValuesParams{
+		From: filter.From,
+		To:   filter.To,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting average order values: %s", err.Error())
+	}
+
+	values := make([]repository.AverageOrderValue, len(rows))
+	for i, row := range rows {
+		revenue, err := pkg.NumericToMoney(row.Revenue, pkg.Currency(row.Currency))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid revenue in %s: %s", row.Currency, err.Error())
+		}
+		// rows are only returned for currencies with orders
+		average, err := revenue.MulRatio(1, row.Orders)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error averaging orders in %s: %s", row.Currency, err.Error())
+		}
+
+		values[i] = repository.AverageOrderValue{
+			Currency: revenue.Currency(),
+			Orders:   row.Orders,
+			Revenue:  revenue,
+			Average:  average,
+		}
+	}
+
+	return values, nil
+}
+
+func (ar *AnalyticsRepository) GetTopProducts(ctx context.Context, filter *repository.AnalyticsFilter) ([]repository.TopProduct, error) {
+	if err := validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.queries.GetTopProducts(ctx, generated.GetTopProductsParams{
+		From:  filter.From,
+		To:    filter.To,
+		Limit: int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting top products: %s", err.Error())
+	}
+
+	products := make([]repository.TopProduct, len(rows))
+	for i, row := range rows {
+		revenue, err := pkg.NumericToMoney(row.Revenue, pkg.Currency(row.Currency))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid revenue for product with id %d: %s", row.ProductID, err.Error())
+		}
+
+		products[i] = repository.TopProduct{
+			ProductID:   uint32(row.ProductID),
+			ProductName: row.ProductName,
+			Currency:    revenue.Currency(),
+			Quantity:    row.Quantity,
+			Revenue:     revenue,
+		}
+	}
+
+	return products, nil
+}
+
+func (ar *AnalyticsRepository) GetTopCategories(ctx context.Context, filter *repository.AnalyticsFilter) ([]repository.TopCategory, error) {
+	if err := validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.queries.GetTopCategories(ctx, generated.GetTopCategoriesParams{
+		From:  filter.From,
+		To:    filter.To,
+		Limit: int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting top categories: %s", err.Error())
+	}
+
+	categories := make([]repository.TopCategory, len(rows))
+	for i, row := range rows {
+		revenue, err := pkg.NumericToMoney(row.Revenue, pkg.Currency(row.Currency))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid revenue for category with id %d: %s", row.CategoryID, err.Error())
+		}
+
+		categories[i] = repository.TopCategory{
+			CategoryID:   uint32(row.CategoryID),
+			CategoryName: row.CategoryName,
+			Currency:     revenue.Currency(),
+			Quantity:     row.Quantity,
+			Revenue:      revenue,
+		}
+	}
+
+	return categories, nil
+}
+
+func (ar *AnalyticsRepository) GetSubscriptionMetrics(ctx context.Context, filter *repository.AnalyticsFilter) (*repository.SubscriptionMetrics, error) {
+	if err := validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	counts, err := ar.queries.GetSubscriptionCounts(ctx, generated.GetSubscriptionCountsParams{
+		From: filter.From,
+		To:   filter.To,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting subscriptions: %s", err.Error())
+	}
+
+	prices, err := ar.queries.ListSubscriptionPrices(ctx, filter.To)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing subscription prices: %s", err.Error())
+	}
+
+	// amounts in different currencies cannot be summed
+	mrr := make(map[pkg.Currency]pkg.Money)
+	for _, row := range prices {
+		deliveries, ok := deliveriesPerYear[row.Frequency]
+		if !ok {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "unknown frequency %q of subscriptions", row.Frequency)
+		}
+
+		currency := pkg.Currency(row.Currency)
+		price, err := pkg.NumericToMoney(row.Price, currency)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price of %s %s subscriptions: %s", row.Frequency, currency, err.Error())
+		}
+		monthly, err := price.MulRatio(deliveries, 12)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error working out the mrr of %s %s subscriptions: %s", row.Frequency, currency, err.Error())
+		}
+
+		total, ok := mrr[currency]
+		if !ok {
+			total = pkg.NewMoney(0, currency)
+		}
+		if mrr[currency], err = total.Add(monthly); err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error working out the mrr in %s: %s", currency, err.Error())
+		}
+	}
+
+	metrics := &repository.SubscriptionMetrics{
+		ActiveAtStart: counts.ActiveAtStart,
+		ActiveAtEnd:   counts.ActiveAtEnd,
+		New:           counts.Started,
+		Churned:       counts.Churned,
+		ChurnRate:     0,
+		MRR:           mrr,
+	}
+	if counts.ActiveAtStart > 0 {
+		metrics.ChurnRate = float64(counts.Churned) / float64(counts.ActiveAtStart)
+	}
+
+	return metrics, nil
+}
+
+func (ar *AnalyticsRepository) GetCustomerMetrics(ctx context.Context, filter *repository.AnalyticsFilter) (*repository.CustomerMetrics, error) {
+	if err := validateAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	counts, err := ar.queries.GetCustomerCounts(ctx, generated.GetCustomerCountsParams{
+		From: filter.From,
+		To:   filter.To,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting customers: %s", err.Error())
+	}
+
+	return &repository.CustomerMetrics{
+		Customers: counts.Customers,
+		New:       counts.NewCustomers,
+		Returning: counts.Customers - counts.NewCustomers,
+	}, nil
+}
+
+func validateAnalyticsFilter(filter *repository.AnalyticsFilter) error {
+	if !filter.To.After(filter.From) {
+		return pkg.Errorf(pkg.INVALID_ERROR, "to must be after from")
+	}
+
+	switch filter.Granularity {
+	case "", repository.GranularityDay, repository.GranularityWeek, repository.GranularityMonth:
+	default:
+		return pkg.Errorf(pkg.INVALID_ERROR, "granularity must be one of day, week or month")
+	}
+
+	return nil
+}
+
+// generatedOrderToRepo converts an order without its items.
+func generatedOrderToRepo(order generated.Order) (*repository.Order, error) {
+	currency := pkg.Currency(order.Currency)
+	amounts, err := numericsToMoney(currency, order.TotalAmount, order.NetAmount, order.TaxAmount)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amounts for order %d: %s", order.ID, err.Error())
+	}
+
+	rslt := &repository.Order{
+		ID:               uint32(order.ID),
+		Buyer:            buyerToRepo(order.UserName, order.UserPhoneNumber, order.BuyerEmail),
+		Recipient:        repository.Contact{Name: order.RecipientName, PhoneNumber: order.RecipientPhoneNumber},
+		TotalAmount:      amounts[0],
+		NetAmount:        amounts[1],
+		TaxAmount:        amounts[2],
+		Currency:         currency,
+		PaymentStatus:    order.PaymentStatus,
+		Status:           order.Status,
+		DeliveryDate:     order.DeliveryDate,
+		TimeSlot:         order.TimeSlot,
+		ByAdmin:          order.ByAdmin,
+		DeliveryAddress:  deliveryAddressToRepo(order.AddressArea, order.AddressStreet, order.AddressBuilding, order.AddressLandmark, order.AddressLatitude, order.AddressLongitude),
+		PaymentReference: nil,
+		DeletedAt:        nil,
+		CreatedAt:        order.CreatedAt,
+	}
+
+	if order.PaymentReference.Valid {
+		rslt.PaymentReference = &order.PaymentReference.String
+	}
+
+	if order.DeletedAt.Valid {
+		rslt.DeletedAt = &order.DeletedAt.Time
+	}
+
+	return rslt, nil
+}
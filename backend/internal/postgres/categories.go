@@ -23,11 +23,29 @@ func NewCategoryRepository(queries *generated.Queries) *CategoryRepository {
 }
 
 func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *repository.Category) (*repository.Category, error) {
-	generatedCategory, err := cr.queries.CreateCategory(ctx, generated.CreateCategoryParams{
+	params := generated.CreateCategoryParams{
 		Name:        category.Name,
 		Description: category.Description,
 		ImageUrl:    category.ImageUrl,
-	})
+		TaxClassID:  pgtype.Int8{Valid: false},
+		ParentID:    pgtype.Int8{Valid: false},
+	}
+
+	if category.TaxClassID != nil {
+		if exists, _ := cr.queries.TaxClassExists(ctx, int64(*category.TaxClassID)); !exists {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tax class with id %d not found", *category.TaxClassID)
+		}
+		params.TaxClassID = pgtype.Int8{Valid: true, Int64: int64(*category.TaxClassID)}
+	}
+
+	if category.ParentID != nil {
+		if exists, _ := cr.queries.CategoryExists(ctx, int64(*category.ParentID)); !exists {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "parent category with id %d not found", *category.ParentID)
+		}
+		params.ParentID = pgtype.Int8{Valid: true, Int64: int64(*category.ParentID)}
+	}
+
+	generatedCategory, err := cr.queries.CreateCategory(ctx, params)
 	if err != nil {
 		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
 			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "%s", err.Error())
@@ -51,20 +69,7 @@ func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id int64) (*r
 		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching category by id: %s", err.Error())
 	}
 
-	category := &repository.Category{
-		ID:          uint32(generatedCategory.ID),
-		Name:        generatedCategory.Name,
-		Description: generatedCategory.Description,
-		ImageUrl:    generatedCategory.ImageUrl,
-		CreatedAt:   generatedCategory.CreatedAt,
-		DeletedAt:   nil,
-	}
-
-	if generatedCategory.DeletedAt.Valid {
-		category.DeletedAt = &generatedCategory.DeletedAt.Time
-	}
-
-	return category, nil
+	return generatedCategoryToRepo(generatedCategory), nil
 }
 
 func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *repository.UpdateCategory) (*repository.Category, error) {
@@ -73,6 +78,8 @@ func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *repo
 		Name:        pgtype.Text{Valid: false},
 		Description: pgtype.Text{Valid: false},
 		ImageUrl:    nil,
+		TaxClassID:  pgtype.Int8{Valid: false},
+		ParentID:    pgtype.Int8{Valid: false},
 	}
 
 	if category.Name != nil {
@@ -90,6 +97,32 @@ func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *repo
 	if category.ImageUrl != nil {
 		params.ImageUrl = *category.ImageUrl
 	}
+	if category.TaxClassID != nil {
+		if exists, _ := cr.queries.TaxClassExists(ctx, int64(*category.TaxClassID)); !exists {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tax class with id %d not found", *category.TaxClassID)
+		}
+		params.TaxClassID = pgtype.Int8{Valid: true, Int64: int64(*category.TaxClassID)}
+	}
+	if category.ParentID != nil {
+		if *category.ParentID != 0 {
+			if exists, _ := cr.queries.CategoryExists(ctx, int64(*category.ParentID)); !exists {
+				return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "parent category with id %d not found", *category.ParentID)
+			}
+
+			// a category cannot move under itself or one of its descendants
+			inSubtree, err := cr.queries.IsCategoryInSubtree(ctx, generated.IsCategoryInSubtreeParams{
+				CategoryID: int64(*category.ParentID),
+				RootID:     int64(category.ID),
+			})
+			if err != nil {
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error checking category parent: %s", err.Error())
+			}
+			if inSubtree {
+				return nil, pkg.Errorf(pkg.INVALID_ERROR, "category %d cannot be moved under itself or one of its sub-categories", category.ID)
+			}
+		}
+		params.ParentID = pgtype.Int8{Valid: true, Int64: int64(*category.ParentID)}
+	}
 
 	generatedCategory, err := cr.queries.UpdateCategory(ctx, params)
 	if err != nil {
@@ -99,20 +132,7 @@ func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *repo
 		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error updating category: %s", err.Error())
 	}
 
-	rslt := &repository.Category{
-		ID:          uint32(generatedCategory.ID),
-		Name:        generatedCategory.Name,
-		Description: generatedCategory.Description,
-		ImageUrl:    generatedCategory.ImageUrl,
-		CreatedAt:   generatedCategory.CreatedAt,
-		DeletedAt:   nil,
-	}
-
-	if generatedCategory.DeletedAt.Valid {
-		rslt.DeletedAt = &generatedCategory.DeletedAt.Time
-	}
-
-	return rslt, nil
+	return generatedCategoryToRepo(generatedCategory), nil
 }
 
 func (cr *CategoryRepository) ListCategories(ctx context.Context, filter *repository.CategoryFilter) ([]*repository.Category, *pkg.Pagination, error) {
@@ -146,20 +166,31 @@ func (cr *CategoryRepository) ListCategories(ctx context.Context, filter *reposi
 
 	categoryList := make([]*repository.Category, len(generatedCategories))
 	for i, cat := range generatedCategories {
-		categoryList[i] = &repository.Category{
-			ID:          uint32(cat.ID),
-			Name:        cat.Name,
-			Description: cat.Description,
-			ImageUrl:    cat.ImageUrl,
-			DeletedAt:   nil,
-			CreatedAt:   cat.CreatedAt,
-		}
+		categoryList[i] = generatedCategoryToRepo(cat)
 	}
 
 	return categoryList, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
 }
 
+// DeleteCategory refuses to delete a category that still has sub-categories
+// or products, they have to be moved or deleted first.
 func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id int64) error {
+	children, err := cr.queries.CountCategoryChildren(ctx, pgtype.Int8{Valid: true, Int64: id})
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error counting sub-categories: %s", err.Error())
+	}
+	if children > 0 {
+		return pkg.Errorf(pkg.INVALID_ERROR, "category %d has %d sub-categories", id, children)
+	}
+
+	products, err := cr.queries.CountCategoryProducts(ctx, id)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error counting category products: %s", err.Error())
+	}
+	if products > 0 {
+		return pkg.Errorf(pkg.INVALID_ERROR, "category %d has %d products", id, products)
+	}
+
 	if err := cr.queries.DeleteCategory(ctx, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "category with ID %d not found", id)
@@ -168,3 +199,91 @@ func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id int64) erro
 	}
 	return nil
 }
+
+// GetCategoryTree returns the top level categories with their sub-categories
+// nested under them. Product counts roll up, so a parent counts the products
+// of its whole subtree.
+func (cr *CategoryRepository) GetCategoryTree(ctx context.Context) ([]*repository.Category, error) {
+	generatedCategories, err := cr.queries.GetCategoriesWithProductCount(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching categories with product count: %s", err.Error())
+	}
+
+	categories := make([]*repository.Category, len(generatedCategories))
+	byID := make(map[uint32]*repository.Category, len(generatedCategories))
+	for i, category := range generatedCategories {
+		categories[i] = generatedCategoryToRepo(category)
+		byID[categories[i].ID] = categories[i]
+	}
+
+	return buildCategoryTree(categories, byID), nil
+}
+
+// RecountProductCounts recomputes the stored product count of every category
+// from the products table and returns how many categories were off.
+func (cr *CategoryRepository) RecountProductCounts(ctx context.Context) (int64, error) {
+	repaired, err := cr.queries.RecountCategoryProducts(ctx)
+	if err != nil {
+		return 0, pkg.Errorf(pkg.INTERNAL_ERROR, "error recounting category products: %s", err.Error())
+	}
+
+	return repaired, nil
+}
+
+// buildCategoryTree nests the categories under their parents and adds the
+// product counts of sub-categories to their ancestors. Categories whose parent
+// is missing, e.g. deleted, are treated as top level.
+func buildCategoryTree(categories []*repository.Category, byID map[uint32]*repository.Category) []*repository.Category {
+	roots := []*repository.Category{}
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if parent, ok := byID[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+
+	var rollUp func(category *repository.Category) int64
+	rollUp = func(category *repository.Category) int64 {
+		for _, child := range category.Children {
+			category.ProductCount += rollUp(child)
+		}
+
+		return category.ProductCount
+	}
+	for _, root := range roots {
+		rollUp(root)
+	}
+
+	return roots
+}
+
+func generatedCategoryToRepo(category generated.Category) *repository.Category {
+	rslt := &repository.Category{
+		ID:           uint32(category.ID),
+		Name:         category.Name,
+		Description:  category.Description,
+		ImageUrl:     category.ImageUrl,
+		ProductCount: category.ProductCount,
+		DeletedAt:    nil,
+		CreatedAt:    category.CreatedAt,
+	}
+
+	if category.DeletedAt.Valid {
+		rslt.DeletedAt = &category.DeletedAt.Time
+	}
+
+	if category.TaxClassID.Valid {
+		taxClassID := uint32(category.TaxClassID.Int64)
+		rslt.TaxClassID = &taxClassID
+	}
+
+	if category.ParentID.Valid {
+		parentID := uint32(category.ParentID.Int64)
+		rslt.ParentID = &parentID
+	}
+
+	return rslt
+}
@@ -24,19 +24,45 @@ type PostgresRepo struct {
 	OrderRepository                *OrderRepository
 	PaymentRepository              *PaymentRepository
 	PaystackRepository             *PaystackRepository
+	TaxRepository                  *TaxRepository
+	InvoiceRepository              *InvoiceRepository
+	TagRepository                  *TagRepository
+	PriceRuleRepository            *PriceRuleRepository
+	ImageRepository                *ImageRepository
+	StockBatchRepository           *StockBatchRepository
+	StockMovementRepository        *StockMovementRepository
+	LowStockRepository             *LowStockRepository
+	SupplierRepository             *SupplierRepository
+	PurchaseOrderRepository        *PurchaseOrderRepository
+	RecipeRepository               *RecipeRepository
+	AnalyticsRepository            *AnalyticsRepository
+	ProductImportRepository        *ProductImportRepository
 }
 
 func NewPostgresRepo(store *Store) *PostgresRepo {
 	return &PostgresRepo{
-		UserRepository:                 NewUserRepository(generated.New(store.pool)),
-		SubscriptionRepository:         NewSubscriptionRepository(generated.New(store.pool)),
+		UserRepository:                 NewUserRepository(store),
+		SubscriptionRepository:         NewSubscriptionRepository(store),
 		SubscriptionDeliveryRepository: NewSubscriptionDeliveryRepository(generated.New(store.pool)),
 		UserSubscriptionRepository:     NewUserSubscriptionRepository(generated.New(store.pool)),
 		CategoryRepository:             NewCategoryRepository(generated.New(store.pool)),
 		ProductRepository:              NewProductRepository(store),
 		OrderRepository:                NewOrderRepository(store),
-		PaymentRepository:              NewPaymentRepository(generated.New(store.pool)),
+		PaymentRepository:              NewPaymentRepository(store),
 		PaystackRepository:             NewPaystackRepository(generated.New(store.pool)),
+		TaxRepository:                  NewTaxRepository(generated.New(store.pool)),
+		InvoiceRepository:              NewInvoiceRepository(store),
+		TagRepository:                  NewTagRepository(generated.New(store.pool)),
+		PriceRuleRepository:            NewPriceRuleRepository(generated.New(store.pool)),
+		ImageRepository:                NewImageRepository(store),
+		StockBatchRepository:           NewStockBatchRepository(store),
+		StockMovementRepository:        NewStockMovementRepository(store),
+		LowStockRepository:             NewLowStockRepository(store),
+		SupplierRepository:             NewSupplierRepository(generated.New(store.pool)),
+		PurchaseOrderRepository:        NewPurchaseOrderRepository(store),
+		RecipeRepository:               NewRecipeRepository(store),
+		AnalyticsRepository:            NewAnalyticsRepository(generated.New(store.pool)),
+		ProductImportRepository:        NewProductImportRepository(store),
 	}
 }
 
@@ -51,7 +77,17 @@ func NewStore(config pkg.Config) *Store {
 	}
 }
 
+// OpenDB connects to the database and applies the migrations not applied yet.
 func (s *Store) OpenDB(ctx context.Context) error {
+	if err := s.ConnectDB(ctx); err != nil {
+		return err
+	}
+
+	return s.runMigration()
+}
+
+// ConnectDB connects to the database as it is, without migrating it.
+func (s *Store) ConnectDB(ctx context.Context) error {
 	if s.config.DATABASE_URL == "" {
 		return pkg.Errorf(pkg.INVALID_ERROR, "database url cannot be empty")
 	}
@@ -67,7 +103,7 @@ func (s *Store) OpenDB(ctx context.Context) error {
 
 	s.pool = pool
 
-	return s.runMigration()
+	return nil
 }
 
 func (s *Store) CloseDB() {
@@ -76,14 +112,17 @@ func (s *Store) CloseDB() {
 }
 
 func (s *Store) runMigration() error {
-	if s.config.MIGRATION_PATH == "" {
-		return pkg.Errorf(pkg.INVALID_ERROR, "migration path cannot be empty")
-	}
+	return s.MigrateUp()
+}
 
-	m, err := migrate.New(s.config.MIGRATION_PATH, s.config.DATABASE_URL)
+// MigrateUp applies the migrations not applied yet. It does not need the
+// database to be opened.
+func (s *Store) MigrateUp() error {
+	m, err := s.newMigrate()
 	if err != nil {
-		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to load migrations: %s", err.Error())
+		return err
 	}
+	defer m.Close()
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
 		return pkg.Errorf(pkg.INTERNAL_ERROR, "error running migrations: %s", err.Error())
@@ -92,6 +131,55 @@ func (s *Store) runMigration() error {
 	return nil
 }
 
+// MigrateDown rolls back the last steps migrations applied.
+func (s *Store) MigrateDown(steps int) error {
+	if steps < 1 {
+		return pkg.Errorf(pkg.INVALID_ERROR, "steps must be at least 1")
+	}
+
+	m, err := s.newMigrate()
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Steps(-steps); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error rolling back migrations: %s", err.Error())
+	}
+
+	return nil
+}
+
+// MigrationVersion is the last migration applied, 0 when there is none, and
+// whether it failed halfway and left the schema dirty.
+func (s *Store) MigrationVersion() (uint, bool, error) {
+	m, err := s.newMigrate()
+	if err != nil {
+		return 0, false, err
+	}
+	defer m.Close()
+
+	version, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return 0, false, pkg.Errorf(pkg.INTERNAL_ERROR, "error reading the migration version: %s", err.Error())
+	}
+
+	return version, dirty, nil
+}
+
+func (s *Store) newMigrate() (*migrate.Migrate, error) {
+	if s.config.MIGRATION_PATH == "" {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "migration path cannot be empty")
+	}
+
+	m, err := migrate.New(s.config.MIGRATION_PATH, s.config.DATABASE_URL)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to load migrations: %s", err.Error())
+	}
+
+	return m, nil
+}
+
 func (s *Store) ExecTx(ctx context.Context, fn func(q *generated.Queries) error) error {
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
@@ -7,25 +7,69 @@ package generated
 
 import (
 	"context"
-	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const categoryExists = `-- name: CategoryExists :one
+SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL) AS exists
+`
+
+func (q *Queries) CategoryExists(ctx context.Context, id int64) (bool, error) {
+	row := q.db.QueryRow(ctx, categoryExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const countCategoryChildren = `-- name: CountCategoryChildren :one
+SELECT COUNT(*) AS total_children
+FROM categories
+WHERE parent_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountCategoryChildren(ctx context.Context, parentID pgtype.Int8) (int64, error) {
+	row := q.db.QueryRow(ctx, countCategoryChildren, parentID)
+	var total_children int64
+	err := row.Scan(&total_children)
+	return total_children, err
+}
+
+const countCategoryProducts = `-- name: CountCategoryProducts :one
+SELECT COUNT(*) AS total_products
+FROM products
+WHERE category_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountCategoryProducts(ctx context.Context, categoryID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countCategoryProducts, categoryID)
+	var total_products int64
+	err := row.Scan(&total_products)
+	return total_products, err
+}
+
 const createCategory = `-- name: CreateCategory :one
-INSERT INTO categories (name, description, image_url)
-VALUES ($1, $2, $3)
-RETURNING id, name, description, image_url, product_count, deleted_at, created_at
+INSERT INTO categories (name, description, image_url, tax_class_id, parent_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, description, image_url, product_count, deleted_at, created_at, tax_class_id, parent_id
 `
 
 type CreateCategoryParams struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	ImageUrl    []string `json:"image_url"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	ImageUrl    []string    `json:"image_url"`
+	TaxClassID  pgtype.Int8 `json:"tax_class_id"`
+	ParentID    pgtype.Int8 `json:"parent_id"`
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
-	row := q.db.QueryRow(ctx, createCategory, arg.Name, arg.Description, arg.ImageUrl)
+	row := q.db.QueryRow(ctx, createCategory,
+		arg.Name,
+		arg.Description,
+		arg.ImageUrl,
+		arg.TaxClassID,
+		arg.ParentID,
+	)
 	var i Category
 	err := row.Scan(
 		&i.ID,
@@ -35,6 +79,8 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		&i.ProductCount,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.TaxClassID,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -51,37 +97,20 @@ func (q *Queries) DeleteCategory(ctx context.Context, id int64) error {
 }
 
 const getCategoriesWithProductCount = `-- name: GetCategoriesWithProductCount :many
-SELECT 
-    c.id, c.name, c.description, c.image_url, c.product_count, c.deleted_at, c.created_at, 
-    COUNT(p.id) AS products_count
-FROM categories c
-LEFT JOIN products p 
-    ON p.category_id = c.id 
-   AND p.deleted_at IS NULL  
-WHERE c.deleted_at IS NULL
-GROUP BY c.id
+SELECT id, name, description, image_url, product_count, deleted_at, created_at, tax_class_id, parent_id FROM categories
+WHERE deleted_at IS NULL
+ORDER BY name
 `
 
-type GetCategoriesWithProductCountRow struct {
-	ID            int64              `json:"id"`
-	Name          string             `json:"name"`
-	Description   string             `json:"description"`
-	ImageUrl      []string           `json:"image_url"`
-	ProductCount  int64              `json:"product_count"`
-	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
-	CreatedAt     time.Time          `json:"created_at"`
-	ProductsCount int64              `json:"products_count"`
-}
-
-func (q *Queries) GetCategoriesWithProductCount(ctx context.Context) ([]GetCategoriesWithProductCountRow, error) {
+func (q *Queries) GetCategoriesWithProductCount(ctx context.Context) ([]Category, error) {
 	rows, err := q.db.Query(ctx, getCategoriesWithProductCount)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetCategoriesWithProductCountRow{}
+	items := []Category{}
 	for rows.Next() {
-		var i GetCategoriesWithProductCountRow
+		var i Category
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -90,7 +119,8 @@ func (q *Queries) GetCategoriesWithProductCount(ctx context.Context) ([]GetCateg
 			&i.ProductCount,
 			&i.DeletedAt,
 			&i.CreatedAt,
-			&i.ProductsCount,
+			&i.TaxClassID,
+			&i.ParentID,
 		); err != nil {
 			return nil, err
 		}
@@ -102,8 +132,48 @@ func (q *Queries) GetCategoriesWithProductCount(ctx context.Context) ([]GetCateg
 	return items, nil
 }
 
+const getCategoryAncestors = `-- name: GetCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT c.id, c.name, c.parent_id, 0 AS depth
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.name, c.parent_id, a.depth + 1
+    FROM categories c
+    JOIN ancestors a ON c.id = a.parent_id
+    WHERE a.depth < 32
+)
+SELECT id, name FROM ancestors
+ORDER BY depth DESC
+`
+
+type GetCategoryAncestorsRow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) GetCategoryAncestors(ctx context.Context, id int64) ([]GetCategoryAncestorsRow, error) {
+	rows, err := q.db.Query(ctx, getCategoryAncestors, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCategoryAncestorsRow{}
+	for rows.Next() {
+		var i GetCategoryAncestorsRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCategoryByID = `-- name: GetCategoryByID :one
-SELECT id, name, description, image_url, product_count, deleted_at, created_at FROM categories WHERE id = $1
+SELECT id, name, description, image_url, product_count, deleted_at, created_at, tax_class_id, parent_id FROM categories WHERE id = $1
 `
 
 func (q *Queries) GetCategoryByID(ctx context.Context, id int64) (Category, error) {
@@ -117,12 +187,30 @@ func (q *Queries) GetCategoryByID(ctx context.Context, id int64) (Category, erro
 		&i.ProductCount,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.TaxClassID,
+		&i.ParentID,
 	)
 	return i, err
 }
 
+const isCategoryInSubtree = `-- name: IsCategoryInSubtree :one
+SELECT $1::bigint = ANY(category_subtree_ids(ARRAY[$2::bigint])) AS in_subtree
+`
+
+type IsCategoryInSubtreeParams struct {
+	CategoryID int64 `json:"category_id"`
+	RootID     int64 `json:"root_id"`
+}
+
+func (q *Queries) IsCategoryInSubtree(ctx context.Context, arg IsCategoryInSubtreeParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isCategoryInSubtree, arg.CategoryID, arg.RootID)
+	var in_subtree bool
+	err := row.Scan(&in_subtree)
+	return in_subtree, err
+}
+
 const listCategories = `-- name: ListCategories :many
-SELECT id, name, description, image_url, product_count, deleted_at, created_at FROM categories
+SELECT id, name, description, image_url, product_count, deleted_at, created_at, tax_class_id, parent_id FROM categories
 WHERE 
     deleted_at IS NULL
     AND (
@@ -157,6 +245,8 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 			&i.ProductCount,
 			&i.DeletedAt,
 			&i.CreatedAt,
+			&i.TaxClassID,
+			&i.ParentID,
 		); err != nil {
 			return nil, err
 		}
@@ -187,27 +277,86 @@ func (q *Queries) ListCategoriesCount(ctx context.Context, search interface{}) (
 	return total_categories, err
 }
 
+const listCategoryIDsByName = `-- name: ListCategoryIDsByName :many
+SELECT id FROM categories
+WHERE deleted_at IS NULL AND LOWER(name) = LOWER($1)
+ORDER BY id
+`
+
+// the live categories named name, ignoring case.
+func (q *Queries) ListCategoryIDsByName(ctx context.Context, name string) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listCategoryIDsByName, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recountCategoryProducts = `-- name: RecountCategoryProducts :execrows
+UPDATE categories c
+SET product_count = counted.product_count
+FROM (
+    SELECT c2.id, COUNT(p.id) AS product_count
+    FROM categories c2
+    LEFT JOIN products p ON p.category_id = c2.id AND p.deleted_at IS NULL
+    GROUP BY c2.id
+) counted
+WHERE c.id = counted.id AND c.product_count <> counted.product_count
+`
+
+// repairs the product counts that drifted from the products table
+func (q *Queries) RecountCategoryProducts(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, recountCategoryProducts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories
 SET name = coalesce($1, name),
     description = coalesce($2, description),
-    image_url = coalesce($3, image_url)
-WHERE id = $4
-RETURNING id, name, description, image_url, product_count, deleted_at, created_at
+    image_url = coalesce($3, image_url),
+    tax_class_id = coalesce($4, tax_class_id),
+    parent_id = CASE
+        WHEN $5::bigint IS NULL THEN parent_id
+        WHEN $5::bigint = 0 THEN NULL
+        ELSE $5::bigint
+    END
+WHERE id = $6
+RETURNING id, name, description, image_url, product_count, deleted_at, created_at, tax_class_id, parent_id
 `
 
 type UpdateCategoryParams struct {
 	Name        pgtype.Text `json:"name"`
 	Description pgtype.Text `json:"description"`
 	ImageUrl    []string    `json:"image_url"`
+	TaxClassID  pgtype.Int8 `json:"tax_class_id"`
+	ParentID    pgtype.Int8 `json:"parent_id"`
 	ID          int64       `json:"id"`
 }
 
+// a parent_id of 0 moves the category to the top level
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
 	row := q.db.QueryRow(ctx, updateCategory,
 		arg.Name,
 		arg.Description,
 		arg.ImageUrl,
+		arg.TaxClassID,
+		arg.ParentID,
 		arg.ID,
 	)
 	var i Category
@@ -219,6 +368,8 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		&i.ProductCount,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.TaxClassID,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -14,23 +14,39 @@ import (
 
 const listAddOns = `-- name: ListAddOns :many
 SELECT 
-    p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity, p.deleted_at, p.created_at, p.has_stems, p.is_message_card, p.is_flowers, p.is_add_on,
+    p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity, p.deleted_at, p.created_at, p.has_variants, p.is_message_card, p.is_flowers, p.is_add_on, p.currency, p.tax_class_id, p.reorder_threshold, p.sku, p.popularity,
     c.id AS category_id,
     c.name AS category_name, 
     c.description AS category_description,
-    COALESCE(
-        json_agg(
-            jsonb_build_object(
-                'id', ps.id,
-                'product_id', ps.product_id,
-                'stem_count', ps.stem_count,
-                'price', ps.price
-            )
-        ) FILTER (WHERE ps.id IS NOT NULL), '[]'
-    ) AS stems
+    product_price(p, NULL, p.price, now())::decimal AS effective_price,
+    product_availability(p, NULL, p.stock_quantity)::bigint AS available_quantity,
+    COALESCE((
+        SELECT json_agg(json_build_object(
+            'id', pv.id,
+            'product_id', pv.product_id,
+            'sku', pv.sku,
+            'price', product_price(p, pv.id, pv.price, now()),
+            'base_price', pv.price,
+            'stock_quantity', pv.stock_quantity,
+            'available_quantity', product_availability(p, pv.id, pv.stock_quantity),
+            'reorder_threshold', pv.reorder_threshold,
+            'image_url', pv.image_url,
+            'options', COALESCE((
+                SELECT json_agg(json_build_object(
+                    'name', pot.name,
+                    'value', pov.value
+                ) ORDER BY pot.position, pot.id)
+                FROM product_variant_options pvo
+                JOIN product_option_values pov ON pov.id = pvo.option_value_id
+                JOIN product_option_types pot ON pot.id = pov.option_type_id
+                WHERE pvo.variant_id = pv.id
+            ), '[]')
+        ) ORDER BY pv.id)
+        FROM product_variants pv
+        WHERE pv.product_id = p.id AND pv.deleted_at IS NULL
+    ), '[]') AS variants
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
-LEFT JOIN product_stems ps ON ps.product_id = p.id
 WHERE p.deleted_at IS NULL AND p.is_add_on = TRUE
 GROUP BY p.id, c.id, c.name, c.description
 ORDER BY p.created_at DESC
@@ -46,14 +62,21 @@ type ListAddOnsRow struct {
 	StockQuantity       int64              `json:"stock_quantity"`
 	DeletedAt           pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt           time.Time          `json:"created_at"`
-	HasStems            bool               `json:"has_stems"`
+	HasVariants         bool               `json:"has_variants"`
 	IsMessageCard       bool               `json:"is_message_card"`
 	IsFlowers           bool               `json:"is_flowers"`
 	IsAddOn             bool               `json:"is_add_on"`
+	Currency            string             `json:"currency"`
+	TaxClassID          pgtype.Int8        `json:"tax_class_id"`
+	ReorderThreshold    pgtype.Int8        `json:"reorder_threshold"`
+	Sku                 pgtype.Text        `json:"sku"`
+	Popularity          int64              `json:"popularity"`
 	CategoryID_2        pgtype.Int8        `json:"category_id_2"`
 	CategoryName        pgtype.Text        `json:"category_name"`
 	CategoryDescription pgtype.Text        `json:"category_description"`
-	Stems               interface{}        `json:"stems"`
+	EffectivePrice      pgtype.Numeric     `json:"effective_price"`
+	AvailableQuantity   int64              `json:"available_quantity"`
+	Variants            interface{}        `json:"variants"`
 }
 
 func (q *Queries) ListAddOns(ctx context.Context) ([]ListAddOnsRow, error) {
@@ -75,14 +98,21 @@ func (q *Queries) ListAddOns(ctx context.Context) ([]ListAddOnsRow, error) {
 			&i.StockQuantity,
 			&i.DeletedAt,
 			&i.CreatedAt,
-			&i.HasStems,
+			&i.HasVariants,
 			&i.IsMessageCard,
 			&i.IsFlowers,
 			&i.IsAddOn,
+			&i.Currency,
+			&i.TaxClassID,
+			&i.ReorderThreshold,
+			&i.Sku,
+			&i.Popularity,
 			&i.CategoryID_2,
 			&i.CategoryName,
 			&i.CategoryDescription,
-			&i.Stems,
+			&i.EffectivePrice,
+			&i.AvailableQuantity,
+			&i.Variants,
 		); err != nil {
 			return nil, err
 		}
@@ -96,23 +126,39 @@ func (q *Queries) ListAddOns(ctx context.Context) ([]ListAddOnsRow, error) {
 
 const listMessageCards = `-- name: ListMessageCards :many
 SELECT 
-    p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity, p.deleted_at, p.created_at, p.has_stems, p.is_message_card, p.is_flowers, p.is_add_on,
+    p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity, p.deleted_at, p.created_at, p.has_variants, p.is_message_card, p.is_flowers, p.is_add_on, p.currency, p.tax_class_id, p.reorder_threshold, p.sku, p.popularity,
     c.id AS category_id,
     c.name AS category_name, 
     c.description AS category_description,
-    COALESCE(
-        json_agg(
-            jsonb_build_object(
-                'id', ps.id,
-                'product_id', ps.product_id,
-                'stem_count', ps.stem_count,
-                'price', ps.price
-            )
-        ) FILTER (WHERE ps.id IS NOT NULL), '[]'
-    ) AS stems
+    product_price(p, NULL, p.price, now())::decimal AS effective_price,
+    product_availability(p, NULL, p.stock_quantity)::bigint AS available_quantity,
+    COALESCE((
+        SELECT json_agg(json_build_object(
+            'id', pv.id,
+            'product_id', pv.product_id,
+            'sku', pv.sku,
+            'price', product_price(p, pv.id, pv.price, now()),
+            'base_price', pv.price,
+            'stock_quantity', pv.stock_quantity,
+            'available_quantity', product_availability(p, pv.id, pv.stock_quantity),
+            'reorder_threshold', pv.reorder_threshold,
+            'image_url', pv.image_url,
+            'options', COALESCE((
+                SELECT json_agg(json_build_object(
+                    'name', pot.name,
+                    'value', pov.value
+                ) ORDER BY pot.position, pot.id)
+                FROM product_variant_options pvo
+                JOIN product_option_values pov ON pov.id = pvo.option_value_id
+                JOIN product_option_types pot ON pot.id = pov.option_type_id
+                WHERE pvo.variant_id = pv.id
+            ), '[]')
+        ) ORDER BY pv.id)
+        FROM product_variants pv
+        WHERE pv.product_id = p.id AND pv.deleted_at IS NULL
+    ), '[]') AS variants
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
-LEFT JOIN product_stems ps ON ps.product_id = p.id
 WHERE p.deleted_at IS NULL AND p.is_message_card = TRUE
 GROUP BY p.id, c.id, c.name, c.description
 ORDER BY p.created_at DESC
@@ -128,14 +174,21 @@ type ListMessageCardsRow struct {
 	StockQuantity       int64              `json:"stock_quantity"`
 	DeletedAt           pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt           time.Time          `json:"created_at"`
-	HasStems            bool               `json:"has_stems"`
+	HasVariants         bool               `json:"has_variants"`
 	IsMessageCard       bool               `json:"is_message_card"`
 	IsFlowers           bool               `json:"is_flowers"`
 	IsAddOn             bool               `json:"is_add_on"`
+	Currency            string             `json:"currency"`
+	TaxClassID          pgtype.Int8        `json:"tax_class_id"`
+	ReorderThreshold    pgtype.Int8        `json:"reorder_threshold"`
+	Sku                 pgtype.Text        `json:"sku"`
+	Popularity          int64              `json:"popularity"`
 	CategoryID_2        pgtype.Int8        `json:"category_id_2"`
 	CategoryName        pgtype.Text        `json:"category_name"`
 	CategoryDescription pgtype.Text        `json:"category_description"`
-	Stems               interface{}        `json:"stems"`
+	EffectivePrice      pgtype.Numeric     `json:"effective_price"`
+	AvailableQuantity   int64              `json:"available_quantity"`
+	Variants            interface{}        `json:"variants"`
 }
 
 func (q *Queries) ListMessageCards(ctx context.Context) ([]ListMessageCardsRow, error) {
@@ -157,14 +210,21 @@ func (q *Queries) ListMessageCards(ctx context.Context) ([]ListMessageCardsRow,
 			&i.StockQuantity,
 			&i.DeletedAt,
 			&i.CreatedAt,
-			&i.HasStems,
+			&i.HasVariants,
 			&i.IsMessageCard,
 			&i.IsFlowers,
 			&i.IsAddOn,
+			&i.Currency,
+			&i.TaxClassID,
+			&i.ReorderThreshold,
+			&i.Sku,
+			&i.Popularity,
 			&i.CategoryID_2,
 			&i.CategoryName,
 			&i.CategoryDescription,
-			&i.Stems,
+			&i.EffectivePrice,
+			&i.AvailableQuantity,
+			&i.Variants,
 		); err != nil {
 			return nil, err
 		}
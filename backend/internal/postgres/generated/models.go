@@ -18,32 +18,98 @@ type Category struct {
 	ProductCount int64              `json:"product_count"`
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt    time.Time          `json:"created_at"`
+	TaxClassID   pgtype.Int8        `json:"tax_class_id"`
+	ParentID     pgtype.Int8        `json:"parent_id"`
+}
+
+type Image struct {
+	ID           int64     `json:"id"`
+	OwnerType    string    `json:"owner_type"`
+	OwnerID      int64     `json:"owner_id"`
+	Key          string    `json:"key"`
+	Url          string    `json:"url"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+	ThumbnailUrl string    `json:"thumbnail_url"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int32     `json:"width"`
+	Height       int32     `json:"height"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Invoice struct {
+	ID            int64       `json:"id"`
+	InvoiceNumber int64       `json:"invoice_number"`
+	OrderID       pgtype.Int8 `json:"order_id"`
+	PaymentID     pgtype.Int8 `json:"payment_id"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+type InvoiceCounter struct {
+	ID   bool  `json:"id"`
+	Next int64 `json:"next"`
+}
+
+type LowStockAlert struct {
+	ID         int64       `json:"id"`
+	ProductID  int64       `json:"product_id"`
+	VariantID  pgtype.Int8 `json:"variant_id"`
+	NotifiedAt time.Time   `json:"notified_at"`
+}
+
+type LowStockItem struct {
+	ProductID        int64       `json:"product_id"`
+	ProductName      string      `json:"product_name"`
+	VariantID        pgtype.Int8 `json:"variant_id"`
+	VariantSku       pgtype.Text `json:"variant_sku"`
+	StockQuantity    int64       `json:"stock_quantity"`
+	ReorderThreshold pgtype.Int8 `json:"reorder_threshold"`
 }
 
 type Order struct {
-	ID              int64              `json:"id"`
-	UserName        string             `json:"user_name"`
-	UserPhoneNumber string             `json:"user_phone_number"`
-	TotalAmount     pgtype.Numeric     `json:"total_amount"`
-	PaymentStatus   bool               `json:"payment_status"`
-	Status          string             `json:"status"`
-	ShippingAddress pgtype.Text        `json:"shipping_address"`
-	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
-	CreatedAt       time.Time          `json:"created_at"`
-	DeliveryDate    time.Time          `json:"delivery_date"`
-	TimeSlot        string             `json:"time_slot"`
-	ByAdmin         bool               `json:"by_admin"`
+	ID                   int64              `json:"id"`
+	UserName             string             `json:"user_name"`
+	UserPhoneNumber      string             `json:"user_phone_number"`
+	TotalAmount          pgtype.Numeric     `json:"total_amount"`
+	PaymentStatus        bool               `json:"payment_status"`
+	Status               string             `json:"status"`
+	DeletedAt            pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt            time.Time          `json:"created_at"`
+	DeliveryDate         time.Time          `json:"delivery_date"`
+	TimeSlot             string             `json:"time_slot"`
+	ByAdmin              bool               `json:"by_admin"`
+	Currency             string             `json:"currency"`
+	NetAmount            pgtype.Numeric     `json:"net_amount"`
+	TaxAmount            pgtype.Numeric     `json:"tax_amount"`
+	PaymentReference     pgtype.Text        `json:"payment_reference"`
+	BuyerEmail           pgtype.Text        `json:"buyer_email"`
+	RecipientName        string             `json:"recipient_name"`
+	RecipientPhoneNumber string             `json:"recipient_phone_number"`
+	AddressArea          string             `json:"address_area"`
+	AddressStreet        pgtype.Text        `json:"address_street"`
+	AddressBuilding      pgtype.Text        `json:"address_building"`
+	AddressLandmark      pgtype.Text        `json:"address_landmark"`
+	AddressLatitude      pgtype.Float8      `json:"address_latitude"`
+	AddressLongitude     pgtype.Float8      `json:"address_longitude"`
 }
 
 type OrderItem struct {
-	ID            int64          `json:"id"`
-	OrderID       int64          `json:"order_id"`
-	ProductID     int64          `json:"product_id"`
-	Quantity      int32          `json:"quantity"`
-	Amount        pgtype.Numeric `json:"amount"`
-	StemID        pgtype.Int8    `json:"stem_id"`
-	PaymentMethod string         `json:"payment_method"`
-	Frequency     pgtype.Text    `json:"frequency"`
+	ID                int64          `json:"id"`
+	OrderID           int64          `json:"order_id"`
+	ProductID         int64          `json:"product_id"`
+	Quantity          int32          `json:"quantity"`
+	Amount            pgtype.Numeric `json:"amount"`
+	VariantID         pgtype.Int8    `json:"variant_id"`
+	PaymentMethod     string         `json:"payment_method"`
+	Frequency         pgtype.Text    `json:"frequency"`
+	NetAmount         pgtype.Numeric `json:"net_amount"`
+	TaxAmount         pgtype.Numeric `json:"tax_amount"`
+	TaxRateBps        int32          `json:"tax_rate_bps"`
+	CardMessage       pgtype.Text    `json:"card_message"`
+	CardSenderName    pgtype.Text    `json:"card_sender_name"`
+	CardRecipientName pgtype.Text    `json:"card_recipient_name"`
+	CardAnonymous     bool           `json:"card_anonymous"`
+	ParentItemID      pgtype.Int8    `json:"parent_item_id"`
 }
 
 type Payment struct {
@@ -55,6 +121,7 @@ type Payment struct {
 	Amount             pgtype.Numeric `json:"amount"`
 	PaidAt             time.Time      `json:"paid_at"`
 	CreatedAt          time.Time      `json:"created_at"`
+	Currency           string         `json:"currency"`
 }
 
 type PaystackEvent struct {
@@ -72,29 +139,152 @@ type PaystackPayment struct {
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Currency  string    `json:"currency"`
+}
+
+type PriceChange struct {
+	ID        int64          `json:"id"`
+	ProductID int64          `json:"product_id"`
+	VariantID pgtype.Int8    `json:"variant_id"`
+	OldPrice  pgtype.Numeric `json:"old_price"`
+	NewPrice  pgtype.Numeric `json:"new_price"`
+	ChangedAt time.Time      `json:"changed_at"`
+}
+
+type PriceRule struct {
+	ID         int64              `json:"id"`
+	Name       string             `json:"name"`
+	Kind       string             `json:"kind"`
+	Price      pgtype.Numeric     `json:"price"`
+	Currency   pgtype.Text        `json:"currency"`
+	UpliftBps  pgtype.Int4        `json:"uplift_bps"`
+	ProductID  pgtype.Int8        `json:"product_id"`
+	VariantID  pgtype.Int8        `json:"variant_id"`
+	CategoryID pgtype.Int8        `json:"category_id"`
+	TagID      pgtype.Int8        `json:"tag_id"`
+	StartsAt   time.Time          `json:"starts_at"`
+	EndsAt     pgtype.Timestamptz `json:"ends_at"`
+	Priority   int32              `json:"priority"`
+	DeletedAt  pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt  time.Time          `json:"created_at"`
 }
 
 type Product struct {
-	ID            int64              `json:"id"`
-	Name          string             `json:"name"`
-	Description   string             `json:"description"`
-	Price         pgtype.Numeric     `json:"price"`
-	CategoryID    int64              `json:"category_id"`
-	ImageUrl      []string           `json:"image_url"`
-	StockQuantity int64              `json:"stock_quantity"`
-	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
-	CreatedAt     time.Time          `json:"created_at"`
-	HasStems      bool               `json:"has_stems"`
-	IsMessageCard bool               `json:"is_message_card"`
-	IsFlowers     bool               `json:"is_flowers"`
-	IsAddOn       bool               `json:"is_add_on"`
+	ID               int64              `json:"id"`
+	Name             string             `json:"name"`
+	Description      string             `json:"description"`
+	Price            pgtype.Numeric     `json:"price"`
+	CategoryID       int64              `json:"category_id"`
+	ImageUrl         []string           `json:"image_url"`
+	StockQuantity    int64              `json:"stock_quantity"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+	HasVariants      bool               `json:"has_variants"`
+	IsMessageCard    bool               `json:"is_message_card"`
+	IsFlowers        bool               `json:"is_flowers"`
+	IsAddOn          bool               `json:"is_add_on"`
+	Currency         string             `json:"currency"`
+	TaxClassID       pgtype.Int8        `json:"tax_class_id"`
+	ReorderThreshold pgtype.Int8        `json:"reorder_threshold"`
+	Sku              pgtype.Text        `json:"sku"`
+	Popularity       int64              `json:"popularity"`
 }
 
-type ProductStem struct {
-	ID        int64          `json:"id"`
-	ProductID int64          `json:"product_id"`
-	StemCount int64          `json:"stem_count"`
-	Price     pgtype.Numeric `json:"price"`
+type ProductOptionType struct {
+	ID        int64  `json:"id"`
+	ProductID int64  `json:"product_id"`
+	Name      string `json:"name"`
+	Position  int32  `json:"position"`
+}
+
+type ProductOptionValue struct {
+	ID           int64  `json:"id"`
+	OptionTypeID int64  `json:"option_type_id"`
+	Value        string `json:"value"`
+	Position     int32  `json:"position"`
+}
+
+type ProductTag struct {
+	ProductID int64 `json:"product_id"`
+	TagID     int64 `json:"tag_id"`
+}
+
+type ProductVariant struct {
+	ID               int64              `json:"id"`
+	ProductID        int64              `json:"product_id"`
+	Sku              string             `json:"sku"`
+	Price            pgtype.Numeric     `json:"price"`
+	StockQuantity    pgtype.Int8        `json:"stock_quantity"`
+	ImageUrl         []string           `json:"image_url"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+	ReorderThreshold pgtype.Int8        `json:"reorder_threshold"`
+}
+
+type ProductVariantOption struct {
+	VariantID     int64 `json:"variant_id"`
+	OptionValueID int64 `json:"option_value_id"`
+}
+
+type PurchaseOrder struct {
+	ID          int64              `json:"id"`
+	SupplierID  int64              `json:"supplier_id"`
+	Status      string             `json:"status"`
+	Currency    string             `json:"currency"`
+	ExpectedAt  pgtype.Timestamptz `json:"expected_at"`
+	Notes       pgtype.Text        `json:"notes"`
+	CreatedBy   pgtype.Int8        `json:"created_by"`
+	OrderedAt   pgtype.Timestamptz `json:"ordered_at"`
+	ReceivedAt  pgtype.Timestamptz `json:"received_at"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type PurchaseOrderItem struct {
+	ID               int64          `json:"id"`
+	PurchaseOrderID  int64          `json:"purchase_order_id"`
+	ProductID        int64          `json:"product_id"`
+	VariantID        pgtype.Int8    `json:"variant_id"`
+	Quantity         int64          `json:"quantity"`
+	ReceivedQuantity int64          `json:"received_quantity"`
+	UnitCost         pgtype.Numeric `json:"unit_cost"`
+}
+
+type RecipeComponent struct {
+	ID                 int64       `json:"id"`
+	ProductID          int64       `json:"product_id"`
+	VariantID          pgtype.Int8 `json:"variant_id"`
+	ComponentProductID int64       `json:"component_product_id"`
+	ComponentVariantID pgtype.Int8 `json:"component_variant_id"`
+	Quantity           int64       `json:"quantity"`
+	CreatedAt          time.Time   `json:"created_at"`
+}
+
+type StockBatch struct {
+	ID           int64              `json:"id"`
+	ProductID    int64              `json:"product_id"`
+	VariantID    pgtype.Int8        `json:"variant_id"`
+	Supplier     pgtype.Text        `json:"supplier"`
+	ReceivedAt   time.Time          `json:"received_at"`
+	ExpiresAt    time.Time          `json:"expires_at"`
+	Quantity     int64              `json:"quantity"`
+	Remaining    int64              `json:"remaining"`
+	WrittenOff   int64              `json:"written_off"`
+	WrittenOffAt pgtype.Timestamptz `json:"written_off_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+type StockMovement struct {
+	ID           int64       `json:"id"`
+	ProductID    int64       `json:"product_id"`
+	VariantID    pgtype.Int8 `json:"variant_id"`
+	Kind         string      `json:"kind"`
+	Quantity     int64       `json:"quantity"`
+	Reason       string      `json:"reason"`
+	UserID       pgtype.Int8 `json:"user_id"`
+	OrderID      pgtype.Int8 `json:"order_id"`
+	StockBatchID pgtype.Int8 `json:"stock_batch_id"`
+	CreatedAt    time.Time   `json:"created_at"`
 }
 
 type Subscription struct {
@@ -107,8 +297,9 @@ type Subscription struct {
 	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt     time.Time          `json:"created_at"`
 	ByAdmin       bool               `json:"by_admin"`
-	StemIds       []int32            `json:"stem_ids"`
+	VariantIds    []int32            `json:"variant_ids"`
 	ParentOrderID pgtype.Int8        `json:"parent_order_id"`
+	Currency      string             `json:"currency"`
 }
 
 type SubscriptionDelivery struct {
@@ -120,6 +311,55 @@ type SubscriptionDelivery struct {
 	CreatedAt          time.Time          `json:"created_at"`
 }
 
+type Supplier struct {
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	ContactName pgtype.Text        `json:"contact_name"`
+	Email       pgtype.Text        `json:"email"`
+	PhoneNumber pgtype.Text        `json:"phone_number"`
+	Address     pgtype.Text        `json:"address"`
+	Notes       pgtype.Text        `json:"notes"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type Tag struct {
+	ID          int64              `json:"id"`
+	TagGroupID  int64              `json:"tag_group_id"`
+	Name        string             `json:"name"`
+	Slug        string             `json:"slug"`
+	Description string             `json:"description"`
+	ImageUrl    []string           `json:"image_url"`
+	Position    int32              `json:"position"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type TagGroup struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Slug      string             `json:"slug"`
+	Position  int32              `json:"position"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type TaxClass struct {
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type TaxRate struct {
+	ID            int64     `json:"id"`
+	TaxClassID    int64     `json:"tax_class_id"`
+	RateBps       int32     `json:"rate_bps"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
 type User struct {
 	ID           int64       `json:"id"`
 	Name         string      `json:"name"`
@@ -144,4 +384,5 @@ type UserSubscription struct {
 	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt      time.Time          `json:"created_at"`
 	Frequency      string             `json:"frequency"`
+	CancelledAt    pgtype.Timestamptz `json:"cancelled_at"`
 }
@@ -12,19 +12,27 @@ import (
 )
 
 const createOrderItem = `-- name: CreateOrderItem :one
-INSERT INTO order_items (order_id, product_id, quantity, amount, payment_method, frequency, stem_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO order_items (order_id, product_id, quantity, amount, payment_method, frequency, variant_id, net_amount, tax_amount, tax_rate_bps, card_message, card_sender_name, card_recipient_name, card_anonymous, parent_item_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING id
 `
 
 type CreateOrderItemParams struct {
-	OrderID       int64          `json:"order_id"`
-	ProductID     int64          `json:"product_id"`
-	Quantity      int32          `json:"quantity"`
-	Amount        pgtype.Numeric `json:"amount"`
-	PaymentMethod string         `json:"payment_method"`
-	Frequency     pgtype.Text    `json:"frequency"`
-	StemID        pgtype.Int8    `json:"stem_id"`
+	OrderID           int64          `json:"order_id"`
+	ProductID         int64          `json:"product_id"`
+	Quantity          int32          `json:"quantity"`
+	Amount            pgtype.Numeric `json:"amount"`
+	PaymentMethod     string         `json:"payment_method"`
+	Frequency         pgtype.Text    `json:"frequency"`
+	VariantID         pgtype.Int8    `json:"variant_id"`
+	NetAmount         pgtype.Numeric `json:"net_amount"`
+	TaxAmount         pgtype.Numeric `json:"tax_amount"`
+	TaxRateBps        int32          `json:"tax_rate_bps"`
+	CardMessage       pgtype.Text    `json:"card_message"`
+	CardSenderName    pgtype.Text    `json:"card_sender_name"`
+	CardRecipientName pgtype.Text    `json:"card_recipient_name"`
+	CardAnonymous     bool           `json:"card_anonymous"`
+	ParentItemID      pgtype.Int8    `json:"parent_item_id"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (int64, error) {
@@ -35,7 +43,15 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.Amount,
 		arg.PaymentMethod,
 		arg.Frequency,
-		arg.StemID,
+		arg.VariantID,
+		arg.NetAmount,
+		arg.TaxAmount,
+		arg.TaxRateBps,
+		arg.CardMessage,
+		arg.CardSenderName,
+		arg.CardRecipientName,
+		arg.CardAnonymous,
+		arg.ParentItemID,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -57,15 +73,23 @@ func (q *Queries) GetCountOrderItemsByProductID(ctx context.Context, productID i
 
 const getOrderItemsByProductID = `-- name: GetOrderItemsByProductID :many
 SELECT 
-    oi.id, oi.order_id, oi.product_id, oi.quantity, oi.amount, oi.stem_id, oi.payment_method, oi.frequency,
+    oi.id, oi.order_id, oi.product_id, oi.quantity, oi.amount, oi.variant_id, oi.payment_method, oi.frequency, oi.net_amount, oi.tax_amount, oi.tax_rate_bps, oi.card_message, oi.card_sender_name, oi.card_recipient_name, oi.card_anonymous, oi.parent_item_id,
     COALESCE(p1.order_json, '{}') AS order_data
 FROM order_items oi
 LEFT JOIN LATERAL (
     SELECT json_build_object(
         'id', o.id,
-        'user_name', o.user_name,
-        'user_phone_number', o.user_phone_number,
+        'buyer', json_build_object(
+            'name', o.user_name,
+            'phone_number', o.user_phone_number,
+            'email', o.buyer_email
+        ),
+        'recipient', json_build_object(
+            'name', o.recipient_name,
+            'phone_number', o.recipient_phone_number
+        ),
         'total_amount', o.total_amount,
+        'currency', o.currency,
         'payment_status', o.payment_status,
         'status', o.status
     ) AS order_json
@@ -83,15 +107,23 @@ type GetOrderItemsByProductIDParams struct {
 }
 
 type GetOrderItemsByProductIDRow struct {
-	ID            int64          `json:"id"`
-	OrderID       int64          `json:"order_id"`
-	ProductID     int64          `json:"product_id"`
-	Quantity      int32          `json:"quantity"`
-	Amount        pgtype.Numeric `json:"amount"`
-	StemID        pgtype.Int8    `json:"stem_id"`
-	PaymentMethod string         `json:"payment_method"`
-	Frequency     pgtype.Text    `json:"frequency"`
-	OrderData     []byte         `json:"order_data"`
+	ID                int64          `json:"id"`
+	OrderID           int64          `json:"order_id"`
+	ProductID         int64          `json:"product_id"`
+	Quantity          int32          `json:"quantity"`
+	Amount            pgtype.Numeric `json:"amount"`
+	VariantID         pgtype.Int8    `json:"variant_id"`
+	PaymentMethod     string         `json:"payment_method"`
+	Frequency         pgtype.Text    `json:"frequency"`
+	NetAmount         pgtype.Numeric `json:"net_amount"`
+	TaxAmount         pgtype.Numeric `json:"tax_amount"`
+	TaxRateBps        int32          `json:"tax_rate_bps"`
+	CardMessage       pgtype.Text    `json:"card_message"`
+	CardSenderName    pgtype.Text    `json:"card_sender_name"`
+	CardRecipientName pgtype.Text    `json:"card_recipient_name"`
+	CardAnonymous     bool           `json:"card_anonymous"`
+	ParentItemID      pgtype.Int8    `json:"parent_item_id"`
+	OrderData         []byte         `json:"order_data"`
 }
 
 func (q *Queries) GetOrderItemsByProductID(ctx context.Context, arg GetOrderItemsByProductIDParams) ([]GetOrderItemsByProductIDRow, error) {
@@ -109,9 +141,17 @@ func (q *Queries) GetOrderItemsByProductID(ctx context.Context, arg GetOrderItem
 			&i.ProductID,
 			&i.Quantity,
 			&i.Amount,
-			&i.StemID,
+			&i.VariantID,
 			&i.PaymentMethod,
 			&i.Frequency,
+			&i.NetAmount,
+			&i.TaxAmount,
+			&i.TaxRateBps,
+			&i.CardMessage,
+			&i.CardSenderName,
+			&i.CardRecipientName,
+			&i.CardAnonymous,
+			&i.ParentItemID,
 			&i.OrderData,
 		); err != nil {
 			return nil, err
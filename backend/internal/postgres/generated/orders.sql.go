@@ -13,21 +13,33 @@ import (
 )
 
 const createOrder = `-- name: CreateOrder :one
-INSERT INTO orders (user_name, user_phone_number, total_amount, payment_status, status, shipping_address, delivery_date, time_slot, by_admin)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO orders (user_name, user_phone_number, total_amount, payment_status, status, delivery_date, time_slot, by_admin, currency, net_amount, tax_amount, payment_reference, buyer_email, recipient_name, recipient_phone_number, address_area, address_street, address_building, address_landmark, address_latitude, address_longitude)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 RETURNING id
 `
 
 type CreateOrderParams struct {
-	UserName        string         `json:"user_name"`
-	UserPhoneNumber string         `json:"user_phone_number"`
-	TotalAmount     pgtype.Numeric `json:"total_amount"`
-	PaymentStatus   bool           `json:"payment_status"`
-	Status          string         `json:"status"`
-	ShippingAddress pgtype.Text    `json:"shipping_address"`
-	DeliveryDate    time.Time      `json:"delivery_date"`
-	TimeSlot        string         `json:"time_slot"`
-	ByAdmin         bool           `json:"by_admin"`
+	UserName             string         `json:"user_name"`
+	UserPhoneNumber      string         `json:"user_phone_number"`
+	TotalAmount          pgtype.Numeric `json:"total_amount"`
+	PaymentStatus        bool           `json:"payment_status"`
+	Status               string         `json:"status"`
+	DeliveryDate         time.Time      `json:"delivery_date"`
+	TimeSlot             string         `json:"time_slot"`
+	ByAdmin              bool           `json:"by_admin"`
+	Currency             string         `json:"currency"`
+	NetAmount            pgtype.Numeric `json:"net_amount"`
+	TaxAmount            pgtype.Numeric `json:"tax_amount"`
+	PaymentReference     pgtype.Text    `json:"payment_reference"`
+	BuyerEmail           pgtype.Text    `json:"buyer_email"`
+	RecipientName        string         `json:"recipient_name"`
+	RecipientPhoneNumber string         `json:"recipient_phone_number"`
+	AddressArea          string         `json:"address_area"`
+	AddressStreet        pgtype.Text    `json:"address_street"`
+	AddressBuilding      pgtype.Text    `json:"address_building"`
+	AddressLandmark      pgtype.Text    `json:"address_landmark"`
+	AddressLatitude      pgtype.Float8  `json:"address_latitude"`
+	AddressLongitude     pgtype.Float8  `json:"address_longitude"`
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (int64, error) {
@@ -37,10 +49,22 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (int64
 		arg.TotalAmount,
 		arg.PaymentStatus,
 		arg.Status,
-		arg.ShippingAddress,
 		arg.DeliveryDate,
 		arg.TimeSlot,
 		arg.ByAdmin,
+		arg.Currency,
+		arg.NetAmount,
+		arg.TaxAmount,
+		arg.PaymentReference,
+		arg.BuyerEmail,
+		arg.RecipientName,
+		arg.RecipientPhoneNumber,
+		arg.AddressArea,
+		arg.AddressStreet,
+		arg.AddressBuilding,
+		arg.AddressLandmark,
+		arg.AddressLatitude,
+		arg.AddressLongitude,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -60,7 +84,7 @@ func (q *Queries) DeleteOrder(ctx context.Context, id int64) error {
 
 const getOrderByFullDataID = `-- name: GetOrderByFullDataID :one
 SELECT 
-  o.id, o.user_name, o.user_phone_number, o.total_amount, o.payment_status, o.status, o.shipping_address, o.deleted_at, o.created_at, o.delivery_date, o.time_slot, o.by_admin,
+  o.id, o.user_name, o.user_phone_number, o.total_amount, o.payment_status, o.status, o.deleted_at, o.created_at, o.delivery_date, o.time_slot, o.by_admin, o.currency, o.net_amount, o.tax_amount, o.payment_reference, o.buyer_email, o.recipient_name, o.recipient_phone_number, o.address_area, o.address_street, o.address_building, o.address_landmark, o.address_latitude, o.address_longitude,
   COALESCE(items.items, '[]') AS order_item_data
 FROM orders o
 LEFT JOIN LATERAL (
@@ -68,8 +92,21 @@ LEFT JOIN LATERAL (
     'id', oi.id,
     'order_id', oi.order_id,
     'product_id', oi.product_id,
+    'variant_id', oi.variant_id,
+    'payment_method', oi.payment_method,
+    'frequency', oi.frequency,
     'quantity', oi.quantity,
     'amount', oi.amount,
+    'net_amount', oi.net_amount,
+    'tax_amount', oi.tax_amount,
+    'tax_rate_bps', oi.tax_rate_bps,
+    'parent_item_id', oi.parent_item_id,
+    'message_card', CASE WHEN oi.card_message IS NULL THEN NULL ELSE json_build_object(
+      'message', oi.card_message,
+      'sender_name', oi.card_sender_name,
+      'recipient_name', oi.card_recipient_name,
+      'anonymous', oi.card_anonymous
+    ) END,
     'current_product_details', json_build_object(
       'id', p.id,
       'name', p.name,
@@ -78,30 +115,64 @@ LEFT JOIN LATERAL (
       'stock_quantity', p.stock_quantity,
       'image_url', p.image_url,
       'category_id', p.category_id,
-      'created_at', p.created_at
+      'has_variants', p.has_variants,
+      'is_message_card', p.is_message_card,
+      'is_flowers', p.is_flowers,
+      'is_add_on', p.is_add_on,
+      'created_at', p.created_at,
+      'variants', CASE WHEN pv.id IS NULL THEN '[]'::json ELSE json_build_array(json_build_object(
+        'id', pv.id,
+        'product_id', pv.product_id,
+        'sku', pv.sku,
+        'price', pv.price,
+        'image_url', pv.image_url,
+        'options', COALESCE((
+          SELECT json_agg(json_build_object(
+            'name', pot.name,
+            'value', pov.value
+          ) ORDER BY pot.position, pot.id)
+          FROM product_variant_options pvo
+          JOIN product_option_values pov ON pov.id = pvo.option_value_id
+          JOIN product_option_types pot ON pot.id = pov.option_type_id
+          WHERE pvo.variant_id = pv.id
+        ), '[]')
+      )) END
     )
-  )) AS items
+  ) ORDER BY oi.id) AS items
   FROM order_items oi
   JOIN products p ON p.id = oi.product_id
+  LEFT JOIN product_variants pv ON pv.id = oi.variant_id
   WHERE oi.order_id = o.id
 ) items ON true
 WHERE o.id = $1
 `
 
 type GetOrderByFullDataIDRow struct {
-	ID              int64              `json:"id"`
-	UserName        string             `json:"user_name"`
-	UserPhoneNumber string             `json:"user_phone_number"`
-	TotalAmount     pgtype.Numeric     `json:"total_amount"`
-	PaymentStatus   bool               `json:"payment_status"`
-	Status          string             `json:"status"`
-	ShippingAddress pgtype.Text        `json:"shipping_address"`
-	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
-	CreatedAt       time.Time          `json:"created_at"`
-	DeliveryDate    time.Time          `json:"delivery_date"`
-	TimeSlot        string             `json:"time_slot"`
-	ByAdmin         bool               `json:"by_admin"`
-	OrderItemData   []byte             `json:"order_item_data"`
+	ID                   int64              `json:"id"`
+	UserName             string             `json:"user_name"`
+	UserPhoneNumber      string             `json:"user_phone_number"`
+	TotalAmount          pgtype.Numeric     `json:"total_amount"`
+	PaymentStatus        bool               `json:"payment_status"`
+	Status               string             `json:"status"`
+	DeletedAt            pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt            time.Time          `json:"created_at"`
+	DeliveryDate         time.Time          `json:"delivery_date"`
+	TimeSlot             string             `json:"time_slot"`
+	ByAdmin              bool               `json:"by_admin"`
+	Currency             string             `json:"currency"`
+	NetAmount            pgtype.Numeric     `json:"net_amount"`
+	TaxAmount            pgtype.Numeric     `json:"tax_amount"`
+	PaymentReference     pgtype.Text        `json:"payment_reference"`
+	BuyerEmail           pgtype.Text        `json:"buyer_email"`
+	RecipientName        string             `json:"recipient_name"`
+	RecipientPhoneNumber string             `json:"recipient_phone_number"`
+	AddressArea          string             `json:"address_area"`
+	AddressStreet        pgtype.Text        `json:"address_street"`
+	AddressBuilding      pgtype.Text        `json:"address_building"`
+	AddressLandmark      pgtype.Text        `json:"address_landmark"`
+	AddressLatitude      pgtype.Float8      `json:"address_latitude"`
+	AddressLongitude     pgtype.Float8      `json:"address_longitude"`
+	OrderItemData        []byte             `json:"order_item_data"`
 }
 
 func (q *Queries) GetOrderByFullDataID(ctx context.Context, id int64) (GetOrderByFullDataIDRow, error) {
@@ -114,19 +185,31 @@ func (q *Queries) GetOrderByFullDataID(ctx context.Context, id int64) (GetOrderB
 		&i.TotalAmount,
 		&i.PaymentStatus,
 		&i.Status,
-		&i.ShippingAddress,
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.DeliveryDate,
 		&i.TimeSlot,
 		&i.ByAdmin,
+		&i.Currency,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.PaymentReference,
+		&i.BuyerEmail,
+		&i.RecipientName,
+		&i.RecipientPhoneNumber,
+		&i.AddressArea,
+		&i.AddressStreet,
+		&i.AddressBuilding,
+		&i.AddressLandmark,
+		&i.AddressLatitude,
+		&i.AddressLongitude,
 		&i.OrderItemData,
 	)
 	return i, err
 }
 
 const getOrderByID = `-- name: GetOrderByID :one
-SELECT id, user_name, user_phone_number, total_amount, payment_status, status, shipping_address, deleted_at, created_at, delivery_date, time_slot, by_admin FROM orders WHERE id = $1
+SELECT id, user_name, user_phone_number, total_amount, payment_status, status, deleted_at, created_at, delivery_date, time_slot, by_admin, currency, net_amount, tax_amount, payment_reference, buyer_email, recipient_name, recipient_phone_number, address_area, address_street, address_building, address_landmark, address_latitude, address_longitude FROM orders WHERE id = $1
 `
 
 func (q *Queries) GetOrderByID(ctx context.Context, id int64) (Order, error) {
@@ -139,18 +222,30 @@ func (q *Queries) GetOrderByID(ctx context.Context, id int64) (Order, error) {
 		&i.TotalAmount,
 		&i.PaymentStatus,
 		&i.Status,
-		&i.ShippingAddress,
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.DeliveryDate,
 		&i.TimeSlot,
 		&i.ByAdmin,
+		&i.Currency,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.PaymentReference,
+		&i.BuyerEmail,
+		&i.RecipientName,
+		&i.RecipientPhoneNumber,
+		&i.AddressArea,
+		&i.AddressStreet,
+		&i.AddressBuilding,
+		&i.AddressLandmark,
+		&i.AddressLatitude,
+		&i.AddressLongitude,
 	)
 	return i, err
 }
 
 const getRecentOrders = `-- name: GetRecentOrders :many
-SELECT id, user_name, user_phone_number, total_amount, payment_status, status, shipping_address, deleted_at, created_at, delivery_date, time_slot, by_admin FROM orders
+SELECT id, user_name, user_phone_number, total_amount, payment_status, status, deleted_at, created_at, delivery_date, time_slot, by_admin, currency, net_amount, tax_amount, payment_reference, buyer_email, recipient_name, recipient_phone_number, address_area, address_street, address_building, address_landmark, address_latitude, address_longitude FROM orders
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT 7
@@ -172,12 +267,24 @@ func (q *Queries) GetRecentOrders(ctx context.Context) ([]Order, error) {
 			&i.TotalAmount,
 			&i.PaymentStatus,
 			&i.Status,
-			&i.ShippingAddress,
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.DeliveryDate,
 			&i.TimeSlot,
 			&i.ByAdmin,
+			&i.Currency,
+			&i.NetAmount,
+			&i.TaxAmount,
+			&i.PaymentReference,
+			&i.BuyerEmail,
+			&i.RecipientName,
+			&i.RecipientPhoneNumber,
+			&i.AddressArea,
+			&i.AddressStreet,
+			&i.AddressBuilding,
+			&i.AddressLandmark,
+			&i.AddressLatitude,
+			&i.AddressLongitude,
 		); err != nil {
 			return nil, err
 		}
@@ -198,7 +305,11 @@ WHERE
         COALESCE($1, '') = '' 
         OR LOWER(user_name) LIKE $1
         OR LOWER(user_phone_number) LIKE $1
-        OR LOWER(shipping_address) LIKE $1
+        OR LOWER(buyer_email) LIKE $1
+        OR LOWER(recipient_name) LIKE $1
+        OR LOWER(recipient_phone_number) LIKE $1
+        OR LOWER(address_area) LIKE $1
+        OR LOWER(address_street) LIKE $1
     )
     AND (
         $2::boolean IS NULL 
@@ -208,66 +319,147 @@ WHERE
         COALESCE($3, '') = '' 
         OR LOWER(status) LIKE $3
     )
+    AND (
+        $4::timestamptz IS NULL
+        OR created_at >= $4
+    )
+    AND (
+        $5::timestamptz IS NULL
+        OR created_at < $5
+    )
 `
 
 type ListCountOrderParams struct {
-	Search        interface{} `json:"search"`
-	PaymentStatus pgtype.Bool `json:"payment_status"`
-	Status        interface{} `json:"status"`
+	Search        interface{}        `json:"search"`
+	PaymentStatus pgtype.Bool        `json:"payment_status"`
+	Status        interface{}        `json:"status"`
+	StartDate     pgtype.Timestamptz `json:"start_date"`
+	EndDate       pgtype.Timestamptz `json:"end_date"`
 }
 
 func (q *Queries) ListCountOrder(ctx context.Context, arg ListCountOrderParams) (int64, error) {
-	row := q.db.QueryRow(ctx, listCountOrder, arg.Search, arg.PaymentStatus, arg.Status)
+	row := q.db.QueryRow(ctx, listCountOrder,
+		arg.Search,
+		arg.PaymentStatus,
+		arg.Status,
+		arg.StartDate,
+		arg.EndDate,
+	)
 	var total_orders int64
 	err := row.Scan(&total_orders)
 	return total_orders, err
 }
 
-const listOrder = `-- name: ListOrder :many
-SELECT id, user_name, user_phone_number, total_amount, payment_status, status, shipping_address, deleted_at, created_at, delivery_date, time_slot, by_admin FROM orders
-WHERE
-    deleted_at IS NULL
-    AND (
-        COALESCE($1, '') = '' 
-        OR LOWER(user_name) LIKE $1
-        OR LOWER(user_phone_number) LIKE $1
-        OR LOWER(shipping_address) LIKE $1
-    )
-    AND (
-        $2::boolean IS NULL 
-        OR payment_status = $2
-    )
-    AND (
-        COALESCE($3, '') = '' 
-        OR LOWER(status) LIKE $3
+const listOrdersByDeliveryDate = `-- name: ListOrdersByDeliveryDate :many
+SELECT 
+  o.id, o.user_name, o.user_phone_number, o.total_amount, o.payment_status, o.status, o.deleted_at, o.created_at, o.delivery_date, o.time_slot, o.by_admin, o.currency, o.net_amount, o.tax_amount, o.payment_reference, o.buyer_email, o.recipient_name, o.recipient_phone_number, o.address_area, o.address_street, o.address_building, o.address_landmark, o.address_latitude, o.address_longitude,
+  COALESCE(items.items, '[]') AS order_item_data
+FROM orders o
+LEFT JOIN LATERAL (
+  SELECT json_agg(json_build_object(
+    'id', oi.id,
+    'order_id', oi.order_id,
+    'product_id', oi.product_id,
+    'variant_id', oi.variant_id,
+    'payment_method', oi.payment_method,
+    'frequency', oi.frequency,
+    'quantity', oi.quantity,
+    'amount', oi.amount,
+    'net_amount', oi.net_amount,
+    'tax_amount', oi.tax_amount,
+    'tax_rate_bps', oi.tax_rate_bps,
+    'parent_item_id', oi.parent_item_id,
+    'message_card', CASE WHEN oi.card_message IS NULL THEN NULL ELSE json_build_object(
+      'message', oi.card_message,
+      'sender_name', oi.card_sender_name,
+      'recipient_name', oi.card_recipient_name,
+      'anonymous', oi.card_anonymous
+    ) END,
+    'current_product_details', json_build_object(
+      'id', p.id,
+      'name', p.name,
+      'description', p.description,
+      'price', p.price,
+      'stock_quantity', p.stock_quantity,
+      'image_url', p.image_url,
+      'category_id', p.category_id,
+      'has_variants', p.has_variants,
+      'is_message_card', p.is_message_card,
+      'is_flowers', p.is_flowers,
+      'is_add_on', p.is_add_on,
+      'created_at', p.created_at,
+      'variants', CASE WHEN pv.id IS NULL THEN '[]'::json ELSE json_build_array(json_build_object(
+        'id', pv.id,
+        'product_id', pv.product_id,
+        'sku', pv.sku,
+        'price', pv.price,
+        'image_url', pv.image_url,
+        'options', COALESCE((
+          SELECT json_agg(json_build_object(
+            'name', pot.name,
+            'value', pov.value
+          ) ORDER BY pot.position, pot.id)
+          FROM product_variant_options pvo
+          JOIN product_option_values pov ON pov.id = pvo.option_value_id
+          JOIN product_option_types pot ON pot.id = pov.option_type_id
+          WHERE pvo.variant_id = pv.id
+        ), '[]')
+      )) END
     )
-ORDER BY created_at DESC
-LIMIT $5 OFFSET $4
+  ) ORDER BY oi.id) AS items
+  FROM order_items oi
+  JOIN products p ON p.id = oi.product_id
+  LEFT JOIN product_variants pv ON pv.id = oi.variant_id
+  WHERE oi.order_id = o.id
+) items ON true
+WHERE o.deleted_at IS NULL
+  AND o.delivery_date >= $1
+  AND o.delivery_date < $2
+ORDER BY o.delivery_date, o.id
 `
 
-type ListOrderParams struct {
-	Search        interface{} `json:"search"`
-	PaymentStatus pgtype.Bool `json:"payment_status"`
-	Status        interface{} `json:"status"`
-	Offset        int32       `json:"offset"`
-	Limit         int32       `json:"limit"`
+type ListOrdersByDeliveryDateParams struct {
+	DayStart time.Time `json:"day_start"`
+	DayEnd   time.Time `json:"day_end"`
 }
 
-func (q *Queries) ListOrder(ctx context.Context, arg ListOrderParams) ([]Order, error) {
-	rows, err := q.db.Query(ctx, listOrder,
-		arg.Search,
-		arg.PaymentStatus,
-		arg.Status,
-		arg.Offset,
-		arg.Limit,
-	)
+type ListOrdersByDeliveryDateRow struct {
+	ID                   int64              `json:"id"`
+	UserName             string             `json:"user_name"`
+	UserPhoneNumber      string             `json:"user_phone_number"`
+	TotalAmount          pgtype.Numeric     `json:"total_amount"`
+	PaymentStatus        bool               `json:"payment_status"`
+	Status               string             `json:"status"`
+	DeletedAt            pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt            time.Time          `json:"created_at"`
+	DeliveryDate         time.Time          `json:"delivery_date"`
+	TimeSlot             string             `json:"time_slot"`
+	ByAdmin              bool               `json:"by_admin"`
+	Currency             string             `json:"currency"`
+	NetAmount            pgtype.Numeric     `json:"net_amount"`
+	TaxAmount            pgtype.Numeric     `json:"tax_amount"`
+	PaymentReference     pgtype.Text        `json:"payment_reference"`
+	BuyerEmail           pgtype.Text        `json:"buyer_email"`
+	RecipientName        string             `json:"recipient_name"`
+	RecipientPhoneNumber string             `json:"recipient_phone_number"`
+	AddressArea          string             `json:"address_area"`
+	AddressStreet        pgtype.Text        `json:"address_street"`
+	AddressBuilding      pgtype.Text        `json:"address_building"`
+	AddressLandmark      pgtype.Text        `json:"address_landmark"`
+	AddressLatitude      pgtype.Float8      `json:"address_latitude"`
+	AddressLongitude     pgtype.Float8      `json:"address_longitude"`
+	OrderItemData        []byte             `json:"order_item_data"`
+}
+
+func (q *Queries) ListOrdersByDeliveryDate(ctx context.Context, arg ListOrdersByDeliveryDateParams) ([]ListOrdersByDeliveryDateRow, error) {
+	rows, err := q.db.Query(ctx, listOrdersByDeliveryDate, arg.DayStart, arg.DayEnd)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Order{}
+	items := []ListOrdersByDeliveryDateRow{}
 	for rows.Next() {
-		var i Order
+		var i ListOrdersByDeliveryDateRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserName,
@@ -275,12 +467,25 @@ func (q *Queries) ListOrder(ctx context.Context, arg ListOrderParams) ([]Order,
 			&i.TotalAmount,
 			&i.PaymentStatus,
 			&i.Status,
-			&i.ShippingAddress,
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.DeliveryDate,
 			&i.TimeSlot,
 			&i.ByAdmin,
+			&i.Currency,
+			&i.NetAmount,
+			&i.TaxAmount,
+			&i.PaymentReference,
+			&i.BuyerEmail,
+			&i.RecipientName,
+			&i.RecipientPhoneNumber,
+			&i.AddressArea,
+			&i.AddressStreet,
+			&i.AddressBuilding,
+			&i.AddressLandmark,
+			&i.AddressLatitude,
+			&i.AddressLongitude,
+			&i.OrderItemData,
 		); err != nil {
 			return nil, err
 		}
@@ -304,14 +509,14 @@ func (q *Queries) OrderExists(ctx context.Context, id int64) (bool, error) {
 }
 
 const totalOrders = `-- name: TotalOrders :one
-SELECT COALESCE(COUNT(*), 0) AS total_orders
+SELECT COUNT(*) AS total_orders
 FROM orders
 WHERE deleted_at IS NULL
 `
 
-func (q *Queries) TotalOrders(ctx context.Context) (interface{}, error) {
+func (q *Queries) TotalOrders(ctx context.Context) (int64, error) {
 	row := q.db.QueryRow(ctx, totalOrders)
-	var total_orders interface{}
+	var total_orders int64
 	err := row.Scan(&total_orders)
 	return total_orders, err
 }
@@ -322,18 +527,34 @@ SET user_name = coalesce($1, user_name),
     user_phone_number = coalesce($2, user_phone_number),
     payment_status = coalesce($3, payment_status),
     status = coalesce($4, status),
-    shipping_address = coalesce($5, shipping_address)
-WHERE id = $6
+    buyer_email = coalesce($5, buyer_email),
+    recipient_name = coalesce($6, recipient_name),
+    recipient_phone_number = coalesce($7, recipient_phone_number),
+    address_area = coalesce($8, address_area),
+    address_street = coalesce($9, address_street),
+    address_building = coalesce($10, address_building),
+    address_landmark = coalesce($11, address_landmark),
+    address_latitude = coalesce($12, address_latitude),
+    address_longitude = coalesce($13, address_longitude)
+WHERE id = $14
 RETURNING id
 `
 
 type UpdateOrderParams struct {
-	UserName        pgtype.Text `json:"user_name"`
-	UserPhoneNumber pgtype.Text `json:"user_phone_number"`
-	PaymentStatus   pgtype.Bool `json:"payment_status"`
-	Status          pgtype.Text `json:"status"`
-	ShippingAddress pgtype.Text `json:"shipping_address"`
-	ID              int64       `json:"id"`
+	UserName             pgtype.Text   `json:"user_name"`
+	UserPhoneNumber      pgtype.Text   `json:"user_phone_number"`
+	PaymentStatus        pgtype.Bool   `json:"payment_status"`
+	Status               pgtype.Text   `json:"status"`
+	BuyerEmail           pgtype.Text   `json:"buyer_email"`
+	RecipientName        pgtype.Text   `json:"recipient_name"`
+	RecipientPhoneNumber pgtype.Text   `json:"recipient_phone_number"`
+	AddressArea          pgtype.Text   `json:"address_area"`
+	AddressStreet        pgtype.Text   `json:"address_street"`
+	AddressBuilding      pgtype.Text   `json:"address_building"`
+	AddressLandmark      pgtype.Text   `json:"address_landmark"`
+	AddressLatitude      pgtype.Float8 `json:"address_latitude"`
+	AddressLongitude     pgtype.Float8 `json:"address_longitude"`
+	ID                   int64         `json:"id"`
 }
 
 func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (int64, error) {
@@ -342,7 +563,15 @@ func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (int64
 		arg.UserPhoneNumber,
 		arg.PaymentStatus,
 		arg.Status,
-		arg.ShippingAddress,
+		arg.BuyerEmail,
+		arg.RecipientName,
+		arg.RecipientPhoneNumber,
+		arg.AddressArea,
+		arg.AddressStreet,
+		arg.AddressBuilding,
+		arg.AddressLandmark,
+		arg.AddressLatitude,
+		arg.AddressLongitude,
 		arg.ID,
 	)
 	var id int64
@@ -13,9 +13,9 @@ import (
 )
 
 const createPayment = `-- name: CreatePayment :one
-INSERT INTO payments (order_id, description, user_subscription_id, amount, payment_method, paid_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at
+INSERT INTO payments (order_id, description, user_subscription_id, amount, payment_method, paid_at, currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at, currency
 `
 
 type CreatePaymentParams struct {
@@ -25,6 +25,7 @@ type CreatePaymentParams struct {
 	Amount             pgtype.Numeric `json:"amount"`
 	PaymentMethod      string         `json:"payment_method"`
 	PaidAt             time.Time      `json:"paid_at"`
+	Currency           string         `json:"currency"`
 }
 
 func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error) {
@@ -35,6 +36,7 @@ func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (P
 		arg.Amount,
 		arg.PaymentMethod,
 		arg.PaidAt,
+		arg.Currency,
 	)
 	var i Payment
 	err := row.Scan(
@@ -46,12 +48,13 @@ func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (P
 		&i.Amount,
 		&i.PaidAt,
 		&i.CreatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const getPaymentByID = `-- name: GetPaymentByID :one
-SELECT id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at FROM payments WHERE id = $1
+SELECT id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at, currency FROM payments WHERE id = $1
 `
 
 func (q *Queries) GetPaymentByID(ctx context.Context, id int64) (Payment, error) {
@@ -66,12 +69,13 @@ func (q *Queries) GetPaymentByID(ctx context.Context, id int64) (Payment, error)
 		&i.Amount,
 		&i.PaidAt,
 		&i.CreatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const getPaymentsByOrderID = `-- name: GetPaymentsByOrderID :one
-SELECT id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at FROM payments WHERE order_id = $1 LIMIT 1
+SELECT id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at, currency FROM payments WHERE order_id = $1 LIMIT 1
 `
 
 func (q *Queries) GetPaymentsByOrderID(ctx context.Context, orderID pgtype.Int8) (Payment, error) {
@@ -86,12 +90,13 @@ func (q *Queries) GetPaymentsByOrderID(ctx context.Context, orderID pgtype.Int8)
 		&i.Amount,
 		&i.PaidAt,
 		&i.CreatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const getPaymentsByUserSubscriptionID = `-- name: GetPaymentsByUserSubscriptionID :one
-SELECT id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at FROM payments WHERE order_id = $1 LIMIT 1
+SELECT id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at, currency FROM payments WHERE order_id = $1 LIMIT 1
 `
 
 func (q *Queries) GetPaymentsByUserSubscriptionID(ctx context.Context, orderID pgtype.Int8) (Payment, error) {
@@ -106,6 +111,7 @@ func (q *Queries) GetPaymentsByUserSubscriptionID(ctx context.Context, orderID p
 		&i.Amount,
 		&i.PaidAt,
 		&i.CreatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -134,51 +140,28 @@ func (q *Queries) ListCountPayments(ctx context.Context, arg ListCountPaymentsPa
 	return total_payments, err
 }
 
-const listPayments = `-- name: ListPayments :many
-SELECT id, description, order_id, user_subscription_id, payment_method, amount, paid_at, created_at FROM payments
-WHERE 
-    (
-        COALESCE($1, '') = '' 
-        OR LOWER(payment_method) LIKE $1
-    )
-    AND paid_at BETWEEN $2 AND $3
-ORDER BY paid_at DESC
-LIMIT $5 OFFSET $4
+const totalRevenue = `-- name: TotalRevenue :many
+SELECT currency, COALESCE(SUM(amount), 0)::numeric AS total_revenue
+FROM payments
+GROUP BY currency
+ORDER BY currency
 `
 
-type ListPaymentsParams struct {
-	PaymentMethod interface{}        `json:"payment_method"`
-	StartDate     pgtype.Timestamptz `json:"start_date"`
-	EndDate       pgtype.Timestamptz `json:"end_date"`
-	Offset        int32              `json:"offset"`
-	Limit         int32              `json:"limit"`
+type TotalRevenueRow struct {
+	Currency     string         `json:"currency"`
+	TotalRevenue pgtype.Numeric `json:"total_revenue"`
 }
 
-func (q *Queries) ListPayments(ctx context.Context, arg ListPaymentsParams) ([]Payment, error) {
-	rows, err := q.db.Query(ctx, listPayments,
-		arg.PaymentMethod,
-		arg.StartDate,
-		arg.EndDate,
-		arg.Offset,
-		arg.Limit,
-	)
+func (q *Queries) TotalRevenue(ctx context.Context) ([]TotalRevenueRow, error) {
+	rows, err := q.db.Query(ctx, totalRevenue)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Payment{}
+	items := []TotalRevenueRow{}
 	for rows.Next() {
-		var i Payment
-		if err := rows.Scan(
-			&i.ID,
-			&i.Description,
-			&i.OrderID,
-			&i.UserSubscriptionID,
-			&i.PaymentMethod,
-			&i.Amount,
-			&i.PaidAt,
-			&i.CreatedAt,
-		); err != nil {
+		var i TotalRevenueRow
+		if err := rows.Scan(&i.Currency, &i.TotalRevenue); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -189,18 +172,6 @@ func (q *Queries) ListPayments(ctx context.Context, arg ListPaymentsParams) ([]P
 	return items, nil
 }
 
-const totalRevenue = `-- name: TotalRevenue :one
-SELECT COALESCE(SUM(amount), 0) AS total_revenue
-FROM payments
-`
-
-func (q *Queries) TotalRevenue(ctx context.Context) (interface{}, error) {
-	row := q.db.QueryRow(ctx, totalRevenue)
-	var total_revenue interface{}
-	err := row.Scan(&total_revenue)
-	return total_revenue, err
-}
-
 const updatePayment = `-- name: UpdatePayment :one
 UPDATE payments
 SET payment_method = coalesce($1, payment_method),
@@ -27,23 +27,45 @@ func (q *Queries) CreatePaystackEvent(ctx context.Context, arg CreatePaystackEve
 }
 
 const createPaystackPayment = `-- name: CreatePaystackPayment :exec
-INSERT INTO paystack_payments (amount, email, reference)
-VALUES ($1, $2, $3)
+INSERT INTO paystack_payments (amount, email, reference, currency)
+VALUES ($1, $2, $3, $4)
 `
 
 type CreatePaystackPaymentParams struct {
 	Amount    string `json:"amount"`
 	Email     string `json:"email"`
 	Reference string `json:"reference"`
+	Currency  string `json:"currency"`
 }
 
 func (q *Queries) CreatePaystackPayment(ctx context.Context, arg CreatePaystackPaymentParams) error {
-	_, err := q.db.Exec(ctx, createPaystackPayment, arg.Amount, arg.Email, arg.Reference)
+	_, err := q.db.Exec(ctx, createPaystackPayment,
+		arg.Amount,
+		arg.Email,
+		arg.Reference,
+		arg.Currency,
+	)
 	return err
 }
 
+const getPaystackEventByID = `-- name: GetPaystackEventByID :one
+SELECT id, event, data, created_at FROM paystack_events WHERE id = $1
+`
+
+func (q *Queries) GetPaystackEventByID(ctx context.Context, id int64) (PaystackEvent, error) {
+	row := q.db.QueryRow(ctx, getPaystackEventByID, id)
+	var i PaystackEvent
+	err := row.Scan(
+		&i.ID,
+		&i.Event,
+		&i.Data,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getPaystackPaymentByReference = `-- name: GetPaystackPaymentByReference :one
-SELECT id, email, amount, reference, status, created_at, updated_at FROM paystack_payments WHERE reference = $1
+SELECT id, email, amount, reference, status, created_at, updated_at, currency FROM paystack_payments WHERE reference = $1
 `
 
 func (q *Queries) GetPaystackPaymentByReference(ctx context.Context, reference string) (PaystackPayment, error) {
@@ -57,6 +79,7 @@ func (q *Queries) GetPaystackPaymentByReference(ctx context.Context, reference s
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -138,7 +161,7 @@ func (q *Queries) ListPaystackEvents(ctx context.Context, arg ListPaystackEvents
 }
 
 const listPaystackPayments = `-- name: ListPaystackPayments :many
-SELECT id, email, amount, reference, status, created_at, updated_at FROM paystack_payments
+SELECT id, email, amount, reference, status, created_at, updated_at, currency FROM paystack_payments
 WHERE 
     (
         COALESCE($1::text, '') = '' 
@@ -171,6 +194,7 @@ func (q *Queries) ListPaystackPayments(ctx context.Context, arg ListPaystackPaym
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
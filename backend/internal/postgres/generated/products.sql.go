@@ -13,22 +13,26 @@ import (
 )
 
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO products (name, description, price, category_id, has_stems, is_message_card, is_add_on, is_flowers, image_url, stock_quantity)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, name, description, price, category_id, image_url, stock_quantity, deleted_at, created_at, has_stems, is_message_card, is_flowers, is_add_on
+INSERT INTO products (name, description, price, category_id, has_variants, is_message_card, is_add_on, is_flowers, image_url, stock_quantity, currency, tax_class_id, reorder_threshold, sku)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, name, description, price, category_id, image_url, stock_quantity, deleted_at, created_at, has_variants, is_message_card, is_flowers, is_add_on, currency, tax_class_id, reorder_threshold, sku, popularity
 `
 
 type CreateProductParams struct {
-	Name          string         `json:"name"`
-	Description   string         `json:"description"`
-	Price         pgtype.Numeric `json:"price"`
-	CategoryID    int64          `json:"category_id"`
-	HasStems      bool           `json:"has_stems"`
-	IsMessageCard bool           `json:"is_message_card"`
-	IsAddOn       bool           `json:"is_add_on"`
-	IsFlowers     bool           `json:"is_flowers"`
-	ImageUrl      []string       `json:"image_url"`
-	StockQuantity int64          `json:"stock_quantity"`
+	Name             string         `json:"name"`
+	Description      string         `json:"description"`
+	Price            pgtype.Numeric `json:"price"`
+	CategoryID       int64          `json:"category_id"`
+	HasVariants      bool           `json:"has_variants"`
+	IsMessageCard    bool           `json:"is_message_card"`
+	IsAddOn          bool           `json:"is_add_on"`
+	IsFlowers        bool           `json:"is_flowers"`
+	ImageUrl         []string       `json:"image_url"`
+	StockQuantity    int64          `json:"stock_quantity"`
+	Currency         string         `json:"currency"`
+	TaxClassID       pgtype.Int8    `json:"tax_class_id"`
+	ReorderThreshold pgtype.Int8    `json:"reorder_threshold"`
+	Sku              pgtype.Text    `json:"sku"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -37,12 +41,16 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.Description,
 		arg.Price,
 		arg.CategoryID,
-		arg.HasStems,
+		arg.HasVariants,
 		arg.IsMessageCard,
 		arg.IsAddOn,
 		arg.IsFlowers,
 		arg.ImageUrl,
 		arg.StockQuantity,
+		arg.Currency,
+		arg.TaxClassID,
+		arg.ReorderThreshold,
+		arg.Sku,
 	)
 	var i Product
 	err := row.Scan(
@@ -55,10 +63,15 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.StockQuantity,
 		&i.DeletedAt,
 		&i.CreatedAt,
-		&i.HasStems,
+		&i.HasVariants,
 		&i.IsMessageCard,
 		&i.IsFlowers,
 		&i.IsAddOn,
+		&i.Currency,
+		&i.TaxClassID,
+		&i.ReorderThreshold,
+		&i.Sku,
+		&i.Popularity,
 	)
 	return i, err
 }
@@ -75,10 +88,12 @@ func (q *Queries) DeleteProduct(ctx context.Context, id int64) error {
 }
 
 const getProductByID = `-- name: GetProductByID :one
-SELECT p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity, p.deleted_at, p.created_at, p.has_stems, p.is_message_card, p.is_flowers, p.is_add_on, 
+SELECT p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity, p.deleted_at, p.created_at, p.has_variants, p.is_message_card, p.is_flowers, p.is_add_on, p.currency, p.tax_class_id, p.reorder_threshold, p.sku, p.popularity, 
        c.id AS category_id,
        c.name AS category_name, 
-       c.description AS category_description
+       c.description AS category_description,
+       product_price(p, NULL, p.price, now())::decimal AS effective_price,
+       product_availability(p, NULL, p.stock_quantity)::bigint AS available_quantity
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.id = $1
@@ -95,13 +110,20 @@ type GetProductByIDRow struct {
 	StockQuantity       int64              `json:"stock_quantity"`
 	DeletedAt           pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt           time.Time          `json:"created_at"`
-	HasStems            bool               `json:"has_stems"`
+	HasVariants         bool               `json:"has_variants"`
 	IsMessageCard       bool               `json:"is_message_card"`
 	IsFlowers           bool               `json:"is_flowers"`
 	IsAddOn             bool               `json:"is_add_on"`
+	Currency            string             `json:"currency"`
+	TaxClassID          pgtype.Int8        `json:"tax_class_id"`
+	ReorderThreshold    pgtype.Int8        `json:"reorder_threshold"`
+	Sku                 pgtype.Text        `json:"sku"`
+	Popularity          int64              `json:"popularity"`
 	CategoryID_2        pgtype.Int8        `json:"category_id_2"`
 	CategoryName        pgtype.Text        `json:"category_name"`
 	CategoryDescription pgtype.Text        `json:"category_description"`
+	EffectivePrice      pgtype.Numeric     `json:"effective_price"`
+	AvailableQuantity   int64              `json:"available_quantity"`
 }
 
 func (q *Queries) GetProductByID(ctx context.Context, id int64) (GetProductByIDRow, error) {
@@ -117,224 +139,189 @@ func (q *Queries) GetProductByID(ctx context.Context, id int64) (GetProductByIDR
 		&i.StockQuantity,
 		&i.DeletedAt,
 		&i.CreatedAt,
-		&i.HasStems,
+		&i.HasVariants,
 		&i.IsMessageCard,
 		&i.IsFlowers,
 		&i.IsAddOn,
+		&i.Currency,
+		&i.TaxClassID,
+		&i.ReorderThreshold,
+		&i.Sku,
+		&i.Popularity,
 		&i.CategoryID_2,
 		&i.CategoryName,
 		&i.CategoryDescription,
+		&i.EffectivePrice,
+		&i.AvailableQuantity,
 	)
 	return i, err
 }
 
-const listCountProducts = `-- name: ListCountProducts :one
-SELECT COUNT(DISTINCT p.id) AS total_products
-FROM products p
-WHERE 
-    p.deleted_at IS NULL
-    AND (
-        COALESCE($1, '') = '' 
-        OR LOWER(p.name) LIKE $1
-        OR LOWER(p.description) LIKE $1
-    )
-    AND (
-        $2::float IS NULL 
-        OR p.price >= $2
-    )
-    AND (
-        $3::boolean IS NULL 
-        OR p.is_message_card = $3
-    )
-    AND (
-        $4::boolean IS NULL 
-        OR p.is_flowers = $4
-    )
-    AND (
-        $5::boolean IS NULL 
-        OR p.is_add_on = $5
-    )
-    AND (
-        $6::float IS NULL 
-        OR p.price <= $6
-    )
-    AND (
-        $7::int[] IS NULL 
-        OR p.category_id = ANY($7::int[])
-    )
+const getProductBySKU = `-- name: GetProductBySKU :one
+SELECT id, deleted_at FROM products
+WHERE sku = $1
 `
 
-type ListCountProductsParams struct {
-	Search        interface{}   `json:"search"`
-	PriceFrom     pgtype.Float8 `json:"price_from"`
-	IsMessageCard pgtype.Bool   `json:"is_message_card"`
-	IsFlowers     pgtype.Bool   `json:"is_flowers"`
-	IsAddOn       pgtype.Bool   `json:"is_add_on"`
-	PriceTo       pgtype.Float8 `json:"price_to"`
-	CategoryIds   []int32       `json:"category_ids"`
+type GetProductBySKURow struct {
+	ID        int64              `json:"id"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
-func (q *Queries) ListCountProducts(ctx context.Context, arg ListCountProductsParams) (int64, error) {
-	row := q.db.QueryRow(ctx, listCountProducts,
-		arg.Search,
-		arg.PriceFrom,
-		arg.IsMessageCard,
-		arg.IsFlowers,
-		arg.IsAddOn,
-		arg.PriceTo,
-		arg.CategoryIds,
-	)
-	var total_products int64
-	err := row.Scan(&total_products)
-	return total_products, err
+// the product with its own sku, deleted or not, since the sku stays taken.
+func (q *Queries) GetProductBySKU(ctx context.Context, sku pgtype.Text) (GetProductBySKURow, error) {
+	row := q.db.QueryRow(ctx, getProductBySKU, sku)
+	var i GetProductBySKURow
+	err := row.Scan(&i.ID, &i.DeletedAt)
+	return i, err
 }
 
-const listProducts = `-- name: ListProducts :many
-
-
-SELECT 
-    p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity, p.deleted_at, p.created_at, p.has_stems, p.is_message_card, p.is_flowers, p.is_add_on,
-    c.id AS category_id,
-    c.name AS category_name, 
-    c.description AS category_description,
-    COALESCE(
-        json_agg(
-            jsonb_build_object(
-                'id', ps.id,
-                'product_id', ps.product_id,
-                'stem_count', ps.stem_count,
-                'price', ps.price
+const listCountProducts = `-- name: ListCountProducts :one
+WITH filtered AS (
+    SELECT
+        p.id,
+        product_price(p, NULL, p.price, now()) AS price,
+        p.category_id,
+        p.is_flowers,
+        p.is_add_on,
+        p.is_message_card,
+        (
+            product_availability(p, NULL, p.stock_quantity) > 0
+            OR EXISTS (
+                SELECT 1 FROM product_variants pv
+                WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND product_availability(p, pv.id, pv.stock_quantity) > 0
             )
-        ) FILTER (WHERE ps.id IS NOT NULL), '[]'
-    ) AS stems
-FROM products p
-LEFT JOIN categories c ON p.category_id = c.id
-LEFT JOIN product_stems ps ON ps.product_id = p.id
-WHERE 
-    p.deleted_at IS NULL
-    AND (
-        COALESCE($1, '') = '' 
-        OR LOWER(p.name) LIKE $1
-        OR LOWER(p.description) LIKE $1
-    )
-    AND (
-        $2::float IS NULL 
-        OR p.price >= $2
-    )
-    AND (
-        $3::boolean IS NULL 
-        OR p.is_message_card = $3
-    )
-    AND (
-        $4::boolean IS NULL 
-        OR p.is_flowers = $4
-    )
-    AND (
-        $5::boolean IS NULL 
-        OR p.is_add_on = $5
-    )
-    AND (
-        $6::float IS NULL 
-        OR p.price <= $6
-    )
-    AND (
-        $7::int[] IS NULL 
-        OR p.category_id = ANY($7::int[])
-    )
-GROUP BY p.id, c.id, c.name, c.description
-ORDER BY p.created_at DESC
-LIMIT $9 OFFSET $8
+        ) AS in_stock
+    FROM products p
+    LEFT JOIN product_search_documents psd ON psd.product_id = p.id
+    WHERE 
+        p.deleted_at IS NULL
+        AND (
+            COALESCE($1::text, '') = ''
+            OR psd.document @@ websearch_to_tsquery('english', $1::text)
+            OR p.name % $1::text
+            OR $1::text <% p.name
+        )
+        AND (
+            $2::float IS NULL 
+            OR product_price(p, NULL, p.price, now()) >= $2
+        )
+        AND (
+            $3::boolean IS NULL 
+            OR p.is_message_card = $3
+        )
+        AND (
+            $4::boolean IS NULL 
+            OR p.is_flowers = $4
+        )
+        AND (
+            $5::boolean IS NULL 
+            OR p.is_add_on = $5
+        )
+        AND (
+            $6::float IS NULL 
+            OR product_price(p, NULL, p.price, now()) <= $6
+        )
+        AND (
+            $7::int[] IS NULL 
+            OR p.category_id = ANY(category_subtree_ids($7::bigint[]))
+        )
+        AND (
+            $8::boolean IS NULL
+            OR (
+                product_availability(p, NULL, p.stock_quantity) > 0
+                OR EXISTS (
+                    SELECT 1 FROM product_variants pv
+                    WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND product_availability(p, pv.id, pv.stock_quantity) > 0
+                )
+            ) = $8
+        )
+        AND (
+            $9::bigint[] IS NULL
+            OR (
+                SELECT COUNT(*) FROM product_tags pt
+                WHERE pt.product_id = p.id AND pt.tag_id = ANY($9::bigint[])
+            ) = cardinality($9::bigint[])
+        )
+)
+SELECT
+    (SELECT COUNT(*) FROM filtered) AS total_products,
+    CASE WHEN $10::boolean THEN json_build_object(
+        'categories', (
+            SELECT COALESCE(json_agg(json_build_object(
+                'id', c.id,
+                'name', c.name,
+                'count', f.count
+            ) ORDER BY f.count DESC, c.name), '[]')
+            FROM (SELECT category_id, COUNT(*) AS count FROM filtered GROUP BY category_id) f
+            JOIN categories c ON c.id = f.category_id
+        ),
+        'tags', (
+            SELECT COALESCE(json_agg(json_build_object(
+                'id', t.id,
+                'name', t.name,
+                'slug', t.slug,
+                'group', g.name,
+                'count', f.count
+            ) ORDER BY g.position, g.name, f.count DESC, t.name), '[]')
+            FROM (
+                SELECT pt.tag_id, COUNT(*) AS count
+                FROM filtered
+                JOIN product_tags pt ON pt.product_id = filtered.id
+                GROUP BY pt.tag_id
+            ) f
+            JOIN tags t ON t.id = f.tag_id AND t.deleted_at IS NULL
+            JOIN tag_groups g ON g.id = t.tag_group_id
+        ),
+        'price_buckets', (
+            SELECT COALESCE(json_agg(json_build_object(
+                'bucket', b.bucket,
+                'count', b.count
+            ) ORDER BY b.bucket), '[]')
+            FROM (
+                SELECT width_bucket(price::float8, $11::float8[]) AS bucket, COUNT(*) AS count
+                FROM filtered
+                GROUP BY 1
+            ) b
+        ),
+        'types', (
+            SELECT json_build_object(
+                'flowers', COUNT(*) FILTER (WHERE is_flowers),
+                'add_ons', COUNT(*) FILTER (WHERE is_add_on),
+                'message_cards', COUNT(*) FILTER (WHERE is_message_card)
+            )
+            FROM filtered
+        ),
+        'stock', (
+            SELECT json_build_object(
+                'in_stock', COUNT(*) FILTER (WHERE in_stock),
+                'out_of_stock', COUNT(*) FILTER (WHERE NOT in_stock)
+            )
+            FROM filtered
+        )
+    ) END AS facets
 `
 
-type ListProductsParams struct {
-	Search        interface{}   `json:"search"`
+type ListCountProductsParams struct {
+	Search        pgtype.Text   `json:"search"`
 	PriceFrom     pgtype.Float8 `json:"price_from"`
 	IsMessageCard pgtype.Bool   `json:"is_message_card"`
 	IsFlowers     pgtype.Bool   `json:"is_flowers"`
 	IsAddOn       pgtype.Bool   `json:"is_add_on"`
 	PriceTo       pgtype.Float8 `json:"price_to"`
 	CategoryIds   []int32       `json:"category_ids"`
-	Offset        int32         `json:"offset"`
-	Limit         int32         `json:"limit"`
+	InStock       pgtype.Bool   `json:"in_stock"`
+	TagIds        []int64       `json:"tag_ids"`
+	WithFacets    bool          `json:"with_facets"`
+	PriceBounds   []float64     `json:"price_bounds"`
 }
 
-type ListProductsRow struct {
-	ID                  int64              `json:"id"`
-	Name                string             `json:"name"`
-	Description         string             `json:"description"`
-	Price               pgtype.Numeric     `json:"price"`
-	CategoryID          int64              `json:"category_id"`
-	ImageUrl            []string           `json:"image_url"`
-	StockQuantity       int64              `json:"stock_quantity"`
-	DeletedAt           pgtype.Timestamptz `json:"deleted_at"`
-	CreatedAt           time.Time          `json:"created_at"`
-	HasStems            bool               `json:"has_stems"`
-	IsMessageCard       bool               `json:"is_message_card"`
-	IsFlowers           bool               `json:"is_flowers"`
-	IsAddOn             bool               `json:"is_add_on"`
-	CategoryID_2        pgtype.Int8        `json:"category_id_2"`
-	CategoryName        pgtype.Text        `json:"category_name"`
-	CategoryDescription pgtype.Text        `json:"category_description"`
-	Stems               interface{}        `json:"stems"`
+type ListCountProductsRow struct {
+	TotalProducts int64  `json:"total_products"`
+	Facets        []byte `json:"facets"`
 }
 
-// -- name: ListProducts :many
-// SELECT p.*,
-//
-//	c.id AS category_id,
-//	c.name AS category_name,
-//	c.description AS category_description
-//
-// FROM products p
-// LEFT JOIN categories c ON p.category_id = c.id
-// WHERE
-//
-//	p.deleted_at IS NULL
-//	AND (
-//	    COALESCE(sqlc.narg('search'), '') = ''
-//	    OR LOWER(p.name) LIKE sqlc.narg('search')
-//	    OR LOWER(p.description) LIKE sqlc.narg('search')
-//	)
-//	AND (
-//	    sqlc.narg('price_from')::float IS NULL
-//	    OR p.price >= sqlc.narg('price_from')
-//	)
-//	AND (
-//	    sqlc.narg('price_to')::float IS NULL
-//	    OR p.price <= sqlc.narg('price_to')
-//	)
-//	AND (
-//	    sqlc.narg('category_ids')::int[] IS NULL
-//	    OR p.category_id = ANY(sqlc.narg('category_ids')::int[])
-//	)
-//
-// ORDER BY p.created_at DESC
-// LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-// -- name: ListCountProducts :one
-// SELECT COUNT(*) AS total_products
-// FROM products
-// WHERE
-//
-//	deleted_at IS NULL
-//	AND (
-//	    COALESCE(sqlc.narg('search'), '') = ''
-//	    OR LOWER(name) LIKE sqlc.narg('search')
-//	    OR LOWER(description) LIKE sqlc.narg('search')
-//	)
-//	AND (
-//	    sqlc.narg('price_from')::float IS NULL
-//	    OR price >= sqlc.narg('price_from')
-//	)
-//	AND (
-//	    sqlc.narg('price_to')::float IS NULL
-//	    OR price <= sqlc.narg('price_to')
-//	)
-//	AND (
-//	    sqlc.narg('category_ids')::int[] IS NULL
-//	    OR category_id = ANY(sqlc.narg('category_ids')::int[])
-//	);
-func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error) {
-	rows, err := q.db.Query(ctx, listProducts,
+func (q *Queries) ListCountProducts(ctx context.Context, arg ListCountProductsParams) (ListCountProductsRow, error) {
+	row := q.db.QueryRow(ctx, listCountProducts,
 		arg.Search,
 		arg.PriceFrom,
 		arg.IsMessageCard,
@@ -342,43 +329,14 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]L
 		arg.IsAddOn,
 		arg.PriceTo,
 		arg.CategoryIds,
-		arg.Offset,
-		arg.Limit,
+		arg.InStock,
+		arg.TagIds,
+		arg.WithFacets,
+		arg.PriceBounds,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []ListProductsRow{}
-	for rows.Next() {
-		var i ListProductsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.Price,
-			&i.CategoryID,
-			&i.ImageUrl,
-			&i.StockQuantity,
-			&i.DeletedAt,
-			&i.CreatedAt,
-			&i.HasStems,
-			&i.IsMessageCard,
-			&i.IsFlowers,
-			&i.IsAddOn,
-			&i.CategoryID_2,
-			&i.CategoryName,
-			&i.CategoryDescription,
-			&i.Stems,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	var i ListCountProductsRow
+	err := row.Scan(&i.TotalProducts, &i.Facets)
+	return i, err
 }
 
 const productExists = `-- name: ProductExists :one
@@ -392,15 +350,32 @@ func (q *Queries) ProductExists(ctx context.Context, id int64) (bool, error) {
 	return exists, err
 }
 
+const restoreProduct = `-- name: RestoreProduct :execrows
+UPDATE products p
+SET deleted_at = NULL
+WHERE p.id = $1
+    AND p.deleted_at IS NOT NULL
+    AND EXISTS (SELECT 1 FROM categories c WHERE c.id = p.category_id AND c.deleted_at IS NULL)
+`
+
+// only products whose category is still live can be restored
+func (q *Queries) RestoreProduct(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreProduct, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const totalProducts = `-- name: TotalProducts :one
-SELECT COALESCE(COUNT(*), 0) AS total_products
+SELECT COUNT(*) AS total_products
 FROM products
 WHERE deleted_at IS NULL
 `
 
-func (q *Queries) TotalProducts(ctx context.Context) (interface{}, error) {
+func (q *Queries) TotalProducts(ctx context.Context) (int64, error) {
 	row := q.db.QueryRow(ctx, totalProducts)
-	var total_products interface{}
+	var total_products int64
 	err := row.Scan(&total_products)
 	return total_products, err
 }
@@ -411,28 +386,36 @@ SET name = coalesce($1, name),
     description = coalesce($2, description),
     price = coalesce($3, price),
     category_id = coalesce($4, category_id),
-    has_stems = coalesce($5, has_stems),
+    has_variants = coalesce($5, has_variants),
     is_message_card = coalesce($6, is_message_card),
     is_flowers = coalesce($7, is_flowers),
     is_add_on = coalesce($8, is_add_on),
     image_url = coalesce($9, image_url),
-    stock_quantity = coalesce($10, stock_quantity)
-WHERE id = $11
-RETURNING id, name, description, price, category_id, image_url, stock_quantity, deleted_at, created_at, has_stems, is_message_card, is_flowers, is_add_on
+    stock_quantity = coalesce($10, stock_quantity),
+    currency = coalesce($11, currency),
+    tax_class_id = coalesce($12, tax_class_id),
+    reorder_threshold = coalesce($13, reorder_threshold),
+    sku = coalesce($14, sku)
+WHERE id = $15
+RETURNING id, name, description, price, category_id, image_url, stock_quantity, deleted_at, created_at, has_variants, is_message_card, is_flowers, is_add_on, currency, tax_class_id, reorder_threshold, sku, popularity
 `
 
 type UpdateProductParams struct {
-	Name          pgtype.Text    `json:"name"`
-	Description   pgtype.Text    `json:"description"`
-	Price         pgtype.Numeric `json:"price"`
-	CategoryID    pgtype.Int8    `json:"category_id"`
-	HasStems      pgtype.Bool    `json:"has_stems"`
-	IsMessageCard pgtype.Bool    `json:"is_message_card"`
-	IsFlowers     pgtype.Bool    `json:"is_flowers"`
-	IsAddOn       pgtype.Bool    `json:"is_add_on"`
-	ImageUrl      []string       `json:"image_url"`
-	StockQuantity pgtype.Int8    `json:"stock_quantity"`
-	ID            int64          `json:"id"`
+	Name             pgtype.Text    `json:"name"`
+	Description      pgtype.Text    `json:"description"`
+	Price            pgtype.Numeric `json:"price"`
+	CategoryID       pgtype.Int8    `json:"category_id"`
+	HasVariants      pgtype.Bool    `json:"has_variants"`
+	IsMessageCard    pgtype.Bool    `json:"is_message_card"`
+	IsFlowers        pgtype.Bool    `json:"is_flowers"`
+	IsAddOn          pgtype.Bool    `json:"is_add_on"`
+	ImageUrl         []string       `json:"image_url"`
+	StockQuantity    pgtype.Int8    `json:"stock_quantity"`
+	Currency         pgtype.Text    `json:"currency"`
+	TaxClassID       pgtype.Int8    `json:"tax_class_id"`
+	ReorderThreshold pgtype.Int8    `json:"reorder_threshold"`
+	Sku              pgtype.Text    `json:"sku"`
+	ID               int64          `json:"id"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
@@ -441,12 +424,16 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.Description,
 		arg.Price,
 		arg.CategoryID,
-		arg.HasStems,
+		arg.HasVariants,
 		arg.IsMessageCard,
 		arg.IsFlowers,
 		arg.IsAddOn,
 		arg.ImageUrl,
 		arg.StockQuantity,
+		arg.Currency,
+		arg.TaxClassID,
+		arg.ReorderThreshold,
+		arg.Sku,
 		arg.ID,
 	)
 	var i Product
@@ -460,10 +447,15 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.StockQuantity,
 		&i.DeletedAt,
 		&i.CreatedAt,
-		&i.HasStems,
+		&i.HasVariants,
 		&i.IsMessageCard,
 		&i.IsFlowers,
 		&i.IsAddOn,
+		&i.Currency,
+		&i.TaxClassID,
+		&i.ReorderThreshold,
+		&i.Sku,
+		&i.Popularity,
 	)
 	return i, err
 }
@@ -6,142 +6,253 @@ package generated
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	ActiveSubscriptions(ctx context.Context) (interface{}, error)
+	ActiveSubscriptions(ctx context.Context) (int64, error)
+	AddCategoryImageUrls(ctx context.Context, arg AddCategoryImageUrlsParams) error
+	AddProductImageUrls(ctx context.Context, arg AddProductImageUrlsParams) error
+	// stock never goes below 0, the quantity actually added is returned
+	AddProductStock(ctx context.Context, arg AddProductStockParams) (int64, error)
+	AddProductTags(ctx context.Context, arg AddProductTagsParams) error
+	AddProductVariantStock(ctx context.Context, arg AddProductVariantStockParams) (int64, error)
+	AllocateStockBatch(ctx context.Context, arg AllocateStockBatchParams) error
+	CategoryExists(ctx context.Context, id int64) (bool, error)
+	CountCategoryChildren(ctx context.Context, parentID pgtype.Int8) (int64, error)
+	CountCategoryProducts(ctx context.Context, categoryID int64) (int64, error)
+	CountLiveTags(ctx context.Context, ids []int64) (int64, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateImage(ctx context.Context, arg CreateImageParams) (Image, error)
+	// alerts on the low stock staff have not been notified of yet
+	CreateLowStockAlerts(ctx context.Context) ([]LowStockItem, error)
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (int64, error)
+	CreateOrderInvoice(ctx context.Context, arg CreateOrderInvoiceParams) (Invoice, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (int64, error)
 	CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error)
+	CreatePaymentInvoice(ctx context.Context, arg CreatePaymentInvoiceParams) (Invoice, error)
 	CreatePaystackEvent(ctx context.Context, arg CreatePaystackEventParams) error
 	CreatePaystackPayment(ctx context.Context, arg CreatePaystackPaymentParams) error
+	CreatePriceRule(ctx context.Context, arg CreatePriceRuleParams) (PriceRule, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
-	CreateProductStem(ctx context.Context, arg CreateProductStemParams) (ProductStem, error)
+	CreateProductVariantOption(ctx context.Context, arg CreateProductVariantOptionParams) error
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) error
+	CreateRecipeComponent(ctx context.Context, arg CreateRecipeComponentParams) error
+	CreateStockBatch(ctx context.Context, arg CreateStockBatchParams) (StockBatch, error)
+	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error)
 	CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) (int64, error)
 	CreateSubscriptionDelivery(ctx context.Context, arg CreateSubscriptionDeliveryParams) (SubscriptionDelivery, error)
+	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
+	CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error)
+	CreateTagGroup(ctx context.Context, arg CreateTagGroupParams) (TagGroup, error)
+	CreateTaxClass(ctx context.Context, arg CreateTaxClassParams) (TaxClass, error)
+	CreateTaxRate(ctx context.Context, arg CreateTaxRateParams) (TaxRate, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateUserSubscription(ctx context.Context, arg CreateUserSubscriptionParams) (int64, error)
 	DeleteCategory(ctx context.Context, id int64) error
+	DeleteImage(ctx context.Context, id int64) error
 	DeleteOrder(ctx context.Context, id int64) error
 	DeleteProduct(ctx context.Context, id int64) error
-	DeleteProductStemsByProductID(ctx context.Context, productID int64) error
+	DeleteProductTags(ctx context.Context, productID int64) error
+	DeleteProductVariantOptions(ctx context.Context, variantID int64) error
+	DeleteProductVariantsNotIn(ctx context.Context, arg DeleteProductVariantsNotInParams) error
+	DeletePurchaseOrderItems(ctx context.Context, purchaseOrderID int64) error
+	DeleteRecipeComponents(ctx context.Context, arg DeleteRecipeComponentsParams) error
 	DeleteSubscription(ctx context.Context, id int64) error
 	DeleteSubscriptionDelivery(ctx context.Context, id int64) error
+	DeleteSupplier(ctx context.Context, id int64) (int64, error)
+	DeleteTag(ctx context.Context, id int64) error
+	DeleteTagGroup(ctx context.Context, tagGroupID int64) error
+	DeleteTaxClass(ctx context.Context, id int64) error
+	DeleteTaxRate(ctx context.Context, id int64) error
 	DeleteUserSubscription(ctx context.Context, id int64) error
-	GetCategoriesWithProductCount(ctx context.Context) ([]GetCategoriesWithProductCountRow, error)
+	// rules that have started are ended now so the history shows when they were
+	// in force, rules that have not started yet, or start just now and so cannot
+	// end after they start, are deleted
+	EndPriceRule(ctx context.Context, id int64) (int64, error)
+	// the paid orders placed between from and to per currency.
+	GetAverageOrderValues(ctx context.Context, arg GetAverageOrderValuesParams) ([]GetAverageOrderValuesRow, error)
+	GetCategoriesWithProductCount(ctx context.Context) ([]Category, error)
+	GetCategoryAncestors(ctx context.Context, id int64) ([]GetCategoryAncestorsRow, error)
 	GetCategoryByID(ctx context.Context, id int64) (Category, error)
 	GetCountOrderItemsByProductID(ctx context.Context, productID int64) (int64, error)
 	GetCountUserSubscriptionsByUserID(ctx context.Context, userID pgtype.Int8) (int64, error)
+	// the price of the product, or of one of its variants, at a point in time
+	// with the price rule in force applied
+	// the customers, told apart by phone number, who ordered between from and to
+	// and those of them who had not ordered before.
+	GetCustomerCounts(ctx context.Context, arg GetCustomerCountsParams) (GetCustomerCountsRow, error)
+	GetEffectivePrice(ctx context.Context, arg GetEffectivePriceParams) (pgtype.Numeric, error)
+	GetInvoiceByOrderID(ctx context.Context, orderID pgtype.Int8) (Invoice, error)
+	GetInvoiceByPaymentID(ctx context.Context, paymentID pgtype.Int8) (Invoice, error)
+	// revenue, net of tax, of the paid orders placed between from and to against
+	// the average cost of the stock received in the currency sold in. Variants
+	// without costs of their own are costed at their product's, and products with
+	// a recipe at what their components cost. Items without a cost in the
+	// currency they were sold in have a NULL cost.
+	GetMarginReport(ctx context.Context, arg GetMarginReportParams) ([]GetMarginReportRow, error)
 	GetOrderByFullDataID(ctx context.Context, id int64) (GetOrderByFullDataIDRow, error)
 	GetOrderByID(ctx context.Context, id int64) (Order, error)
 	GetOrderItemsByProductID(ctx context.Context, arg GetOrderItemsByProductIDParams) ([]GetOrderItemsByProductIDRow, error)
+	GetOrdersByStatus(ctx context.Context, arg GetOrdersByStatusParams) ([]GetOrdersByStatusRow, error)
 	GetPaymentByID(ctx context.Context, id int64) (Payment, error)
 	GetPaymentsByOrderID(ctx context.Context, orderID pgtype.Int8) (Payment, error)
 	GetPaymentsByUserSubscriptionID(ctx context.Context, orderID pgtype.Int8) (Payment, error)
+	GetPaystackEventByID(ctx context.Context, id int64) (PaystackEvent, error)
 	GetPaystackPaymentByReference(ctx context.Context, reference string) (PaystackPayment, error)
+	GetPriceRuleByID(ctx context.Context, id int64) (PriceRule, error)
 	GetProductByID(ctx context.Context, id int64) (GetProductByIDRow, error)
-	GetProductStemByID(ctx context.Context, id int64) (ProductStem, error)
-	GetProductStemsByProductID(ctx context.Context, productID int64) ([]ProductStem, error)
+	// the product with its own sku, deleted or not, since the sku stays taken.
+	GetProductBySKU(ctx context.Context, sku pgtype.Text) (GetProductBySKURow, error)
+	GetProductVariantByID(ctx context.Context, id int64) (ProductVariant, error)
+	// the product's own tax class wins over its category's
+	GetProductTaxRate(ctx context.Context, arg GetProductTaxRateParams) (int32, error)
+	GetPurchaseOrderByID(ctx context.Context, id int64) (GetPurchaseOrderByIDRow, error)
+	// locks the purchase order until the transaction changing it commits
+	GetPurchaseOrderForUpdate(ctx context.Context, id int64) (PurchaseOrder, error)
 	GetRecentOrders(ctx context.Context) ([]Order, error)
+	// payments received between from and to per period of granularity (day, week
+	// or month) and currency. Periods without payments are left out.
+	GetRevenueByPeriod(ctx context.Context, arg GetRevenueByPeriodParams) ([]GetRevenueByPeriodRow, error)
+	GetStockBatchByID(ctx context.Context, id int64) (StockBatch, error)
+	GetStockLedgerBalance(ctx context.Context, arg GetStockLedgerBalanceParams) (int64, error)
 	GetSubscriptionByID(ctx context.Context, id int64) (GetSubscriptionByIDRow, error)
 	GetSubscriptionDeliveryByUserSubscriptionID(ctx context.Context, userSubscriptionID int64) ([]SubscriptionDelivery, error)
+	// the subscriptions running at from and at to, and those started and ended
+	// between them.
+	GetSubscriptionCounts(ctx context.Context, arg GetSubscriptionCountsParams) (GetSubscriptionCountsRow, error)
+	GetSupplierByID(ctx context.Context, id int64) (Supplier, error)
+	GetTagGroupByID(ctx context.Context, id int64) (TagGroup, error)
+	GetTagWithProductCount(ctx context.Context, arg GetTagWithProductCountParams) (GetTagWithProductCountRow, error)
+	GetTaxClassByID(ctx context.Context, id int64) (TaxClass, error)
+	// the categories whose products sold most in the paid orders placed between
+	// from and to.
+	GetTopCategories(ctx context.Context, arg GetTopCategoriesParams) ([]GetTopCategoriesRow, error)
+	// the products sold most in the paid orders placed between from and to.
+	GetTopProducts(ctx context.Context, arg GetTopProductsParams) ([]GetTopProductsRow, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int64) (User, error)
 	GetUserSubscriptionByID(ctx context.Context, id int64) (GetUserSubscriptionByIDRow, error)
 	GetUserSubscriptionsByUserID(ctx context.Context, arg GetUserSubscriptionsByUserIDParams) ([]GetUserSubscriptionsByUserIDRow, error)
+	// stock written off, by expired batches or by hand
+	GetWastageReport(ctx context.Context, arg GetWastageReportParams) ([]GetWastageReportRow, error)
+	IsCategoryInSubtree(ctx context.Context, arg IsCategoryInSubtreeParams) (bool, error)
+	IsRecipeComponent(ctx context.Context, componentProductID int64) (bool, error)
+	ListActiveAdmins(ctx context.Context) ([]User, error)
 	ListAddOns(ctx context.Context) ([]ListAddOnsRow, error)
+	// unexpired batches of a product, or of one of its variants, first expiring
+	// first, locked until the order allocating from them commits
+	ListAllocatableStockBatches(ctx context.Context, arg ListAllocatableStockBatchesParams) ([]StockBatch, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
 	ListCategoriesCount(ctx context.Context, search interface{}) (int64, error)
+	// the live categories named name, ignoring case.
+	ListCategoryIDsByName(ctx context.Context, name string) ([]int64, error)
 	ListCountOrder(ctx context.Context, arg ListCountOrderParams) (int64, error)
 	ListCountPayments(ctx context.Context, arg ListCountPaymentsParams) (int64, error)
 	ListCountPaystackEvents(ctx context.Context, event pgtype.Text) (int64, error)
 	ListCountPaystackPayments(ctx context.Context, status pgtype.Text) (int64, error)
-	ListCountProducts(ctx context.Context, arg ListCountProductsParams) (int64, error)
+	ListCountProducts(ctx context.Context, arg ListCountProductsParams) (ListCountProductsRow, error)
 	ListCountSubscriptionDelivery(ctx context.Context) (int64, error)
 	ListCountUserSubscriptions(ctx context.Context, status pgtype.Bool) (int64, error)
+	// the components of the items of the orders delivered between day_start and
+	// day_end, by their recipes
+	ListComponentsNeeded(ctx context.Context, arg ListComponentsNeededParams) ([]ListComponentsNeededRow, error)
+	ListImagesByOwner(ctx context.Context, arg ListImagesByOwnerParams) ([]Image, error)
+	// the components one of a product or variant is made from, by the recipe of
+	// the variant or else of its product, with their stock
+	ListItemRecipeComponents(ctx context.Context, arg ListItemRecipeComponentsParams) ([]ListItemRecipeComponentsRow, error)
+	ListLowStockItems(ctx context.Context) ([]LowStockItem, error)
 	ListMessageCards(ctx context.Context) ([]ListMessageCardsRow, error)
-	ListOrder(ctx context.Context, arg ListOrderParams) ([]Order, error)
-	ListPayments(ctx context.Context, arg ListPaymentsParams) ([]Payment, error)
+	// stock an order has reserved and not released yet, by the batch it came from
+	ListOrderStockReservations(ctx context.Context, orderID pgtype.Int8) ([]ListOrderStockReservationsRow, error)
+	ListOrdersByDeliveryDate(ctx context.Context, arg ListOrdersByDeliveryDateParams) ([]ListOrdersByDeliveryDateRow, error)
+	// images no longer in the image_url of their product, its variants or their
+	// category, for one owner or for all of them
+	ListOrphanImages(ctx context.Context, arg ListOrphanImagesParams) ([]Image, error)
 	ListPaystackEvents(ctx context.Context, arg ListPaystackEventsParams) ([]PaystackEvent, error)
 	ListPaystackPayments(ctx context.Context, arg ListPaystackPaymentsParams) ([]PaystackPayment, error)
-	// -- name: ListProducts :many
-	// SELECT p.*,
-	//        c.id AS category_id,
-	//        c.name AS category_name,
-	//        c.description AS category_description
-	// FROM products p
-	// LEFT JOIN categories c ON p.category_id = c.id
-	// WHERE
-	//     p.deleted_at IS NULL
-	//     AND (
-	//         COALESCE(sqlc.narg('search'), '') = ''
-	//         OR LOWER(p.name) LIKE sqlc.narg('search')
-	//         OR LOWER(p.description) LIKE sqlc.narg('search')
-	//     )
-	//     AND (
-	//         sqlc.narg('price_from')::float IS NULL
-	//         OR p.price >= sqlc.narg('price_from')
-	//     )
-	//     AND (
-	//         sqlc.narg('price_to')::float IS NULL
-	//         OR p.price <= sqlc.narg('price_to')
-	//     )
-	//     AND (
-	//         sqlc.narg('category_ids')::int[] IS NULL
-	//         OR p.category_id = ANY(sqlc.narg('category_ids')::int[])
-	//     )
-	// ORDER BY p.created_at DESC
-	// LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-	// -- name: ListCountProducts :one
-	// SELECT COUNT(*) AS total_products
-	// FROM products
-	// WHERE
-	//     deleted_at IS NULL
-	//     AND (
-	//         COALESCE(sqlc.narg('search'), '') = ''
-	//         OR LOWER(name) LIKE sqlc.narg('search')
-	//         OR LOWER(description) LIKE sqlc.narg('search')
-	//     )
-	//     AND (
-	//         sqlc.narg('price_from')::float IS NULL
-	//         OR price >= sqlc.narg('price_from')
-	//     )
-	//     AND (
-	//         sqlc.narg('price_to')::float IS NULL
-	//         OR price <= sqlc.narg('price_to')
-	//     )
-	//     AND (
-	//         sqlc.narg('category_ids')::int[] IS NULL
-	//         OR category_id = ANY(sqlc.narg('category_ids')::int[])
-	//     );
-	ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error)
+	ListPriceChangesByProductID(ctx context.Context, productID int64) ([]PriceChange, error)
+	ListPriceRules(ctx context.Context, arg ListPriceRulesParams) ([]PriceRule, error)
+	// every rule that has applied or will apply to the product, ended and
+	// deleted rules included
+	ListPriceRulesForProduct(ctx context.Context, productID int64) ([]PriceRule, error)
+	ListProductOptionsByProductID(ctx context.Context, productID int64) ([]ListProductOptionsByProductIDRow, error)
+	ListProductVariantsByProductID(ctx context.Context, productID int64) ([]ListProductVariantsByProductIDRow, error)
+	ListPurchaseOrderItems(ctx context.Context, purchaseOrderID int64) ([]ListPurchaseOrderItemsRow, error)
+	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]ListPurchaseOrdersRow, error)
+	ListPurchaseOrdersCount(ctx context.Context, arg ListPurchaseOrdersCountParams) (int64, error)
+	ListRecipeComponents(ctx context.Context, arg ListRecipeComponentsParams) ([]ListRecipeComponentsRow, error)
+	ListStockBatches(ctx context.Context, arg ListStockBatchesParams) ([]StockBatch, error)
+	ListStockBatchesCount(ctx context.Context, arg ListStockBatchesCountParams) (int64, error)
+	// products and variants with stock of their own whose stock_quantity is not
+	// the sum of their stock movements
+	ListStockDiscrepancies(ctx context.Context) ([]ListStockDiscrepanciesRow, error)
+	ListStockMovements(ctx context.Context, arg ListStockMovementsParams) ([]StockMovement, error)
+	ListStockMovementsCount(ctx context.Context, arg ListStockMovementsCountParams) (int64, error)
 	ListSubscriptionDelivery(ctx context.Context, arg ListSubscriptionDeliveryParams) ([]SubscriptionDelivery, error)
-	ListSubscriptions(ctx context.Context, arg ListSubscriptionsParams) ([]ListSubscriptionsRow, error)
+	// the price per delivery of the active subscriptions running at at, per
+	// delivery frequency.
+	ListSubscriptionPrices(ctx context.Context, at time.Time) ([]ListSubscriptionPricesRow, error)
 	ListSubscriptionsCount(ctx context.Context, arg ListSubscriptionsCountParams) (int64, error)
+	ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([]Supplier, error)
+	ListSuppliersCount(ctx context.Context, search interface{}) (int64, error)
+	ListTagGroups(ctx context.Context) ([]TagGroup, error)
+	ListTagsByProductID(ctx context.Context, productID int64) ([]ListTagsByProductIDRow, error)
+	ListTagsWithProductCount(ctx context.Context, tagGroupID pgtype.Int8) ([]ListTagsWithProductCountRow, error)
+	ListTaxClasses(ctx context.Context) ([]TaxClass, error)
+	ListTaxRatesByClassID(ctx context.Context, taxClassID int64) ([]TaxRate, error)
 	ListUserSubscriptions(ctx context.Context, arg ListUserSubscriptionsParams) ([]ListUserSubscriptionsRow, error)
-	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
 	ListUsersCount(ctx context.Context, arg ListUsersCountParams) (int64, error)
+	// locks the products, in id order so that concurrent recipe changes wait on
+	// each other instead of deadlocking
+	LockRecipeProducts(ctx context.Context, ids []int64) ([]int64, error)
+	// draws the next invoice number, the counter row stays locked until the
+	// invoice's transaction ends
+	NextInvoiceNumber(ctx context.Context) (int64, error)
 	OrderExists(ctx context.Context, id int64) (bool, error)
 	ProductExists(ctx context.Context, id int64) (bool, error)
+	ProductHasRecipe(ctx context.Context, productID int64) (bool, error)
+	ReceivePurchaseOrderItem(ctx context.Context, arg ReceivePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	// repairs the product counts that drifted from the products table
+	RecountCategoryProducts(ctx context.Context) (int64, error)
+	// puts stock an order allocated from a batch back into it
+	ReleaseStockBatch(ctx context.Context, arg ReleaseStockBatchParams) error
+	// stock back above its threshold, or no longer tracked, alerts again when it
+	// next runs low
+	ResolveLowStockAlerts(ctx context.Context) (int64, error)
+	// only products whose category is still live can be restored
+	RestoreProduct(ctx context.Context, id int64) (int64, error)
 	SubscriptionExists(ctx context.Context, id int64) (bool, error)
-	TotalOrders(ctx context.Context) (interface{}, error)
-	TotalProducts(ctx context.Context) (interface{}, error)
-	TotalRevenue(ctx context.Context) (interface{}, error)
+	TagGroupExists(ctx context.Context, id int64) (bool, error)
+	TaxClassExists(ctx context.Context, id int64) (bool, error)
+	TotalOrders(ctx context.Context) (int64, error)
+	TotalProducts(ctx context.Context) (int64, error)
+	TotalRevenue(ctx context.Context) ([]TotalRevenueRow, error)
+	// a parent_id of 0 moves the category to the top level
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
 	UpdateOrder(ctx context.Context, arg UpdateOrderParams) (int64, error)
 	UpdatePayment(ctx context.Context, arg UpdatePaymentParams) (int64, error)
 	UpdatePaystackPaymentStatus(ctx context.Context, arg UpdatePaystackPaymentStatusParams) error
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
-	UpdateProductStem(ctx context.Context, arg UpdateProductStemParams) (ProductStem, error)
+	UpdateProductVariantStock(ctx context.Context, arg UpdateProductVariantStockParams) error
+	UpdatePurchaseOrder(ctx context.Context, arg UpdatePurchaseOrderParams) (PurchaseOrder, error)
 	UpdateSubscription(ctx context.Context, arg UpdateSubscriptionParams) (int64, error)
 	UpdateSubscriptionDelivery(ctx context.Context, arg UpdateSubscriptionDeliveryParams) (SubscriptionDelivery, error)
+	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error)
+	UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error)
+	UpdateTagGroup(ctx context.Context, arg UpdateTagGroupParams) (TagGroup, error)
+	UpdateTaxClass(ctx context.Context, arg UpdateTaxClassParams) (TaxClass, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserSubscription(ctx context.Context, arg UpdateUserSubscriptionParams) (int64, error)
+	UpsertProductOptionType(ctx context.Context, arg UpsertProductOptionTypeParams) (int64, error)
+	UpsertProductOptionValue(ctx context.Context, arg UpsertProductOptionValueParams) (int64, error)
+	UpsertProductVariant(ctx context.Context, arg UpsertProductVariantParams) (int64, error)
 	UserExists(ctx context.Context, id int64) (bool, error)
 	UserSubscriptionExists(ctx context.Context, id int64) (bool, error)
+	WriteOffExpiredStockBatches(ctx context.Context, at pgtype.Timestamptz) ([]StockBatch, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -13,8 +13,8 @@ import (
 )
 
 const createSubscription = `-- name: CreateSubscription :one
-INSERT INTO subscriptions (name, description, product_ids, add_ons, price, stem_ids, by_admin, parent_order_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO subscriptions (name, description, product_ids, add_ons, price, currency, variant_ids, by_admin, parent_order_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING id
 `
 
@@ -24,7 +24,8 @@ type CreateSubscriptionParams struct {
 	ProductIds    []int32        `json:"product_ids"`
 	AddOns        []int32        `json:"add_ons"`
 	Price         pgtype.Numeric `json:"price"`
-	StemIds       []int32        `json:"stem_ids"`
+	Currency      string         `json:"currency"`
+	VariantIds    []int32        `json:"variant_ids"`
 	ByAdmin       bool           `json:"by_admin"`
 	ParentOrderID pgtype.Int8    `json:"parent_order_id"`
 }
@@ -36,7 +37,8 @@ func (q *Queries) CreateSubscription(ctx context.Context, arg CreateSubscription
 		arg.ProductIds,
 		arg.AddOns,
 		arg.Price,
-		arg.StemIds,
+		arg.Currency,
+		arg.VariantIds,
 		arg.ByAdmin,
 		arg.ParentOrderID,
 	)
@@ -58,7 +60,7 @@ func (q *Queries) DeleteSubscription(ctx context.Context, id int64) error {
 
 const getSubscriptionByID = `-- name: GetSubscriptionByID :one
 SELECT 
-  s.id, s.name, s.description, s.product_ids, s.add_ons, s.price, s.deleted_at, s.created_at, s.by_admin, s.stem_ids, s.parent_order_id,
+  s.id, s.name, s.description, s.product_ids, s.add_ons, s.price, s.deleted_at, s.created_at, s.by_admin, s.variant_ids, s.parent_order_id, s.currency,
   COALESCE(p1.products_json, '[]') AS products_data,
   COALESCE(p2.add_ons_json, '[]') AS add_ons_data
 FROM subscriptions s
@@ -85,8 +87,9 @@ type GetSubscriptionByIDRow struct {
 	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt     time.Time          `json:"created_at"`
 	ByAdmin       bool               `json:"by_admin"`
-	StemIds       []int32            `json:"stem_ids"`
+	VariantIds    []int32            `json:"variant_ids"`
 	ParentOrderID pgtype.Int8        `json:"parent_order_id"`
+	Currency      string             `json:"currency"`
 	ProductsData  []byte             `json:"products_data"`
 	AddOnsData    []byte             `json:"add_ons_data"`
 }
@@ -104,127 +107,15 @@ func (q *Queries) GetSubscriptionByID(ctx context.Context, id int64) (GetSubscri
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.ByAdmin,
-		&i.StemIds,
+		&i.VariantIds,
 		&i.ParentOrderID,
+		&i.Currency,
 		&i.ProductsData,
 		&i.AddOnsData,
 	)
 	return i, err
 }
 
-const listSubscriptions = `-- name: ListSubscriptions :many
-SELECT 
-  s.id, s.name, s.description, s.product_ids, s.add_ons, s.price, s.deleted_at, s.created_at, s.by_admin, s.stem_ids, s.parent_order_id,
-  COALESCE(p.products, '[]') AS products_data,
-  COALESCE(a.add_ons, '[]') AS add_ons_data
-FROM subscriptions s
-LEFT JOIN LATERAL (
-  SELECT json_agg(json_build_object(
-    'id', p.id,
-    'name', p.name,
-    'price', p.price
-  )) AS products
-  FROM products p
-  WHERE p.id = ANY(s.product_ids)
-) p ON true
-LEFT JOIN LATERAL (
-  SELECT json_agg(json_build_object(
-    'id', p.id,
-    'name', p.name,
-    'price', p.price
-  )) AS add_ons
-  FROM products p
-  WHERE p.id = ANY(s.add_ons)
-) a ON true
-WHERE 
-    s.deleted_at IS NULL
-    AND (
-        COALESCE($1, '') = '' 
-        OR LOWER(s.name) LIKE $1
-        OR LOWER(s.description) LIKE $1
-    )
-    AND (
-        $2::boolean IS NULL 
-        OR s.by_admin = $2
-    )
-    AND (
-        $3::float IS NULL 
-        OR s.price >= $3
-    )
-    AND (
-        $4::float IS NULL 
-        OR s.price <= $4
-    )
-ORDER BY s.created_at DESC
-LIMIT $6 OFFSET $5
-`
-
-type ListSubscriptionsParams struct {
-	Search    interface{}   `json:"search"`
-	ByAdmin   pgtype.Bool   `json:"by_admin"`
-	PriceFrom pgtype.Float8 `json:"price_from"`
-	PriceTo   pgtype.Float8 `json:"price_to"`
-	Offset    int32         `json:"offset"`
-	Limit     int32         `json:"limit"`
-}
-
-type ListSubscriptionsRow struct {
-	ID            int64              `json:"id"`
-	Name          string             `json:"name"`
-	Description   string             `json:"description"`
-	ProductIds    []int32            `json:"product_ids"`
-	AddOns        []int32            `json:"add_ons"`
-	Price         pgtype.Numeric     `json:"price"`
-	DeletedAt     pgtype.Timestamptz `json:"deleted_at"`
-	CreatedAt     time.Time          `json:"created_at"`
-	ByAdmin       bool               `json:"by_admin"`
-	StemIds       []int32            `json:"stem_ids"`
-	ParentOrderID pgtype.Int8        `json:"parent_order_id"`
-	ProductsData  []byte             `json:"products_data"`
-	AddOnsData    []byte             `json:"add_ons_data"`
-}
-
-func (q *Queries) ListSubscriptions(ctx context.Context, arg ListSubscriptionsParams) ([]ListSubscriptionsRow, error) {
-	rows, err := q.db.Query(ctx, listSubscriptions,
-		arg.Search,
-		arg.ByAdmin,
-		arg.PriceFrom,
-		arg.PriceTo,
-		arg.Offset,
-		arg.Limit,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []ListSubscriptionsRow{}
-	for rows.Next() {
-		var i ListSubscriptionsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.ProductIds,
-			&i.AddOns,
-			&i.Price,
-			&i.DeletedAt,
-			&i.CreatedAt,
-			&i.ByAdmin,
-			&i.StemIds,
-			&i.ParentOrderID,
-			&i.ProductsData,
-			&i.AddOnsData,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
 const listSubscriptionsCount = `-- name: ListSubscriptionsCount :one
 SELECT COUNT(*) AS total_subscriptions
 FROM subscriptions
@@ -284,7 +175,7 @@ UPDATE subscriptions
 SET name = coalesce($1, name),
     description = coalesce($2, description),
     product_ids = coalesce($3, product_ids),
-    stem_ids = coalesce($4, stem_ids), 
+    variant_ids = coalesce($4, variant_ids), 
     add_ons = coalesce($5, add_ons),
     price = coalesce($6, price)
 WHERE id = $7
@@ -295,7 +186,7 @@ type UpdateSubscriptionParams struct {
 	Name        pgtype.Text    `json:"name"`
 	Description pgtype.Text    `json:"description"`
 	ProductIds  []int32        `json:"product_ids"`
-	StemIds     []int32        `json:"stem_ids"`
+	VariantIds  []int32        `json:"variant_ids"`
 	AddOns      []int32        `json:"add_ons"`
 	Price       pgtype.Numeric `json:"price"`
 	ID          int64          `json:"id"`
@@ -306,7 +197,7 @@ func (q *Queries) UpdateSubscription(ctx context.Context, arg UpdateSubscription
 		arg.Name,
 		arg.Description,
 		arg.ProductIds,
-		arg.StemIds,
+		arg.VariantIds,
 		arg.AddOns,
 		arg.Price,
 		arg.ID,
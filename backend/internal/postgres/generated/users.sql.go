@@ -97,43 +97,14 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 	return i, err
 }
 
-const listUsers = `-- name: ListUsers :many
+const listActiveAdmins = `-- name: ListActiveAdmins :many
 SELECT id, name, email, address, phone_number, refresh_token, password, is_admin, is_active, created_at FROM users
-WHERE 
-    (
-        COALESCE($1, '') = '' 
-        OR LOWER(name) LIKE $1
-        OR LOWER(email) LIKE $1
-        OR LOWER(phone_number) LIKE $1
-    )
-    AND (
-        $2::boolean IS NULL 
-        OR is_active = $2
-    )
-    AND (
-        $3::boolean IS NULL 
-        OR is_admin = $3
-    )
-ORDER BY created_at DESC
-LIMIT $5 OFFSET $4
+WHERE is_admin = TRUE AND is_active = TRUE
+ORDER BY id
 `
 
-type ListUsersParams struct {
-	Search   interface{} `json:"search"`
-	IsActive pgtype.Bool `json:"is_active"`
-	IsAdmin  pgtype.Bool `json:"is_admin"`
-	Offset   int32       `json:"offset"`
-	Limit    int32       `json:"limit"`
-}
-
-func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsers,
-		arg.Search,
-		arg.IsActive,
-		arg.IsAdmin,
-		arg.Offset,
-		arg.Limit,
-	)
+func (q *Queries) ListActiveAdmins(ctx context.Context) ([]User, error) {
+	rows, err := q.db.Query(ctx, listActiveAdmins)
 	if err != nil {
 		return nil, err
 	}
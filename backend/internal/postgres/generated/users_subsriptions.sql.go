@@ -13,15 +13,15 @@ import (
 )
 
 const activeSubscriptions = `-- name: ActiveSubscriptions :one
-SELECT COALESCE(COUNT(*), 0) AS active_subscriptions
+SELECT COUNT(*) AS active_subscriptions
 FROM user_subscriptions
 WHERE status = true
   AND deleted_at IS NULL
 `
 
-func (q *Queries) ActiveSubscriptions(ctx context.Context) (interface{}, error) {
+func (q *Queries) ActiveSubscriptions(ctx context.Context) (int64, error) {
 	row := q.db.QueryRow(ctx, activeSubscriptions)
-	var active_subscriptions interface{}
+	var active_subscriptions int64
 	err := row.Scan(&active_subscriptions)
 	return active_subscriptions, err
 }
@@ -81,7 +81,7 @@ func (q *Queries) GetCountUserSubscriptionsByUserID(ctx context.Context, userID
 
 const getUserSubscriptionByID = `-- name: GetUserSubscriptionByID :one
 SELECT 
-    us.id, us.user_id, us.subscription_id, us.day_of_week, us.status, us.start_date, us.end_date, us.deleted_at, us.created_at, us.frequency,
+    us.id, us.user_id, us.subscription_id, us.day_of_week, us.status, us.start_date, us.end_date, us.deleted_at, us.created_at, us.frequency, us.cancelled_at,
     COALESCE(p1.user_json, '{}') AS user_data,
     COALESCE(p2.subscription_json, '{}') AS subscription_data,
     COALESCE(p3.payment_json, '[]') AS payment_data
@@ -104,6 +104,7 @@ LEFT JOIN LATERAL (
         'id', s.id,
         'name', s.name,
         'price', s.price,
+        'currency', s.currency,
         'description', s.description,
         'product_ids', s.product_ids,
         'add_ons', s.add_ons,
@@ -132,6 +133,7 @@ type GetUserSubscriptionByIDRow struct {
 	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt        time.Time          `json:"created_at"`
 	Frequency        string             `json:"frequency"`
+	CancelledAt      pgtype.Timestamptz `json:"cancelled_at"`
 	UserData         []byte             `json:"user_data"`
 	SubscriptionData []byte             `json:"subscription_data"`
 	PaymentData      []byte             `json:"payment_data"`
@@ -151,6 +153,7 @@ func (q *Queries) GetUserSubscriptionByID(ctx context.Context, id int64) (GetUse
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.Frequency,
+		&i.CancelledAt,
 		&i.UserData,
 		&i.SubscriptionData,
 		&i.PaymentData,
@@ -160,7 +163,7 @@ func (q *Queries) GetUserSubscriptionByID(ctx context.Context, id int64) (GetUse
 
 const getUserSubscriptionsByUserID = `-- name: GetUserSubscriptionsByUserID :many
 SELECT 
-    us.id, us.user_id, us.subscription_id, us.day_of_week, us.status, us.start_date, us.end_date, us.deleted_at, us.created_at, us.frequency,
+    us.id, us.user_id, us.subscription_id, us.day_of_week, us.status, us.start_date, us.end_date, us.deleted_at, us.created_at, us.frequency, us.cancelled_at,
     COALESCE(p1.subscription_json, '{}') AS subscription_data
 FROM user_subscriptions us
 LEFT JOIN LATERAL (
@@ -168,6 +171,7 @@ LEFT JOIN LATERAL (
         'id', s.id,
         'name', s.name,
         'price', s.price,
+        'currency', s.currency,
         'description', s.description,
         'product_ids', s.product_ids,
         'add_ons', s.add_ons,
@@ -198,6 +202,7 @@ type GetUserSubscriptionsByUserIDRow struct {
 	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt        time.Time          `json:"created_at"`
 	Frequency        string             `json:"frequency"`
+	CancelledAt      pgtype.Timestamptz `json:"cancelled_at"`
 	SubscriptionData []byte             `json:"subscription_data"`
 }
 
@@ -221,6 +226,7 @@ func (q *Queries) GetUserSubscriptionsByUserID(ctx context.Context, arg GetUserS
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.Frequency,
+			&i.CancelledAt,
 			&i.SubscriptionData,
 		); err != nil {
 			return nil, err
@@ -253,7 +259,7 @@ func (q *Queries) ListCountUserSubscriptions(ctx context.Context, status pgtype.
 
 const listUserSubscriptions = `-- name: ListUserSubscriptions :many
 SELECT 
-    us.id, us.user_id, us.subscription_id, us.day_of_week, us.status, us.start_date, us.end_date, us.deleted_at, us.created_at, us.frequency,
+    us.id, us.user_id, us.subscription_id, us.day_of_week, us.status, us.start_date, us.end_date, us.deleted_at, us.created_at, us.frequency, us.cancelled_at,
     COALESCE(p1.user_json, '{}') AS user_data,
     COALESCE(p2.subscription_json, '{}') AS subscription_data
 FROM user_subscriptions us
@@ -275,6 +281,7 @@ LEFT JOIN LATERAL (
         'id', s.id,
         'name', s.name,
         'price', s.price,
+        'currency', s.currency,
         'description', s.description,
         'product_ids', s.product_ids,
         'add_ons', s.add_ons,
@@ -310,6 +317,7 @@ type ListUserSubscriptionsRow struct {
 	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt        time.Time          `json:"created_at"`
 	Frequency        string             `json:"frequency"`
+	CancelledAt      pgtype.Timestamptz `json:"cancelled_at"`
 	UserData         []byte             `json:"user_data"`
 	SubscriptionData []byte             `json:"subscription_data"`
 }
@@ -334,6 +342,7 @@ func (q *Queries) ListUserSubscriptions(ctx context.Context, arg ListUserSubscri
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.Frequency,
+			&i.CancelledAt,
 			&i.UserData,
 			&i.SubscriptionData,
 		); err != nil {
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.ImageRepository = (*ImageRepository)(nil)
+
+type ImageRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewImageRepository(db *Store) *ImageRepository {
+	return &ImageRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+func (ir *ImageRepository) AddImages(ctx context.Context, ownerType string, ownerID uint32, images []*repository.Image) ([]*repository.Image, error) {
+	switch ownerType {
+	case repository.ImageOwnerProduct:
+		if exists, _ := ir.queries.ProductExists(ctx, int64(ownerID)); !exists {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with id %d not found", ownerID)
+		}
+	case repository.ImageOwnerCategory:
+		if exists, _ := ir.queries.CategoryExists(ctx, int64(ownerID)); !exists {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "category with id %d not found", ownerID)
+		}
+	default:
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid image owner type %s", ownerType)
+	}
+
+	newImages := make([]*repository.Image, len(images))
+	err := ir.db.ExecTx(ctx, func(q *generated.Queries) error {
+		urls := make([]string, len(images))
+		for i, image := range images {
+			generatedImage, err := q.CreateImage(ctx, generated.CreateImageParams{
+				OwnerType:    ownerType,
+				OwnerID:      int64(ownerID),
+				Key:          image.Key,
+				Url:          image.URL,
+				ThumbnailKey: image.ThumbnailKey,
+				ThumbnailUrl: image.ThumbnailURL,
+				ContentType:  image.ContentType,
+				Size:         image.Size,
+				Width:        image.Width,
+				Height:       image.Height,
+			})
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating image: %s", err.Error())
+			}
+			newImages[i] = generatedImageToRepo(generatedImage)
+			urls[i] = image.URL
+		}
+
+		if ownerType == repository.ImageOwnerProduct {
+			if err := q.AddProductImageUrls(ctx, generated.AddProductImageUrlsParams{ImageUrls: urls, ID: int64(ownerID)}); err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error adding product image urls: %s", err.Error())
+			}
+		} else {
+			if err := q.AddCategoryImageUrls(ctx, generated.AddCategoryImageUrlsParams{ImageUrls: urls, ID: int64(ownerID)}); err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error adding category image urls: %s", err.Error())
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newImages, nil
+}
+
+func (ir *ImageRepository) ListImages(ctx context.Context, ownerType string, ownerID uint32) ([]*repository.Image, error) {
+	generatedImages, err := ir.queries.ListImagesByOwner(ctx, generated.ListImagesByOwnerParams{
+		OwnerType: ownerType,
+		OwnerID:   int64(ownerID),
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing images: %s", err.Error())
+	}
+
+	images := make([]*repository.Image, len(generatedImages))
+	for i, image := range generatedImages {
+		images[i] = generatedImageToRepo(image)
+	}
+
+	return images, nil
+}
+
+func (ir *ImageRepository) ListOrphanImages(ctx context.Context, ownerType string, ownerID uint32) ([]*repository.Image, error) {
+	params := generated.ListOrphanImagesParams{
+		OwnerType: pgtype.Text{Valid: false},
+		OwnerID:   pgtype.Int8{Valid: false},
+	}
+
+	if ownerType != "" {
+		params.OwnerType = pgtype.Text{Valid: true, String: ownerType}
+		params.OwnerID = pgtype.Int8{Valid: true, Int64: int64(ownerID)}
+	}
+
+	generatedImages, err := ir.queries.ListOrphanImages(ctx, params)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing orphan images: %s", err.Error())
+	}
+
+	images := make([]*repository.Image, len(generatedImages))
+	for i, image := range generatedImages {
+		images[i] = generatedImageToRepo(image)
+	}
+
+	return images, nil
+}
+
+func (ir *ImageRepository) DeleteImage(ctx context.Context, id int64) error {
+	if err := ir.queries.DeleteImage(ctx, id); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting image by id: %s", err.Error())
+	}
+
+	return nil
+}
+
+func generatedImageToRepo(generatedImage generated.Image) *repository.Image {
+	return &repository.Image{
+		ID:           uint32(generatedImage.ID),
+		OwnerType:    generatedImage.OwnerType,
+		OwnerID:      uint32(generatedImage.OwnerID),
+		Key:          generatedImage.Key,
+		URL:          generatedImage.Url,
+		ThumbnailKey: generatedImage.ThumbnailKey,
+		ThumbnailURL: generatedImage.ThumbnailUrl,
+		ContentType:  generatedImage.ContentType,
+		Size:         generatedImage.Size,
+		Width:        generatedImage.Width,
+		Height:       generatedImage.Height,
+		CreatedAt:    generatedImage.CreatedAt,
+	}
+}
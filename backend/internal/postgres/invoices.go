@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.InvoiceRepository = (*InvoiceRepository)(nil)
+
+type InvoiceRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewInvoiceRepository(db *Store) *InvoiceRepository {
+	return &InvoiceRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+func (ir *InvoiceRepository) GetOrCreateOrderInvoice(ctx context.Context, orderID int64) (*repository.Invoice, error) {
+	if exists, _ := ir.queries.OrderExists(ctx, orderID); !exists {
+		return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "order with id %d not found", orderID)
+	}
+
+	id := pgtype.Int8{Valid: true, Int64: orderID}
+
+	return ir.getOrCreateInvoice(ctx,
+		func() (generated.Invoice, error) { return ir.queries.GetInvoiceByOrderID(ctx, id) },
+		func(q *generated.Queries, number int64) (generated.Invoice, error) {
+			return q.CreateOrderInvoice(ctx, generated.CreateOrderInvoiceParams{OrderID: id, InvoiceNumber: number})
+		},
+	)
+}
+
+func (ir *InvoiceRepository) GetOrCreatePaymentInvoice(ctx context.Context, paymentID int64) (*repository.Invoice, error) {
+	if _, err := ir.queries.GetPaymentByID(ctx, paymentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "payment with id %d not found", paymentID)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching payment by id: %s", err.Error())
+	}
+
+	id := pgtype.Int8{Valid: true, Int64: paymentID}
+
+	return ir.getOrCreateInvoice(ctx,
+		func() (generated.Invoice, error) { return ir.queries.GetInvoiceByPaymentID(ctx, id) },
+		func(q *generated.Queries, number int64) (generated.Invoice, error) {
+			return q.CreatePaymentInvoice(ctx, generated.CreatePaymentInvoiceParams{PaymentID: id, InvoiceNumber: number})
+		},
+	)
+}
+
+// getOrCreateInvoice only draws a new invoice number when none has been issued
+// yet. The number is drawn in the transaction that inserts the invoice, so a
+// failed insert gives it back and the numbers have no gaps. A concurrent
+// request that wins the insert is picked up by re-reading.
+func (ir *InvoiceRepository) getOrCreateInvoice(ctx context.Context, get func() (generated.Invoice, error), create func(q *generated.Queries, number int64) (generated.Invoice, error)) (*repository.Invoice, error) {
+	invoice, err := get()
+	if err == nil {
+		return generatedInvoiceToRepo(invoice), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching invoice: %s", err.Error())
+	}
+
+	var raced bool
+	err = ir.db.ExecTx(ctx, func(q *generated.Queries) error {
+		number, err := q.NextInvoiceNumber(ctx)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error drawing invoice number: %s", err.Error())
+		}
+
+		invoice, err = create(q, number)
+		raced = pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION
+
+		return err
+	})
+	if err != nil {
+		if raced {
+			if invoice, err = get(); err == nil {
+				return generatedInvoiceToRepo(invoice), nil
+			}
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating invoice: %s", err.Error())
+	}
+
+	return generatedInvoiceToRepo(invoice), nil
+}
+
+func generatedInvoiceToRepo(invoice generated.Invoice) *repository.Invoice {
+	rslt := &repository.Invoice{
+		ID:        uint32(invoice.ID),
+		Number:    fmt.Sprintf("INV-%06d", invoice.InvoiceNumber),
+		OrderID:   nil,
+		PaymentID: nil,
+		CreatedAt: invoice.CreatedAt,
+	}
+
+	if invoice.OrderID.Valid {
+		orderID := uint32(invoice.OrderID.Int64)
+		rslt.OrderID = &orderID
+	}
+
+	if invoice.PaymentID.Valid {
+		paymentID := uint32(invoice.PaymentID.Int64)
+		rslt.PaymentID = &paymentID
+	}
+
+	return rslt
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+func createTestOrder(t *testing.T, store *Store) int64 {
+	t.Helper()
+
+	total := pkg.NewMoney(1000, pkg.KES).Numeric()
+	id, err := generated.New(store.pool).CreateOrder(context.Background(), generated.CreateOrderParams{
+		UserName:             "Test Buyer",
+		UserPhoneNumber:      "+254700000000",
+		TotalAmount:          total,
+		PaymentStatus:        true,
+		Status:               "pending",
+		DeliveryDate:         time.Now(),
+		TimeSlot:             "morning",
+		Currency:             string(pkg.KES),
+		NetAmount:            total,
+		TaxAmount:            pkg.NewMoney(0, pkg.KES).Numeric(),
+		RecipientName:        "Test Recipient",
+		RecipientPhoneNumber: "+254700000001",
+		AddressArea:          "Test Area",
+	})
+	if err != nil {
+		t.Fatalf("creating test order: %v", err)
+	}
+
+	return id
+}
+
+func invoiceNumber(t *testing.T, number string) int64 {
+	t.Helper()
+
+	var n int64
+	if _, err := fmt.Sscanf(number, "INV-%d", &n); err != nil {
+		t.Fatalf("invalid invoice number %q: %v", number, err)
+	}
+
+	return n
+}
+
+func TestGetOrCreateOrderInvoiceNumbersWithoutGaps(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+	invoices := NewInvoiceRepository(store)
+
+	first, err := invoices.GetOrCreateOrderInvoice(ctx, createTestOrder(t, store))
+	if err != nil {
+		t.Fatalf("GetOrCreateOrderInvoice: %v", err)
+	}
+
+	// a missing order draws no number
+	if _, err := invoices.GetOrCreateOrderInvoice(ctx, 0); pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+		t.Errorf("GetOrCreateOrderInvoice of a missing order error = %v, want a %s error", err, pkg.NOT_FOUND_ERROR)
+	}
+
+	// concurrent requests for one order get the one invoice
+	orderID := createTestOrder(t, store)
+	var wg sync.WaitGroup
+	numbers := make([]string, 5)
+	for i := range numbers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			invoice, err := invoices.GetOrCreateOrderInvoice(ctx, orderID)
+			if err != nil {
+				t.Errorf("GetOrCreateOrderInvoice: %v", err)
+				return
+			}
+			numbers[i] = invoice.Number
+		}(i)
+	}
+	wg.Wait()
+
+	for _, number := range numbers {
+		if number != numbers[0] {
+			t.Fatalf("concurrent invoices of order %d = %v, want one number", orderID, numbers)
+		}
+	}
+	if got, want := invoiceNumber(t, numbers[0]), invoiceNumber(t, first.Number)+1; got != want {
+		t.Errorf("invoice number = %d, want %d", got, want)
+	}
+
+	again, err := invoices.GetOrCreateOrderInvoice(ctx, orderID)
+	if err != nil {
+		t.Fatalf("GetOrCreateOrderInvoice: %v", err)
+	}
+	if again.Number != numbers[0] {
+		t.Errorf("invoice number = %s on a second request, want %s", again.Number, numbers[0])
+	}
+}
@@ -0,0 +1,373 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The list statements below are not sqlc queries: their ORDER BY and keyset
+// follow the sort a client asks for, which sqlc can't express. listPage.sql
+// completes them, adding only the whitelisted columns of the list's keyset
+// and passing every value as a parameter, and listRows scans the rows into
+// the matching row type, whose fields follow the columns in order.
+
+// listQuery is a list statement without its sort keys, keyset, order and
+// limits. from runs through the WHERE clause of its filters, which the keyset
+// is ANDed to.
+type listQuery struct {
+	columns string
+	from    string
+}
+
+// orderList lists the orders that aren't deleted. $1 is a LIKE pattern matched
+// against the buyer, recipient and address, $2 the payment status, $3 a LIKE
+// pattern of the status and $4 and $5 the range of creation times.
+var orderList = listQuery{
+	columns: `orders.id, orders.user_name, orders.user_phone_number, orders.total_amount,
+    orders.payment_status, orders.status, orders.deleted_at, orders.created_at,
+    orders.delivery_date, orders.time_slot, orders.by_admin, orders.currency,
+    orders.net_amount, orders.tax_amount, orders.payment_reference, orders.buyer_email,
+    orders.recipient_name, orders.recipient_phone_number, orders.address_area,
+    orders.address_street, orders.address_building, orders.address_landmark,
+    orders.address_latitude, orders.address_longitude`,
+	from: `FROM orders
+WHERE
+    deleted_at IS NULL
+    AND (
+        COALESCE($1, '') = ''
+        OR LOWER(user_name) LIKE $1
+        OR LOWER(user_phone_number) LIKE $1
+        OR LOWER(buyer_email) LIKE $1
+        OR LOWER(recipient_name) LIKE $1
+        OR LOWER(recipient_phone_number) LIKE $1
+        OR LOWER(address_area) LIKE $1
+        OR LOWER(address_street) LIKE $1
+    )
+    AND (
+        $2::boolean IS NULL
+        OR payment_status = $2
+    )
+    AND (
+        COALESCE($3, '') = ''
+        OR LOWER(status) LIKE $3
+    )
+    AND (
+        $4::timestamptz IS NULL
+        OR created_at >= $4
+    )
+    AND (
+        $5::timestamptz IS NULL
+        OR created_at < $5
+    )`,
+}
+
+type orderListRow struct {
+	ID                   int64
+	UserName             string
+	UserPhoneNumber      string
+	TotalAmount          pgtype.Numeric
+	PaymentStatus        bool
+	Status               string
+	DeletedAt            pgtype.Timestamptz
+	CreatedAt            time.Time
+	DeliveryDate         time.Time
+	TimeSlot             string
+	ByAdmin              bool
+	Currency             string
+	NetAmount            pgtype.Numeric
+	TaxAmount            pgtype.Numeric
+	PaymentReference     pgtype.Text
+	BuyerEmail           pgtype.Text
+	RecipientName        string
+	RecipientPhoneNumber string
+	AddressArea          string
+	AddressStreet        pgtype.Text
+	AddressBuilding      pgtype.Text
+	AddressLandmark      pgtype.Text
+	AddressLatitude      pgtype.Float8
+	AddressLongitude     pgtype.Float8
+	SortKeys             []string
+}
+
+// paymentList lists payments. $1 is a LIKE pattern of the payment method and
+// $2 and $3 the range of payment times.
+var paymentList = listQuery{
+	columns: `payments.id, payments.description, payments.order_id, payments.user_subscription_id,
+    payments.payment_method, payments.amount, payments.paid_at, payments.created_at,
+    payments.currency`,
+	from: `FROM payments
+WHERE
+    (
+        COALESCE($1, '') = ''
+        OR LOWER(payment_method) LIKE $1
+    )
+    AND paid_at BETWEEN $2 AND $3`,
+}
+
+type paymentListRow struct {
+	ID                 int64
+	Description        pgtype.Text
+	OrderID            pgtype.Int8
+	UserSubscriptionID pgtype.Int8
+	PaymentMethod      string
+	Amount             pgtype.Numeric
+	PaidAt             time.Time
+	CreatedAt          time.Time
+	Currency           string
+	SortKeys           []string
+}
+
+// productList lists the products that aren't deleted. $1 is the search, $2 and
+// $6 the effective price range, $3, $4 and $5 the type flags, $7 category ids
+// including their subcategories, $8 whether in stock and $9 tag ids all of
+// which a product must have.
+var productList = listQuery{
+	columns: `p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.stock_quantity,
+    p.deleted_at, p.created_at, p.has_variants, p.is_message_card, p.is_flowers, p.is_add_on,
+    p.currency, p.tax_class_id, p.reorder_threshold, p.sku, p.popularity,
+    c.id AS category_id,
+    c.name AS category_name,
+    c.description AS category_description,
+    product_price(p, NULL, p.price, now())::decimal AS effective_price,
+    product_availability(p, NULL, p.stock_quantity)::bigint AS available_quantity,
+    COALESCE((
+    SELECT json_agg(json_build_object(
+    'id', pv.id,
+    'product_id', pv.product_id,
+    'sku', pv.sku,
+    'price', product_price(p, pv.id, pv.price, now()),
+    'base_price', pv.price,
+    'stock_quantity', pv.stock_quantity,
+    'available_quantity', product_availability(p, pv.id, pv.stock_quantity),
+    'reorder_threshold', pv.reorder_threshold,
+    'image_url', pv.image_url,
+    'options', COALESCE((
+    SELECT json_agg(json_build_object(
+    'name', pot.name,
+    'value', pov.value
+    ) ORDER BY pot.position, pot.id)
+    FROM product_variant_options pvo
+    JOIN product_option_values pov ON pov.id = pvo.option_value_id
+    JOIN product_option_types pot ON pot.id = pov.option_type_id
+    WHERE pvo.variant_id = pv.id
+    ), '[]')
+    ) ORDER BY pv.id)
+    FROM product_variants pv
+    WHERE pv.product_id = p.id AND pv.deleted_at IS NULL
+    ), '[]') AS variants,
+    COALESCE((
+    SELECT json_agg(json_build_object(
+    'id', t.id,
+    'tag_group_id', t.tag_group_id,
+    'group_name', g.name,
+    'group_slug', g.slug,
+    'name', t.name,
+    'slug', t.slug,
+    'description', t.description,
+    'image_url', t.image_url,
+    'position', t.position,
+    'created_at', t.created_at
+    ) ORDER BY g.position, g.name, t.position, t.name)
+    FROM product_tags pt
+    JOIN tags t ON t.id = pt.tag_id AND t.deleted_at IS NULL
+    JOIN tag_groups g ON g.id = t.tag_group_id
+    WHERE pt.product_id = p.id
+    ), '[]') AS tags,
+    sr.search_rank,
+    ts_headline('english', p.name, websearch_to_tsquery('english', NULLIF($1::text, '')),
+    'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name_highlight,
+    ts_headline('english', p.description, websearch_to_tsquery('english', NULLIF($1::text,
+    '')), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20,
+    MinWords=5') AS description_highlight`,
+	from: `FROM products p
+LEFT JOIN categories c ON p.category_id = c.id
+LEFT JOIN product_search_documents psd ON psd.product_id = p.id
+CROSS JOIN LATERAL (
+    SELECT COALESCE(ts_rank_cd(psd.document, websearch_to_tsquery('english', NULLIF($1::text, ''))) + word_similarity($1::text, p.name), 0)::float8 AS search_rank
+) sr
+WHERE
+    p.deleted_at IS NULL
+    AND (
+        COALESCE($1::text, '') = ''
+        OR psd.document @@ websearch_to_tsquery('english', $1::text)
+        OR p.name % $1::text
+        OR $1::text <% p.name
+    )
+    AND (
+        $2::float IS NULL
+        OR product_price(p, NULL, p.price, now()) >= $2
+    )
+    AND (
+        $3::boolean IS NULL
+        OR p.is_message_card = $3
+    )
+    AND (
+        $4::boolean IS NULL
+        OR p.is_flowers = $4
+    )
+    AND (
+        $5::boolean IS NULL
+        OR p.is_add_on = $5
+    )
+    AND (
+        $6::float IS NULL
+        OR product_price(p, NULL, p.price, now()) <= $6
+    )
+    AND (
+        $7::int[] IS NULL
+        OR p.category_id = ANY(category_subtree_ids($7::bigint[]))
+    )
+    AND (
+        $8::boolean IS NULL
+        OR (
+            product_availability(p, NULL, p.stock_quantity) > 0
+            OR EXISTS (
+                SELECT 1 FROM product_variants pv
+                WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND product_availability(p, pv.id, pv.stock_quantity) > 0
+            )
+        ) = $8
+    )
+    AND (
+        $9::bigint[] IS NULL
+        OR (
+            SELECT COUNT(*) FROM product_tags pt
+            WHERE pt.product_id = p.id AND pt.tag_id = ANY($9::bigint[])
+        ) = cardinality($9::bigint[])
+    )`,
+}
+
+type productListRow struct {
+	ID                   int64
+	Name                 string
+	Description          string
+	Price                pgtype.Numeric
+	CategoryID           int64
+	ImageUrl             []string
+	StockQuantity        int64
+	DeletedAt            pgtype.Timestamptz
+	CreatedAt            time.Time
+	HasVariants          bool
+	IsMessageCard        bool
+	IsFlowers            bool
+	IsAddOn              bool
+	Currency             string
+	TaxClassID           pgtype.Int8
+	ReorderThreshold     pgtype.Int8
+	Sku                  pgtype.Text
+	Popularity           int64
+	CategoryID_2         pgtype.Int8
+	CategoryName         pgtype.Text
+	CategoryDescription  pgtype.Text
+	EffectivePrice       pgtype.Numeric
+	AvailableQuantity    int64
+	Variants             interface{}
+	Tags                 interface{}
+	SearchRank           float64
+	NameHighlight        pgtype.Text
+	DescriptionHighlight pgtype.Text
+	SortKeys             []string
+}
+
+// subscriptionList lists the subscriptions that aren't deleted. $1 is a LIKE
+// pattern of the name and description, $2 whether created by an admin and $3
+// and $4 the price range.
+var subscriptionList = listQuery{
+	columns: `s.id, s.name, s.description, s.product_ids, s.add_ons, s.price, s.deleted_at, s.created_at,
+    s.by_admin, s.variant_ids, s.parent_order_id, s.currency,
+    COALESCE(p.products, '[]') AS products_data,
+    COALESCE(a.add_ons, '[]') AS add_ons_data`,
+	from: `FROM subscriptions s
+LEFT JOIN LATERAL (
+  SELECT json_agg(json_build_object(
+    'id', p.id,
+    'name', p.name,
+    'price', p.price
+  )) AS products
+  FROM products p
+  WHERE p.id = ANY(s.product_ids)
+) p ON true
+LEFT JOIN LATERAL (
+  SELECT json_agg(json_build_object(
+    'id', p.id,
+    'name', p.name,
+    'price', p.price
+  )) AS add_ons
+  FROM products p
+  WHERE p.id = ANY(s.add_ons)
+) a ON true
+WHERE
+    s.deleted_at IS NULL
+    AND (
+        COALESCE($1, '') = ''
+        OR LOWER(s.name) LIKE $1
+        OR LOWER(s.description) LIKE $1
+    )
+    AND (
+        $2::boolean IS NULL
+        OR s.by_admin = $2
+    )
+    AND (
+        $3::float IS NULL
+        OR s.price >= $3
+    )
+    AND (
+        $4::float IS NULL
+        OR s.price <= $4
+    )`,
+}
+
+type subscriptionListRow struct {
+	ID            int64
+	Name          string
+	Description   string
+	ProductIds    []int32
+	AddOns        []int32
+	Price         pgtype.Numeric
+	DeletedAt     pgtype.Timestamptz
+	CreatedAt     time.Time
+	ByAdmin       bool
+	VariantIds    []int32
+	ParentOrderID pgtype.Int8
+	Currency      string
+	ProductsData  []byte
+	AddOnsData    []byte
+	SortKeys      []string
+}
+
+// userList lists users. $1 is a LIKE pattern of the name, email and phone
+// number, $2 whether active and $3 whether an admin.
+var userList = listQuery{
+	columns: `users.id, users.name, users.email, users.address, users.phone_number, users.refresh_token,
+    users.password, users.is_admin, users.is_active, users.created_at`,
+	from: `FROM users
+WHERE
+    (
+        COALESCE($1, '') = ''
+        OR LOWER(name) LIKE $1
+        OR LOWER(email) LIKE $1
+        OR LOWER(phone_number) LIKE $1
+    )
+    AND (
+        $2::boolean IS NULL
+        OR is_active = $2
+    )
+    AND (
+        $3::boolean IS NULL
+        OR is_admin = $3
+    )`,
+}
+
+type userListRow struct {
+	ID           int64
+	Name         string
+	Email        string
+	Address      pgtype.Text
+	PhoneNumber  string
+	RefreshToken pgtype.Text
+	Password     string
+	IsAdmin      bool
+	IsActive     bool
+	CreatedAt    time.Time
+	SortKeys     []string
+}
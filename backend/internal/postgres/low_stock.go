@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+var _ repository.LowStockRepository = (*LowStockRepository)(nil)
+
+type LowStockRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewLowStockRepository(db *Store) *LowStockRepository {
+	return &LowStockRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+func (lsr *LowStockRepository) ListLowStock(ctx context.Context) ([]repository.LowStockItem, error) {
+	items, err := lsr.queries.ListLowStockItems(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing low stock: %s", err.Error())
+	}
+
+	return generatedLowStockItemsToRepo(items), nil
+}
+
+func (lsr *LowStockRepository) CheckLowStock(ctx context.Context) ([]repository.LowStockItem, error) {
+	var items []generated.LowStockItem
+	err := lsr.db.ExecTx(ctx, func(q *generated.Queries) error {
+		if _, err := q.ResolveLowStockAlerts(ctx); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error resolving low stock alerts: %s", err.Error())
+		}
+
+		var err error
+		items, err = q.CreateLowStockAlerts(ctx)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating low stock alerts: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return generatedLowStockItemsToRepo(items), nil
+}
+
+func generatedLowStockItemsToRepo(generatedItems []generated.LowStockItem) []repository.LowStockItem {
+	items := make([]repository.LowStockItem, len(generatedItems))
+	for i, generatedItem := range generatedItems {
+		items[i] = repository.LowStockItem{
+			ProductID:        uint32(generatedItem.ProductID),
+			ProductName:      generatedItem.ProductName,
+			VariantID:        nil,
+			VariantSku:       nil,
+			StockQuantity:    generatedItem.StockQuantity,
+			ReorderThreshold: generatedItem.ReorderThreshold.Int64,
+		}
+		if generatedItem.VariantID.Valid {
+			variantID := uint32(generatedItem.VariantID.Int64)
+			items[i].VariantID = &variantID
+		}
+		if generatedItem.VariantSku.Valid {
+			items[i].VariantSku = &generatedItem.VariantSku.String
+		}
+	}
+
+	return items
+}
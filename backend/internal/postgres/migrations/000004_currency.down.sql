@@ -0,0 +1,4 @@
+ALTER TABLE "products" DROP COLUMN "currency";
+ALTER TABLE "orders" DROP COLUMN "currency";
+ALTER TABLE "payments" DROP COLUMN "currency";
+ALTER TABLE "paystack_payments" DROP COLUMN "currency";
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE products ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'KES' CHECK (currency IN ('KES', 'NGN', 'GHS', 'ZAR', 'USD'));
+ALTER TABLE orders ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'KES' CHECK (currency IN ('KES', 'NGN', 'GHS', 'ZAR', 'USD'));
+ALTER TABLE payments ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'KES' CHECK (currency IN ('KES', 'NGN', 'GHS', 'ZAR', 'USD'));
+ALTER TABLE paystack_payments ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'KES' CHECK (currency IN ('KES', 'NGN', 'GHS', 'ZAR', 'USD'));
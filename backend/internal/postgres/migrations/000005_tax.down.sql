@@ -0,0 +1,10 @@
+ALTER TABLE "order_items" DROP COLUMN "tax_rate_bps";
+ALTER TABLE "order_items" DROP COLUMN "tax_amount";
+ALTER TABLE "order_items" DROP COLUMN "net_amount";
+ALTER TABLE "orders" DROP COLUMN "tax_amount";
+ALTER TABLE "orders" DROP COLUMN "net_amount";
+ALTER TABLE "categories" DROP COLUMN "tax_class_id";
+ALTER TABLE "products" DROP COLUMN "tax_class_id";
+
+DROP TABLE IF EXISTS "tax_rates";
+DROP TABLE IF EXISTS "tax_classes";
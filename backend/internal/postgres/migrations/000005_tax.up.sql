@@ -0,0 +1,34 @@
+CREATE TABLE "tax_classes" (
+    "id" bigserial PRIMARY KEY,
+    "name" varchar(100) NOT NULL UNIQUE,
+    "description" text NOT NULL DEFAULT '',
+    "deleted_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- rate_bps is the rate in basis points, e.g. 1600 for 16% VAT
+CREATE TABLE "tax_rates" (
+    "id" bigserial PRIMARY KEY,
+    "tax_class_id" bigint NOT NULL,
+    "rate_bps" integer NOT NULL CHECK (rate_bps >= 0 AND rate_bps <= 10000),
+    "effective_from" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "tax_rates_tax_class_id_fkey" FOREIGN KEY ("tax_class_id") REFERENCES "tax_classes" ("id") ON DELETE CASCADE,
+    CONSTRAINT "tax_rates_tax_class_id_effective_from_key" UNIQUE ("tax_class_id", "effective_from")
+);
+
+ALTER TABLE products ADD COLUMN tax_class_id bigint NULL;
+ALTER TABLE products ADD CONSTRAINT "products_tax_class_id_fkey" FOREIGN KEY ("tax_class_id") REFERENCES "tax_classes" ("id");
+ALTER TABLE categories ADD COLUMN tax_class_id bigint NULL;
+ALTER TABLE categories ADD CONSTRAINT "categories_tax_class_id_fkey" FOREIGN KEY ("tax_class_id") REFERENCES "tax_classes" ("id");
+
+-- prices are tax inclusive: total_amount/amount stay the gross amount
+ALTER TABLE orders ADD COLUMN net_amount decimal(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN tax_amount decimal(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN net_amount decimal(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_amount decimal(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_rate_bps integer NOT NULL DEFAULT 0;
+
+UPDATE orders SET net_amount = total_amount;
+UPDATE order_items SET net_amount = amount;
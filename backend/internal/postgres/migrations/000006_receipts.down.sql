@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "invoices";
+DROP TABLE IF EXISTS "invoice_counter";
+
+ALTER TABLE "orders" DROP COLUMN "payment_reference";
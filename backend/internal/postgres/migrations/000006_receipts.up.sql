@@ -0,0 +1,24 @@
+ALTER TABLE "orders" ADD COLUMN "payment_reference" varchar(255) NULL;
+
+-- invoice numbers are drawn from a counter row updated in the transaction that
+-- issues the invoice. Unlike a sequence the draw rolls back with a failed
+-- insert, so the numbers run without gaps in issue order, independently of
+-- order and payment ids.
+CREATE TABLE "invoice_counter" (
+    "id" boolean PRIMARY KEY DEFAULT true CHECK ("id"),
+    "next" bigint NOT NULL
+);
+
+INSERT INTO invoice_counter (next) VALUES (1);
+
+CREATE TABLE "invoices" (
+    "id" bigserial PRIMARY KEY,
+    "invoice_number" bigint NOT NULL UNIQUE,
+    "order_id" bigint NULL UNIQUE,
+    "payment_id" bigint NULL UNIQUE,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "invoices_order_id_fkey" FOREIGN KEY ("order_id") REFERENCES "orders" ("id"),
+    CONSTRAINT "invoices_payment_id_fkey" FOREIGN KEY ("payment_id") REFERENCES "payments" ("id"),
+    CONSTRAINT "invoices_source_check" CHECK (("order_id" IS NULL) <> ("payment_id" IS NULL))
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_delivery_date;
+
+ALTER TABLE "order_items" DROP COLUMN "card_anonymous";
+ALTER TABLE "order_items" DROP COLUMN "card_recipient_name";
+ALTER TABLE "order_items" DROP COLUMN "card_sender_name";
+ALTER TABLE "order_items" DROP COLUMN "card_message";
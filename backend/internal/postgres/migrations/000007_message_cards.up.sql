@@ -0,0 +1,7 @@
+-- the printed card of an order item that references a message card product
+ALTER TABLE order_items ADD COLUMN card_message text NULL;
+ALTER TABLE order_items ADD COLUMN card_sender_name varchar(100) NULL;
+ALTER TABLE order_items ADD COLUMN card_recipient_name varchar(100) NULL;
+ALTER TABLE order_items ADD COLUMN card_anonymous boolean NOT NULL DEFAULT false;
+
+CREATE INDEX idx_orders_delivery_date ON orders (delivery_date);
@@ -0,0 +1,14 @@
+ALTER TABLE "orders" ADD COLUMN "shipping_address" text NULL;
+
+UPDATE orders
+SET shipping_address = NULLIF(concat_ws(', ', address_building, address_street, NULLIF(address_area, ''), address_landmark), '');
+
+ALTER TABLE "orders" DROP COLUMN "address_longitude";
+ALTER TABLE "orders" DROP COLUMN "address_latitude";
+ALTER TABLE "orders" DROP COLUMN "address_landmark";
+ALTER TABLE "orders" DROP COLUMN "address_building";
+ALTER TABLE "orders" DROP COLUMN "address_street";
+ALTER TABLE "orders" DROP COLUMN "address_area";
+ALTER TABLE "orders" DROP COLUMN "recipient_phone_number";
+ALTER TABLE "orders" DROP COLUMN "recipient_name";
+ALTER TABLE "orders" DROP COLUMN "buyer_email";
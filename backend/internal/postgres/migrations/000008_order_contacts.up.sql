@@ -0,0 +1,20 @@
+-- user_name and user_phone_number stay the buyer's contact; the recipient is
+-- who the rider delivers to
+ALTER TABLE orders ADD COLUMN buyer_email varchar(255) NULL;
+ALTER TABLE orders ADD COLUMN recipient_name varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN recipient_phone_number varchar(255) NOT NULL DEFAULT '';
+
+ALTER TABLE orders ADD COLUMN address_area varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN address_street varchar(255) NULL;
+ALTER TABLE orders ADD COLUMN address_building varchar(255) NULL;
+ALTER TABLE orders ADD COLUMN address_landmark text NULL;
+ALTER TABLE orders ADD COLUMN address_latitude double precision NULL CHECK (address_latitude BETWEEN -90 AND 90);
+ALTER TABLE orders ADD COLUMN address_longitude double precision NULL CHECK (address_longitude BETWEEN -180 AND 180);
+
+-- existing orders were placed by their recipients
+UPDATE orders
+SET recipient_name = user_name,
+    recipient_phone_number = user_phone_number,
+    address_street = shipping_address;
+
+ALTER TABLE orders DROP COLUMN shipping_address;
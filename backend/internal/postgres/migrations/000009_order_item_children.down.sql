@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_items_parent_item_id;
+
+ALTER TABLE "order_items" DROP COLUMN "parent_item_id";
@@ -0,0 +1,4 @@
+-- add-ons and message cards attached to another item of the same order
+ALTER TABLE order_items ADD COLUMN parent_item_id bigint NULL REFERENCES order_items(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_order_items_parent_item_id ON order_items (parent_item_id);
@@ -0,0 +1,35 @@
+CREATE TABLE "product_stems" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "stem_count" bigint NOT NULL DEFAULT 0,
+    "price" decimal(10, 2) NOT NULL DEFAULT 0,
+
+    CONSTRAINT "product_stems_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_product_stems_product_id ON product_stems (product_id);
+
+-- only variants of a numeric "Stems" option can be turned back into stems
+INSERT INTO product_stems (id, product_id, stem_count, price)
+SELECT pv.id, pv.product_id, pov.value::bigint, pv.price
+FROM product_variants pv
+JOIN product_variant_options pvo ON pvo.variant_id = pv.id
+JOIN product_option_values pov ON pov.id = pvo.option_value_id
+JOIN product_option_types pot ON pot.id = pov.option_type_id
+WHERE pot.name = 'Stems' AND pov.value ~ '^[0-9]+$' AND pv.deleted_at IS NULL;
+
+SELECT setval(pg_get_serial_sequence('product_stems', 'id'), COALESCE((SELECT max(id) FROM product_stems), 0) + 1, false);
+
+ALTER TABLE "products" RENAME COLUMN "has_variants" TO "has_stems";
+
+ALTER TABLE "subscriptions" RENAME COLUMN "variant_ids" TO "stem_ids";
+
+ALTER TABLE "order_items" DROP CONSTRAINT "order_items_variant_id_fkey";
+ALTER TABLE "order_items" RENAME COLUMN "variant_id" TO "stem_id";
+UPDATE order_items SET stem_id = NULL WHERE stem_id NOT IN (SELECT id FROM product_stems);
+ALTER TABLE "order_items" ADD CONSTRAINT "order_items_stem_id_fkey" FOREIGN KEY ("stem_id") REFERENCES "product_stems" ("id");
+
+DROP TABLE IF EXISTS product_variant_options;
+DROP TABLE IF EXISTS product_variants;
+DROP TABLE IF EXISTS product_option_values;
+DROP TABLE IF EXISTS product_option_types;
@@ -0,0 +1,81 @@
+-- option types (size, colour, wrapping, stems, ...) and their values, per product
+CREATE TABLE "product_option_types" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "name" varchar(50) NOT NULL,
+    "position" int NOT NULL DEFAULT 0,
+
+    CONSTRAINT "product_option_types_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "product_option_types_product_id_name_key" UNIQUE ("product_id", "name")
+);
+
+CREATE TABLE "product_option_values" (
+    "id" bigserial PRIMARY KEY,
+    "option_type_id" bigint NOT NULL,
+    "value" varchar(100) NOT NULL,
+    "position" int NOT NULL DEFAULT 0,
+
+    CONSTRAINT "product_option_values_option_type_id_fkey" FOREIGN KEY ("option_type_id") REFERENCES "product_option_types" ("id") ON DELETE CASCADE,
+    CONSTRAINT "product_option_values_option_type_id_value_key" UNIQUE ("option_type_id", "value")
+);
+
+-- variants are soft deleted since order items keep pointing at them
+CREATE TABLE "product_variants" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "sku" varchar(64) NOT NULL,
+    "price" decimal(10, 2) NOT NULL DEFAULT 0,
+    -- NULL when the variant draws on the product's stock
+    "stock_quantity" bigint NULL CHECK ("stock_quantity" >= 0),
+    "image_url" text[] NOT NULL DEFAULT '{}',
+    "deleted_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "product_variants_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "product_variants_sku_key" UNIQUE ("sku")
+);
+
+CREATE INDEX idx_product_variants_product_id ON product_variants (product_id);
+
+CREATE TABLE "product_variant_options" (
+    "variant_id" bigint NOT NULL,
+    "option_value_id" bigint NOT NULL,
+
+    PRIMARY KEY ("variant_id", "option_value_id"),
+    CONSTRAINT "product_variant_options_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE,
+    CONSTRAINT "product_variant_options_option_value_id_fkey" FOREIGN KEY ("option_value_id") REFERENCES "product_option_values" ("id") ON DELETE CASCADE
+);
+
+-- every stem becomes a variant of a "Stems" option and keeps its id, so order
+-- items and subscriptions still point at the same row
+INSERT INTO product_option_types (product_id, name)
+SELECT DISTINCT product_id, 'Stems' FROM product_stems;
+
+INSERT INTO product_option_values (option_type_id, value, position)
+SELECT DISTINCT pot.id, ps.stem_count::text, ps.stem_count::int
+FROM product_stems ps
+JOIN product_option_types pot ON pot.product_id = ps.product_id AND pot.name = 'Stems';
+
+INSERT INTO product_variants (id, product_id, sku, price)
+SELECT id, product_id, format('P%s-STEMS-%s', product_id, id), price
+FROM product_stems;
+
+SELECT setval(pg_get_serial_sequence('product_variants', 'id'), COALESCE((SELECT max(id) FROM product_variants), 0) + 1, false);
+
+INSERT INTO product_variant_options (variant_id, option_value_id)
+SELECT ps.id, pov.id
+FROM product_stems ps
+JOIN product_option_types pot ON pot.product_id = ps.product_id AND pot.name = 'Stems'
+JOIN product_option_values pov ON pov.option_type_id = pot.id AND pov.value = ps.stem_count::text;
+
+ALTER TABLE order_items DROP CONSTRAINT "order_items_stem_id_fkey";
+ALTER TABLE order_items RENAME COLUMN stem_id TO variant_id;
+ALTER TABLE order_items ADD CONSTRAINT "order_items_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id");
+
+-- subscription items without a stem were stored as 0
+ALTER TABLE subscriptions RENAME COLUMN stem_ids TO variant_ids;
+UPDATE subscriptions SET variant_ids = array_remove(variant_ids, 0);
+
+ALTER TABLE products RENAME COLUMN has_stems TO has_variants;
+
+DROP TABLE product_stems;
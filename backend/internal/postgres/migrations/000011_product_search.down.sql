@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS categories_search_documents ON categories;
+DROP TRIGGER IF EXISTS products_search_document ON products;
+
+DROP FUNCTION IF EXISTS refresh_category_search_documents();
+DROP FUNCTION IF EXISTS refresh_product_search_document();
+DROP FUNCTION IF EXISTS product_search_document(text, text, text);
+
+DROP INDEX IF EXISTS idx_products_name_trgm;
+DROP TABLE IF EXISTS "product_search_documents";
+
+-- pg_trgm is left installed, other database objects may depend on it
@@ -0,0 +1,56 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- weighted search document per product: name (A), description (B), category name (C)
+CREATE TABLE "product_search_documents" (
+    "product_id" bigint PRIMARY KEY,
+    "document" tsvector NOT NULL,
+
+    CONSTRAINT "product_search_documents_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_product_search_documents_document ON product_search_documents USING GIN (document);
+CREATE INDEX idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
+
+CREATE OR REPLACE FUNCTION product_search_document(p_name text, p_description text, p_category text) RETURNS tsvector
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(p_name, '')), 'A')
+        || setweight(to_tsvector('english', COALESCE(p_description, '')), 'B')
+        || setweight(to_tsvector('english', COALESCE(p_category, '')), 'C')
+$$;
+
+CREATE OR REPLACE FUNCTION refresh_product_search_document() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO product_search_documents (product_id, document)
+    VALUES (
+        NEW.id,
+        product_search_document(NEW.name, NEW.description, (SELECT name FROM categories WHERE id = NEW.category_id))
+    )
+    ON CONFLICT (product_id) DO UPDATE SET document = EXCLUDED.document;
+    RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION refresh_category_search_documents() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    UPDATE product_search_documents psd
+    SET document = product_search_document(p.name, p.description, NEW.name)
+    FROM products p
+    WHERE p.id = psd.product_id AND p.category_id = NEW.id;
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER products_search_document
+AFTER INSERT OR UPDATE OF name, description, category_id ON products
+FOR EACH ROW EXECUTE FUNCTION refresh_product_search_document();
+
+CREATE TRIGGER categories_search_documents
+AFTER UPDATE OF name ON categories
+FOR EACH ROW EXECUTE FUNCTION refresh_category_search_documents();
+
+INSERT INTO product_search_documents (product_id, document)
+SELECT p.id, product_search_document(p.name, p.description, c.name)
+FROM products p
+LEFT JOIN categories c ON c.id = p.category_id;
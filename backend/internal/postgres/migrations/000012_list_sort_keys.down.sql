@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS idx_subscriptions_created_at_id;
+DROP INDEX IF EXISTS idx_payments_currency_amount_id;
+DROP INDEX IF EXISTS idx_payments_created_at_id;
+DROP INDEX IF EXISTS idx_payments_paid_at_id;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+DROP INDEX IF EXISTS idx_orders_currency_total_amount_id;
+DROP INDEX IF EXISTS idx_orders_delivery_date_id;
+DROP INDEX IF EXISTS idx_orders_created_at_id;
+DROP INDEX IF EXISTS idx_products_popularity_id;
+DROP INDEX IF EXISTS idx_products_created_at_id;
+
+DROP TRIGGER IF EXISTS orders_product_popularity ON orders;
+DROP TRIGGER IF EXISTS order_items_product_popularity_update ON order_items;
+DROP TRIGGER IF EXISTS order_items_product_popularity ON order_items;
+DROP FUNCTION IF EXISTS refresh_order_product_popularity();
+DROP FUNCTION IF EXISTS refresh_product_popularity();
+ALTER TABLE products DROP COLUMN IF EXISTS popularity;
+
@@ -0,0 +1,84 @@
+-- list queries page with row-value predicates over the sort columns and the
+-- id, so every sortable column gets a matching (column, id) index. Amounts
+-- sort by currency first since they are not comparable across currencies.
+-- Products sort by the price customers see, which depends on the price rules
+-- in force and can't be indexed.
+
+-- products.popularity is the quantity ordered over live orders, kept in step
+-- with every order item change and order soft delete or restore
+ALTER TABLE products ADD COLUMN popularity bigint NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION refresh_product_popularity() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE products p SET popularity = p.popularity - OLD.quantity
+        FROM orders o
+        WHERE p.id = OLD.product_id AND o.id = OLD.order_id AND o.deleted_at IS NULL;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE products p SET popularity = p.popularity + NEW.quantity
+        FROM orders o
+        WHERE p.id = NEW.product_id AND o.id = NEW.order_id AND o.deleted_at IS NULL;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER order_items_product_popularity
+AFTER INSERT OR DELETE ON order_items
+FOR EACH ROW EXECUTE FUNCTION refresh_product_popularity();
+
+CREATE TRIGGER order_items_product_popularity_update
+AFTER UPDATE OF product_id, quantity, order_id ON order_items
+FOR EACH ROW
+WHEN (
+    OLD.product_id IS DISTINCT FROM NEW.product_id
+    OR OLD.quantity IS DISTINCT FROM NEW.quantity
+    OR OLD.order_id IS DISTINCT FROM NEW.order_id
+)
+EXECUTE FUNCTION refresh_product_popularity();
+
+CREATE OR REPLACE FUNCTION refresh_order_product_popularity() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    UPDATE products p SET popularity = p.popularity + oi.quantity * CASE WHEN NEW.deleted_at IS NULL THEN 1 ELSE -1 END
+    FROM (
+        SELECT product_id, SUM(quantity) AS quantity
+        FROM order_items
+        WHERE order_id = NEW.id
+        GROUP BY product_id
+    ) oi
+    WHERE p.id = oi.product_id;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER orders_product_popularity
+AFTER UPDATE OF deleted_at ON orders
+FOR EACH ROW
+WHEN ((OLD.deleted_at IS NULL) <> (NEW.deleted_at IS NULL))
+EXECUTE FUNCTION refresh_order_product_popularity();
+
+UPDATE products p
+SET popularity = (
+    SELECT COALESCE(SUM(oi.quantity), 0)
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE oi.product_id = p.id AND o.deleted_at IS NULL
+);
+
+CREATE INDEX idx_products_created_at_id ON products (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_popularity_id ON products (popularity, id) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_orders_created_at_id ON orders (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_orders_delivery_date_id ON orders (delivery_date, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_orders_currency_total_amount_id ON orders (currency, total_amount, id) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_users_created_at_id ON users (created_at, id);
+
+CREATE INDEX idx_payments_paid_at_id ON payments (paid_at, id);
+CREATE INDEX idx_payments_created_at_id ON payments (created_at, id);
+CREATE INDEX idx_payments_currency_amount_id ON payments (currency, amount, id);
+
+CREATE INDEX idx_subscriptions_created_at_id ON subscriptions (created_at, id) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "product_tags";
+DROP TABLE IF EXISTS "tags";
+DROP TABLE IF EXISTS "tag_groups";
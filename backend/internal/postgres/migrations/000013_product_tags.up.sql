@@ -0,0 +1,55 @@
+-- tags group into dimensions customers shop by across categories
+CREATE TABLE "tag_groups" (
+    "id" bigserial PRIMARY KEY,
+    "name" varchar(100) NOT NULL,
+    "slug" varchar(100) NOT NULL,
+    "position" int NOT NULL DEFAULT 0,
+    "deleted_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX tag_groups_slug_key ON tag_groups (slug) WHERE deleted_at IS NULL;
+
+CREATE TABLE "tags" (
+    "id" bigserial PRIMARY KEY,
+    "tag_group_id" bigint NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "slug" varchar(100) NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    "image_url" text[] NOT NULL DEFAULT '{}',
+    "position" int NOT NULL DEFAULT 0,
+    "deleted_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "tags_tag_group_id_fkey" FOREIGN KEY ("tag_group_id") REFERENCES "tag_groups" ("id")
+);
+
+-- slugs name the tag landing pages
+CREATE UNIQUE INDEX tags_slug_key ON tags (slug) WHERE deleted_at IS NULL;
+
+CREATE TABLE "product_tags" (
+    "product_id" bigint NOT NULL,
+    "tag_id" bigint NOT NULL,
+
+    PRIMARY KEY ("product_id", "tag_id"),
+    CONSTRAINT "product_tags_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "product_tags_tag_id_fkey" FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_product_tags_tag_id ON product_tags (tag_id);
+
+INSERT INTO tag_groups (name, slug, position) VALUES
+    ('Occasion', 'occasion', 1),
+    ('Colour', 'colour', 2),
+    ('Flower type', 'flower-type', 3);
+
+INSERT INTO tags (tag_group_id, name, slug, position)
+SELECT g.id, t.name, t.slug, t.position
+FROM tag_groups g
+CROSS JOIN (VALUES
+    ('Birthday', 'birthday', 1),
+    ('Anniversary', 'anniversary', 2),
+    ('Sympathy', 'sympathy', 3),
+    ('Valentine''s', 'valentines', 4)
+) AS t (name, slug, position)
+WHERE g.slug = 'occasion';
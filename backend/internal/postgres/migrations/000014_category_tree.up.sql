@@ -0,0 +1,19 @@
+-- categories nest under a parent, e.g. Flowers > Roses > Red Roses
+ALTER TABLE categories ADD COLUMN parent_id bigint NULL;
+ALTER TABLE categories ADD CONSTRAINT "categories_parent_id_fkey" FOREIGN KEY ("parent_id") REFERENCES "categories" ("id");
+
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
+
+-- the live categories in the subtrees of root_ids, roots included
+CREATE OR REPLACE FUNCTION category_subtree_ids(root_ids bigint[]) RETURNS bigint[]
+LANGUAGE sql STABLE AS $$
+    WITH RECURSIVE subtree AS (
+        SELECT id FROM categories WHERE id = ANY(root_ids) AND deleted_at IS NULL
+        UNION
+        SELECT c.id
+        FROM categories c
+        JOIN subtree s ON c.parent_id = s.id
+        WHERE c.deleted_at IS NULL
+    )
+    SELECT COALESCE(array_agg(id), '{}') FROM subtree
+$$;
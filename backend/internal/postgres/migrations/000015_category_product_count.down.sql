@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS products_category_product_count_update ON products;
+DROP TRIGGER IF EXISTS products_category_product_count ON products;
+DROP FUNCTION IF EXISTS refresh_category_product_count();
@@ -0,0 +1,33 @@
+-- categories.product_count is the number of live products directly in the
+-- category, kept in step with every insert, move, soft delete and restore
+CREATE OR REPLACE FUNCTION refresh_category_product_count() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+        UPDATE categories SET product_count = product_count - 1 WHERE id = OLD.category_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+        UPDATE categories SET product_count = product_count + 1 WHERE id = NEW.category_id;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER products_category_product_count
+AFTER INSERT OR DELETE ON products
+FOR EACH ROW EXECUTE FUNCTION refresh_category_product_count();
+
+CREATE TRIGGER products_category_product_count_update
+AFTER UPDATE OF category_id, deleted_at ON products
+FOR EACH ROW
+WHEN (
+    OLD.category_id IS DISTINCT FROM NEW.category_id
+    OR (OLD.deleted_at IS NULL) <> (NEW.deleted_at IS NULL)
+)
+EXECUTE FUNCTION refresh_category_product_count();
+
+UPDATE categories c
+SET product_count = (
+    SELECT COUNT(*) FROM products p
+    WHERE p.category_id = c.id AND p.deleted_at IS NULL
+);
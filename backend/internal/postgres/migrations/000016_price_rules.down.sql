@@ -0,0 +1,13 @@
+DROP FUNCTION IF EXISTS product_price(products, bigint, numeric, timestamptz);
+DROP FUNCTION IF EXISTS apply_price_rule(numeric, price_rules);
+DROP FUNCTION IF EXISTS active_price_rule(products, bigint, timestamptz);
+DROP FUNCTION IF EXISTS category_ancestor_ids(bigint);
+
+DROP TRIGGER IF EXISTS product_variants_price_change_update ON product_variants;
+DROP TRIGGER IF EXISTS product_variants_price_change ON product_variants;
+DROP TRIGGER IF EXISTS products_price_change_update ON products;
+DROP TRIGGER IF EXISTS products_price_change ON products;
+DROP FUNCTION IF EXISTS record_price_change();
+
+DROP TABLE IF EXISTS price_changes;
+DROP TABLE IF EXISTS price_rules;
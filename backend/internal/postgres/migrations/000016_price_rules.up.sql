@@ -0,0 +1,161 @@
+-- price rules override product and variant prices within a window, either
+-- with an absolute price or with an uplift in basis points (20000 triples
+-- the price, -1000 takes 10% off). Rules are scoped to a product (optionally
+-- one of its variants), a category and its sub-categories, or a tag.
+CREATE TABLE "price_rules" (
+    "id" bigserial PRIMARY KEY,
+    "name" varchar(255) NOT NULL,
+    "kind" varchar(20) NOT NULL CHECK ("kind" IN ('absolute', 'percentage')),
+    "price" decimal(10, 2) NULL CHECK ("price" >= 0),
+    "currency" varchar(3) NULL,
+    "uplift_bps" integer NULL CHECK ("uplift_bps" > -10000),
+    "product_id" bigint NULL,
+    "variant_id" bigint NULL,
+    "category_id" bigint NULL,
+    "tag_id" bigint NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NULL,
+    "priority" integer NOT NULL DEFAULT 0,
+    "deleted_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "price_rules_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "price_rules_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE,
+    CONSTRAINT "price_rules_category_id_fkey" FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE,
+    CONSTRAINT "price_rules_tag_id_fkey" FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE,
+    CONSTRAINT "price_rules_scope_check" CHECK (num_nonnulls("product_id", "category_id", "tag_id") = 1),
+    CONSTRAINT "price_rules_variant_check" CHECK ("variant_id" IS NULL OR "product_id" IS NOT NULL),
+    CONSTRAINT "price_rules_value_check" CHECK (
+        ("kind" = 'absolute' AND "price" IS NOT NULL AND "currency" IS NOT NULL AND "uplift_bps" IS NULL AND "product_id" IS NOT NULL)
+        OR ("kind" = 'percentage' AND "uplift_bps" IS NOT NULL AND "price" IS NULL)
+    ),
+    CONSTRAINT "price_rules_window_check" CHECK ("ends_at" IS NULL OR "ends_at" > "starts_at")
+);
+
+CREATE INDEX idx_price_rules_product_id ON price_rules (product_id);
+CREATE INDEX idx_price_rules_category_id ON price_rules (category_id);
+CREATE INDEX idx_price_rules_tag_id ON price_rules (tag_id);
+CREATE INDEX idx_price_rules_window ON price_rules (starts_at, ends_at) WHERE deleted_at IS NULL;
+
+-- every change to a product or variant base price, for audits
+CREATE TABLE "price_changes" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "variant_id" bigint NULL,
+    "old_price" decimal(10, 2) NULL,
+    "new_price" decimal(10, 2) NOT NULL,
+    "changed_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "price_changes_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "price_changes_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_price_changes_product_id ON price_changes (product_id, changed_at);
+
+CREATE OR REPLACE FUNCTION record_price_change() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'products' THEN
+        INSERT INTO price_changes (product_id, old_price, new_price)
+        VALUES (NEW.id, CASE WHEN TG_OP = 'UPDATE' THEN OLD.price END, NEW.price);
+    ELSE
+        INSERT INTO price_changes (product_id, variant_id, old_price, new_price)
+        VALUES (NEW.product_id, NEW.id, CASE WHEN TG_OP = 'UPDATE' THEN OLD.price END, NEW.price);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER products_price_change
+AFTER INSERT ON products
+FOR EACH ROW EXECUTE FUNCTION record_price_change();
+
+CREATE TRIGGER products_price_change_update
+AFTER UPDATE OF price ON products
+FOR EACH ROW
+WHEN (OLD.price IS DISTINCT FROM NEW.price)
+EXECUTE FUNCTION record_price_change();
+
+CREATE TRIGGER product_variants_price_change
+AFTER INSERT ON product_variants
+FOR EACH ROW EXECUTE FUNCTION record_price_change();
+
+CREATE TRIGGER product_variants_price_change_update
+AFTER UPDATE OF price ON product_variants
+FOR EACH ROW
+WHEN (OLD.price IS DISTINCT FROM NEW.price)
+EXECUTE FUNCTION record_price_change();
+
+-- current prices are the starting point of the history
+INSERT INTO price_changes (product_id, new_price, changed_at)
+SELECT id, price, created_at FROM products;
+
+INSERT INTO price_changes (product_id, variant_id, new_price, changed_at)
+SELECT product_id, id, price, created_at FROM product_variants;
+
+-- the category and its ancestors, the category first
+CREATE OR REPLACE FUNCTION category_ancestor_ids(leaf_id bigint) RETURNS bigint[]
+LANGUAGE sql STABLE AS $$
+    WITH RECURSIVE ancestors AS (
+        SELECT id, parent_id FROM categories WHERE id = leaf_id
+        UNION
+        SELECT c.id, c.parent_id
+        FROM categories c
+        JOIN ancestors a ON c.id = a.parent_id
+    )
+    SELECT COALESCE(array_agg(id), '{}') FROM ancestors
+$$;
+
+-- the rule in force for a product, or one of its variants, at a point in
+-- time. Variant rules beat product rules, which beat tag rules, which beat
+-- category rules; within a scope the highest priority and then the newest
+-- rule wins. Rules don't stack. An absolute rule only holds while the product
+-- keeps the currency it was set in and, once the product has variants, only
+-- for the variant it is scoped to, whatever changed since it was created.
+CREATE OR REPLACE FUNCTION active_price_rule(p products, variant_id bigint, price_at timestamptz) RETURNS price_rules
+LANGUAGE sql STABLE AS $$
+    SELECT r.*
+    FROM price_rules r
+    WHERE r.deleted_at IS NULL
+        AND r.starts_at <= price_at
+        AND (r.ends_at IS NULL OR r.ends_at > price_at)
+        AND (
+            (r.product_id = p.id AND (r.variant_id IS NULL OR r.variant_id = active_price_rule.variant_id))
+            OR r.tag_id IN (
+                SELECT pt.tag_id FROM product_tags pt
+                JOIN tags t ON t.id = pt.tag_id AND t.deleted_at IS NULL
+                WHERE pt.product_id = p.id
+            )
+            OR r.category_id = ANY(category_ancestor_ids(p.category_id))
+        )
+        AND (
+            r.kind <> 'absolute'
+            OR (r.currency = p.currency AND (r.variant_id IS NOT NULL OR NOT p.has_variants))
+        )
+    ORDER BY
+        CASE
+            WHEN r.variant_id IS NOT NULL THEN 0
+            WHEN r.product_id IS NOT NULL THEN 1
+            WHEN r.tag_id IS NOT NULL THEN 2
+            ELSE 3
+        END,
+        r.priority DESC,
+        r.id DESC
+    LIMIT 1
+$$;
+
+CREATE OR REPLACE FUNCTION apply_price_rule(price numeric, price_rule price_rules) RETURNS numeric
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT CASE price_rule.kind
+        WHEN 'absolute' THEN price_rule.price
+        WHEN 'percentage' THEN round(price * (10000 + price_rule.uplift_bps) / 10000, 2)
+        ELSE price
+    END
+$$;
+
+-- the price a customer pays at price_at for the product, or for one of its
+-- variants when variant_id is set and price is the variant's base price
+CREATE OR REPLACE FUNCTION product_price(p products, variant_id bigint, price numeric, price_at timestamptz) RETURNS numeric
+LANGUAGE sql STABLE AS $$
+    SELECT apply_price_rule(price, active_price_rule(p, variant_id, price_at))
+$$;
@@ -0,0 +1,20 @@
+-- images uploaded to the blob store, kept so that blobs no longer referenced
+-- by their product or category can be cleaned up
+CREATE TABLE "images" (
+    "id" bigserial PRIMARY KEY,
+    "owner_type" varchar(20) NOT NULL CHECK ("owner_type" IN ('product', 'category')),
+    "owner_id" bigint NOT NULL,
+    "key" text NOT NULL,
+    "url" text NOT NULL,
+    "thumbnail_key" text NOT NULL,
+    "thumbnail_url" text NOT NULL,
+    "content_type" varchar(50) NOT NULL,
+    "size" bigint NOT NULL,
+    "width" integer NOT NULL,
+    "height" integer NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "images_key_key" UNIQUE ("key")
+);
+
+CREATE INDEX idx_images_owner ON images (owner_type, owner_id);
@@ -0,0 +1,27 @@
+-- stock received in batches that expire. stock_quantity of the product, or of
+-- the variant with stock of its own, stays the total on hand: receiving a
+-- batch adds to it, orders take from the batches first expiring first and
+-- expired batches are written off from it. Stock counted before batches, or
+-- set by hand, is drawn on once the batches run out.
+CREATE TABLE "stock_batches" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "variant_id" bigint NULL,
+    "supplier" varchar(255) NULL,
+    "received_at" timestamptz NOT NULL DEFAULT (now()),
+    "expires_at" timestamptz NOT NULL,
+    "quantity" bigint NOT NULL CHECK ("quantity" > 0),
+    "remaining" bigint NOT NULL CHECK ("remaining" >= 0),
+    "written_off" bigint NOT NULL DEFAULT 0 CHECK ("written_off" >= 0),
+    "written_off_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "stock_batches_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "stock_batches_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE,
+    CONSTRAINT "stock_batches_quantity_check" CHECK ("remaining" + "written_off" <= "quantity"),
+    CONSTRAINT "stock_batches_expires_at_check" CHECK ("expires_at" > "received_at")
+);
+
+CREATE INDEX idx_stock_batches_product_id ON stock_batches (product_id, variant_id);
+CREATE INDEX idx_stock_batches_live ON stock_batches (expires_at) WHERE remaining > 0;
+CREATE INDEX idx_stock_batches_written_off_at ON stock_batches (written_off_at) WHERE written_off_at IS NOT NULL;
@@ -0,0 +1,48 @@
+-- append-only ledger of every change to stock_quantity of a product, or of a
+-- variant with stock of its own. The sum of the movements of a product or
+-- variant is its stock, stock_quantity is kept in step in the same
+-- transaction and checked against the ledger by ListStockDiscrepancies.
+CREATE TABLE "stock_movements" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "variant_id" bigint NULL,
+    "kind" varchar(20) NOT NULL CHECK ("kind" IN ('receipt', 'sale', 'reservation', 'release', 'adjustment', 'wastage', 'return')),
+    "quantity" bigint NOT NULL CHECK ("quantity" <> 0),
+    "reason" text NOT NULL,
+    -- NULL for movements made by customers or scheduled jobs
+    "user_id" bigint NULL,
+    "order_id" bigint NULL,
+    "stock_batch_id" bigint NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "stock_movements_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id"),
+    CONSTRAINT "stock_movements_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id"),
+    CONSTRAINT "stock_movements_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id"),
+    CONSTRAINT "stock_movements_order_id_fkey" FOREIGN KEY ("order_id") REFERENCES "orders" ("id"),
+    CONSTRAINT "stock_movements_stock_batch_id_fkey" FOREIGN KEY ("stock_batch_id") REFERENCES "stock_batches" ("id")
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements (product_id, variant_id);
+CREATE INDEX idx_stock_movements_order_id ON stock_movements (order_id) WHERE order_id IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'stock movements are append-only, record a new movement instead';
+END;
+$$;
+
+CREATE TRIGGER stock_movements_append_only
+BEFORE UPDATE OR DELETE ON stock_movements
+FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+-- the stock on hand so far opens the ledger
+INSERT INTO stock_movements (product_id, kind, quantity, reason)
+SELECT id, 'adjustment', stock_quantity, 'opening balance'
+FROM products
+WHERE stock_quantity <> 0;
+
+INSERT INTO stock_movements (product_id, variant_id, kind, quantity, reason)
+SELECT product_id, id, 'adjustment', stock_quantity, 'opening balance'
+FROM product_variants
+WHERE stock_quantity IS NOT NULL AND stock_quantity <> 0;
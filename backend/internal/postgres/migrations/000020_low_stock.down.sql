@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS low_stock_alerts;
+DROP VIEW IF EXISTS low_stock_items;
+ALTER TABLE product_variants DROP COLUMN IF EXISTS reorder_threshold;
+ALTER TABLE products DROP COLUMN IF EXISTS reorder_threshold;
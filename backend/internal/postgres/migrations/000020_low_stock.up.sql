@@ -0,0 +1,46 @@
+-- stock at or below the reorder threshold is low, NULL turns the alert off
+ALTER TABLE products ADD COLUMN reorder_threshold bigint NULL CHECK (reorder_threshold >= 0);
+ALTER TABLE product_variants ADD COLUMN reorder_threshold bigint NULL CHECK (reorder_threshold >= 0);
+
+-- products, and variants with stock of their own, whose stock is low
+CREATE VIEW low_stock_items AS
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    NULL::bigint AS variant_id,
+    NULL::varchar AS variant_sku,
+    p.stock_quantity,
+    p.reorder_threshold
+FROM products p
+WHERE p.deleted_at IS NULL
+    AND p.reorder_threshold IS NOT NULL
+    AND p.stock_quantity <= p.reorder_threshold
+UNION ALL
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    pv.id AS variant_id,
+    pv.sku AS variant_sku,
+    pv.stock_quantity,
+    pv.reorder_threshold
+FROM product_variants pv
+JOIN products p ON p.id = pv.product_id
+WHERE pv.deleted_at IS NULL
+    AND p.deleted_at IS NULL
+    AND pv.reorder_threshold IS NOT NULL
+    AND pv.stock_quantity IS NOT NULL
+    AND pv.stock_quantity <= pv.reorder_threshold;
+
+-- low stock staff have been notified of, removed once it is back above the
+-- threshold so the next drop notifies again
+CREATE TABLE "low_stock_alerts" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "variant_id" bigint NULL,
+    "notified_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "low_stock_alerts_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "low_stock_alerts_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_low_stock_alerts_item ON low_stock_alerts (product_id, COALESCE(variant_id, 0));
@@ -0,0 +1,56 @@
+CREATE TABLE "suppliers" (
+    "id" bigserial PRIMARY KEY,
+    "name" varchar(255) NOT NULL,
+    "contact_name" varchar(255) NULL,
+    "email" varchar(255) NULL,
+    "phone_number" varchar(50) NULL,
+    "address" text NULL,
+    "notes" text NULL,
+    "deleted_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX idx_suppliers_name ON suppliers (LOWER(name)) WHERE deleted_at IS NULL;
+
+-- a purchase order moves from draft to ordered, then to partially_received
+-- and received as its items are received; only drafts are edited and only
+-- orders with nothing received are cancelled
+CREATE TABLE "purchase_orders" (
+    "id" bigserial PRIMARY KEY,
+    "supplier_id" bigint NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'draft' CHECK ("status" IN ('draft', 'ordered', 'partially_received', 'received', 'cancelled')),
+    "currency" varchar(3) NOT NULL DEFAULT 'KES' CHECK ("currency" IN ('KES', 'NGN', 'GHS', 'ZAR', 'USD')),
+    "expected_at" timestamptz NULL,
+    "notes" text NULL,
+    "created_by" bigint NULL,
+    "ordered_at" timestamptz NULL,
+    "received_at" timestamptz NULL,
+    "cancelled_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "purchase_orders_supplier_id_fkey" FOREIGN KEY ("supplier_id") REFERENCES "suppliers" ("id"),
+    CONSTRAINT "purchase_orders_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users" ("id")
+);
+
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders (status);
+
+-- unit_cost is what the supplier charges per unit in the currency of the
+-- purchase order, the cost margins are reported against
+CREATE TABLE "purchase_order_items" (
+    "id" bigserial PRIMARY KEY,
+    "purchase_order_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "variant_id" bigint NULL,
+    "quantity" bigint NOT NULL CHECK ("quantity" > 0),
+    "received_quantity" bigint NOT NULL DEFAULT 0 CHECK ("received_quantity" >= 0),
+    "unit_cost" decimal(10,2) NOT NULL CHECK ("unit_cost" >= 0),
+
+    CONSTRAINT "purchase_order_items_received_quantity_check" CHECK ("received_quantity" <= "quantity"),
+    CONSTRAINT "purchase_order_items_purchase_order_id_fkey" FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders" ("id") ON DELETE CASCADE,
+    CONSTRAINT "purchase_order_items_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id"),
+    CONSTRAINT "purchase_order_items_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id")
+);
+
+CREATE UNIQUE INDEX idx_purchase_order_items_item ON purchase_order_items (purchase_order_id, product_id, COALESCE(variant_id, 0));
+CREATE INDEX idx_purchase_order_items_product_id ON purchase_order_items (product_id, variant_id) WHERE received_quantity > 0;
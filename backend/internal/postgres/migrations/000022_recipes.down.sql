@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS product_availability(products, bigint, bigint);
+DROP FUNCTION IF EXISTS recipe_availability(bigint, bigint);
+DROP FUNCTION IF EXISTS recipe_variant_id(bigint, bigint);
+DROP TABLE IF EXISTS recipe_components;
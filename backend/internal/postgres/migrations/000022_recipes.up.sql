@@ -0,0 +1,52 @@
+-- a product, or one of its variants, made from other products: its recipe
+-- lists how much of each component goes into one of it. Components are
+-- products, or variants with stock of their own, without a recipe of their
+-- own, and ordering a product with a recipe takes its components out of stock.
+CREATE TABLE "recipe_components" (
+    "id" bigserial PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    -- NULL for the recipe of the product, used by its variants without a
+    -- recipe of their own
+    "variant_id" bigint NULL,
+    "component_product_id" bigint NOT NULL,
+    "component_variant_id" bigint NULL,
+    "quantity" bigint NOT NULL CHECK ("quantity" > 0),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+
+    CONSTRAINT "recipe_components_product_id_fkey" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE,
+    CONSTRAINT "recipe_components_variant_id_fkey" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE,
+    CONSTRAINT "recipe_components_component_product_id_fkey" FOREIGN KEY ("component_product_id") REFERENCES "products" ("id"),
+    CONSTRAINT "recipe_components_component_variant_id_fkey" FOREIGN KEY ("component_variant_id") REFERENCES "product_variants" ("id")
+);
+
+CREATE UNIQUE INDEX idx_recipe_components_component ON recipe_components (product_id, COALESCE(variant_id, 0), component_product_id, COALESCE(component_variant_id, 0));
+CREATE INDEX idx_recipe_components_component_product_id ON recipe_components (component_product_id);
+
+-- the variant whose recipe applies to a product or variant: the variant when
+-- it has a recipe of its own, NULL for the product's
+CREATE OR REPLACE FUNCTION recipe_variant_id(product_id bigint, variant_id bigint) RETURNS bigint
+LANGUAGE sql STABLE AS $$
+    SELECT CASE WHEN EXISTS (
+        SELECT 1 FROM recipe_components rc
+        WHERE rc.product_id = $1 AND rc.variant_id = $2
+    ) THEN $2 END
+$$;
+
+-- how many of a product or variant the stock of its components makes, NULL
+-- when it has no recipe
+CREATE OR REPLACE FUNCTION recipe_availability(product_id bigint, variant_id bigint) RETURNS bigint
+LANGUAGE sql STABLE AS $$
+    SELECT MIN(GREATEST(COALESCE(cv.stock_quantity, cp.stock_quantity), 0) / rc.quantity)
+    FROM recipe_components rc
+    JOIN products cp ON cp.id = rc.component_product_id
+    LEFT JOIN product_variants cv ON cv.id = rc.component_variant_id
+    WHERE rc.product_id = $1
+        AND rc.variant_id IS NOT DISTINCT FROM recipe_variant_id($1, $2)
+$$;
+
+-- the quantity of a product or variant that can be ordered: what its
+-- components make when it has a recipe, its stock_quantity otherwise
+CREATE OR REPLACE FUNCTION product_availability(p products, variant_id bigint, stock_quantity bigint) RETURNS bigint
+LANGUAGE sql STABLE AS $$
+    SELECT COALESCE(recipe_availability(p.id, variant_id), stock_quantity)
+$$;
@@ -0,0 +1,4 @@
+-- products without variants are told apart by a sku of their own, which bulk
+-- imports upsert by
+ALTER TABLE products ADD COLUMN sku varchar(64) NULL;
+ALTER TABLE products ADD CONSTRAINT "products_sku_key" UNIQUE ("sku");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_subscriptions_currency_price_id;
+ALTER TABLE "subscriptions" DROP COLUMN "currency";
@@ -0,0 +1,9 @@
+-- subscriptions are priced in the currency of the order that created them
+ALTER TABLE subscriptions ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'KES' CHECK (currency IN ('KES', 'NGN', 'GHS', 'ZAR', 'USD'));
+
+UPDATE subscriptions s
+SET currency = o.currency
+FROM orders o
+WHERE o.id = s.parent_order_id;
+
+CREATE INDEX idx_subscriptions_currency_price_id ON subscriptions (currency, price, id) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS user_subscriptions_cancelled_at ON user_subscriptions;
+DROP FUNCTION IF EXISTS set_user_subscription_cancelled_at();
+ALTER TABLE "user_subscriptions" DROP COLUMN "cancelled_at";
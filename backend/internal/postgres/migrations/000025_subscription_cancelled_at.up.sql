@@ -0,0 +1,23 @@
+-- cancelled_at is when a user subscription was switched off, so churn can be
+-- told apart from subscriptions that merely reached their end date
+ALTER TABLE user_subscriptions ADD COLUMN cancelled_at timestamptz NULL;
+
+CREATE OR REPLACE FUNCTION set_user_subscription_cancelled_at() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF NEW.status THEN
+        NEW.cancelled_at = NULL;
+    ELSIF TG_OP = 'INSERT' OR OLD.status THEN
+        NEW.cancelled_at = now();
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER user_subscriptions_cancelled_at
+BEFORE INSERT OR UPDATE OF status ON user_subscriptions
+FOR EACH ROW EXECUTE FUNCTION set_user_subscription_cancelled_at();
+
+-- when subscriptions were switched off before is not known, they count as
+-- cancelled now unless they had already ended
+UPDATE user_subscriptions SET cancelled_at = LEAST(now(), end_date) WHERE NOT status;
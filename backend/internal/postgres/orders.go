@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
 	"github.com/flexGURU/flower-haven/backend/internal/repository"
@@ -17,6 +18,14 @@ import (
 
 var _ repository.OrderRepository = (*OrderRepository)(nil)
 
+var defaultOrderSort = pkg.Sort{{Name: "created_at", Desc: true}}
+
+var orderKeyset = keyset{id: "orders.id", fields: map[string][]sortColumn{
+	"created_at":    {{"orders.created_at", "timestamptz"}},
+	"delivery_date": {{"orders.delivery_date", "timestamptz"}},
+	"total_amount":  {{"orders.currency", "text"}, {"orders.total_amount", "numeric"}},
+}}
+
 type OrderRepository struct {
 	queries *generated.Queries
 	db      *Store
@@ -29,136 +38,224 @@ func NewOrderRepository(db *Store) *OrderRepository {
 	}
 }
 
+func (or *OrderRepository) QuoteOrder(ctx context.Context, currency pkg.Currency, orderItems []repository.OrderItem) (*repository.OrderQuote, error) {
+	items, parents, err := flattenOrderItems(orderItems)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := newOrderQuote(currency, len(items))
+	now := time.Now()
+	for idx, item := range items {
+		priced, err := priceOrderItem(ctx, or.queries, currency, item, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkOrderItemNesting(priced.product, parents, idx); err != nil {
+			return nil, err
+		}
+
+		if err := addQuoteItem(quote, priced.item); err != nil {
+			return nil, err
+		}
+	}
+	quote.Items = nestOrderItems(quote.Items, parents)
+
+	return quote, nil
+}
+
 func (or *OrderRepository) CreateOrder(ctx context.Context, order *repository.Order, orderItems []repository.OrderItem) (*repository.Order, error) {
-	err := or.db.ExecTx(ctx, func(q *generated.Queries) error {
+	if err := validateCoordinates(order.DeliveryAddress.Latitude, order.DeliveryAddress.Longitude); err != nil {
+		return nil, err
+	}
+
+	items, parents, err := flattenOrderItems(orderItems)
+	if err != nil {
+		return nil, err
+	}
+
+	err = or.db.ExecTx(ctx, func(q *generated.Queries) error {
 		// create order details
 		createOrderParams := generated.CreateOrderParams{
-			UserName:        order.UserName,
-			UserPhoneNumber: order.UserPhoneNumber,
-			PaymentStatus:   order.PaymentStatus,
-			Status:          order.Status,
-			DeliveryDate:    order.DeliveryDate,
-			TimeSlot:        order.TimeSlot,
-			ByAdmin:         order.ByAdmin,
-			ShippingAddress: pgtype.Text{Valid: false},
-		}
-
-		if order.ShippingAddress != nil {
-			createOrderParams.ShippingAddress = pgtype.Text{
+			UserName:             order.Buyer.Name,
+			UserPhoneNumber:      order.Buyer.PhoneNumber,
+			PaymentStatus:        order.PaymentStatus,
+			Status:               order.Status,
+			DeliveryDate:         order.DeliveryDate,
+			TimeSlot:             order.TimeSlot,
+			ByAdmin:              order.ByAdmin,
+			Currency:             string(order.Currency),
+			PaymentReference:     pgtype.Text{Valid: false},
+			BuyerEmail:           textFromPtr(order.Buyer.Email),
+			RecipientName:        order.Recipient.Name,
+			RecipientPhoneNumber: order.Recipient.PhoneNumber,
+			AddressArea:          order.DeliveryAddress.Area,
+			AddressStreet:        textFromPtr(order.DeliveryAddress.Street),
+			AddressBuilding:      textFromPtr(order.DeliveryAddress.Building),
+			AddressLandmark:      textFromPtr(order.DeliveryAddress.Landmark),
+			AddressLatitude:      float8FromPtr(order.DeliveryAddress.Latitude),
+			AddressLongitude:     float8FromPtr(order.DeliveryAddress.Longitude),
+		}
+
+		if order.PaymentReference != nil {
+			createOrderParams.PaymentReference = pgtype.Text{
 				Valid:  true,
-				String: *order.ShippingAddress,
+				String: *order.PaymentReference,
 			}
 		}
 
-		totalAmount := 0.0
+		quote := newOrderQuote(order.Currency, len(items))
 		clientSubscriptionParams := map[int]generated.CreateSubscriptionParams{}
 		clientUserSubscriptionParams := map[int]generated.CreateUserSubscriptionParams{}
-		orderItemParams := make([]generated.CreateOrderItemParams, len(orderItems))
-		for idx, item := range orderItems {
-			product, err := q.GetProductByID(ctx, int64(item.ProductID))
+		subscriptionPrices := map[int]pkg.Money{}
+		orderItemParams := make([]generated.CreateOrderItemParams, len(items))
+		stockMovements := make([]generated.CreateStockMovementParams, 0, len(items))
+		for idx, item := range items {
+			priced, err := priceOrderItem(ctx, q, order.Currency, item, time.Now())
 			if err != nil {
-				if errors.Is(err, sql.ErrNoRows) {
-					return pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with ID %d not found", item.ProductID)
-				}
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching product by id: %s", err.Error())
+				return err
 			}
+			product, pricedItem := priced.product, priced.item
 
-			if item.StemID != 0 {
-				// check if stem exists
-				stem, err := q.GetProductStemByID(ctx, int64(item.StemID))
-				if err != nil {
-					if errors.Is(err, sql.ErrNoRows) {
-						return pkg.Errorf(pkg.NOT_FOUND_ERROR, "stem with ID %d not found", item.StemID)
-					}
-					return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching stem by id: %s", err.Error())
-				}
-				product.Price = stem.Price
+			if err := checkOrderItemNesting(product, parents, idx); err != nil {
+				return err
 			}
 
-			if product.StockQuantity < int64(item.Quantity) {
-				return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d has stock_quantity of %d and trying to make an order of stock_quantity %d. Need to add stock first", item.ProductID, product.StockQuantity, item.Quantity)
+			messageCard, err := normalizeMessageCard(product.IsMessageCard, item)
+			if err != nil {
+				return err
 			}
 
-			amount := pkg.PgTypeNumericToFloat64(product.Price) * float64(item.Quantity)
-			totalAmount += amount
+			amount := pricedItem.Amount
+			if !amount.Equal(item.Amount) {
+				return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d has price of %s and trying to make an order with amount %s. Amount should be %s", item.ProductID, priced.unitPrice, item.Amount, amount)
+			}
 
-			if amount != item.Amount {
-				return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d has price of %.2f and trying to make an order with amount %.2f. Amount should be %.2f", item.ProductID, pkg.PgTypeNumericToFloat64(product.Price), item.Amount, amount)
+			if err := addQuoteItem(quote, pricedItem); err != nil {
+				return err
 			}
 
-			_, err = q.UpdateProduct(ctx, generated.UpdateProductParams{
-				ID: int64(item.ProductID),
-				StockQuantity: pgtype.Int8{
-					Valid: true,
-					Int64: product.StockQuantity - int64(item.Quantity),
-				},
-			})
+			movements, err := reserveStock(ctx, q, priced)
 			if err != nil {
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update product with id %d quantity: %s", item.Quantity, err.Error())
+				return err
 			}
+			stockMovements = append(stockMovements, movements...)
 
 			if item.PaymentMethod == "subscription" {
-				// create subscription params by_admin to false
-				createSubParams := generated.CreateSubscriptionParams{
-					Name:        order.UserName,
-					Description: fmt.Sprintf("Subscription made by %s for product %s of quantity %d on %s", order.UserName, product.Name, item.Quantity, time.Now().Format("2006-01-02 15:04:05")),
-					ProductIds:  []int32{int32(item.ProductID)},
-					AddOns:      []int32{},
-					Price:       pkg.Float64ToPgTypeNumeric(amount),
-					StemIds:     []int32{int32(item.StemID)},
-					ByAdmin:     false,
-				}
-				clientSubscriptionParams[idx] = createSubParams
-
-				// create user_subscription params with frequency and day_of_week
-				createUserSubParams := generated.CreateUserSubscriptionParams{
-					UserID:    pgtype.Int8{Valid: false},
-					StartDate: time.Now(),
-					EndDate:   time.Now().AddDate(0, 3, 0), // default to 3 months
-					DayOfWeek: int16(order.DeliveryDate.Weekday()),
-					Frequency: item.Frequency,
+				if parentIdx := parents[idx]; parentIdx >= 0 {
+					// attached items are delivered with every cycle of the parent's subscription
+					subParams := clientSubscriptionParams[parentIdx]
+					subParams.AddOns = append(subParams.AddOns, int32(item.ProductID))
+					if item.VariantID != 0 {
+						subParams.VariantIds = append(subParams.VariantIds, int32(item.VariantID))
+					}
+					clientSubscriptionParams[parentIdx] = subParams
+
+					if subscriptionPrices[parentIdx], err = subscriptionPrices[parentIdx].Add(amount); err != nil {
+						return err
+					}
+				} else {
+					// create subscription params by_admin to false, the price is set once attached items are added
+					createSubParams := generated.CreateSubscriptionParams{
+						Name:        order.Buyer.Name,
+						Description: fmt.Sprintf("Subscription made by %s for product %s of quantity %d on %s", order.Buyer.Name, product.Name, item.Quantity, time.Now().Format("2006-01-02 15:04:05")),
+						ProductIds:  []int32{int32(item.ProductID)},
+						AddOns:      []int32{},
+						VariantIds:  []int32{},
+						Currency:    string(order.Currency),
+						ByAdmin:     false,
+					}
+					if item.VariantID != 0 {
+						createSubParams.VariantIds = append(createSubParams.VariantIds, int32(item.VariantID))
+					}
+					clientSubscriptionParams[idx] = createSubParams
+					subscriptionPrices[idx] = amount
+
+					// create user_subscription params with frequency and day_of_week
+					createUserSubParams := generated.CreateUserSubscriptionParams{
+						UserID:    pgtype.Int8{Valid: false},
+						StartDate: time.Now(),
+						EndDate:   time.Now().AddDate(0, 3, 0), // default to 3 months
+						DayOfWeek: int16(order.DeliveryDate.Weekday()),
+						Frequency: item.Frequency,
+					}
+					clientUserSubscriptionParams[idx] = createUserSubParams
 				}
-				clientUserSubscriptionParams[idx] = createUserSubParams
 
 				// add the orderItem params To CreateOrderItemParams
 				createOrerItemParam := generated.CreateOrderItemParams{
 					ProductID:     int64(item.ProductID),
 					Quantity:      item.Quantity,
-					Amount:        pkg.Float64ToPgTypeNumeric(amount),
+					Amount:        amount.Numeric(),
+					NetAmount:     pricedItem.NetAmount.Numeric(),
+					TaxAmount:     pricedItem.TaxAmount.Numeric(),
+					TaxRateBps:    int32(pricedItem.TaxRateBps),
 					PaymentMethod: item.PaymentMethod,
 					Frequency:     pgtype.Text{Valid: true, String: item.Frequency},
-					StemID:        pgtype.Int8{Valid: false},
+					VariantID:     pgtype.Int8{Valid: false},
 				}
-				if item.StemID != 0 {
-					createOrerItemParam.StemID = pgtype.Int8{Valid: true, Int64: int64(item.StemID)}
+				if item.VariantID != 0 {
+					createOrerItemParam.VariantID = pgtype.Int8{Valid: true, Int64: int64(item.VariantID)}
 				}
 				orderItemParams[idx] = createOrerItemParam
 			} else {
 				createOrerItemParam := generated.CreateOrderItemParams{
 					ProductID:     int64(item.ProductID),
 					Quantity:      item.Quantity,
-					Amount:        pkg.Float64ToPgTypeNumeric(amount),
+					Amount:        amount.Numeric(),
+					NetAmount:     pricedItem.NetAmount.Numeric(),
+					TaxAmount:     pricedItem.TaxAmount.Numeric(),
+					TaxRateBps:    int32(pricedItem.TaxRateBps),
 					PaymentMethod: item.PaymentMethod,
 					Frequency:     pgtype.Text{Valid: false},
-					StemID:        pgtype.Int8{Valid: false},
+					VariantID:     pgtype.Int8{Valid: false},
 				}
-				if item.StemID != 0 {
-					createOrerItemParam.StemID = pgtype.Int8{Valid: true, Int64: int64(item.StemID)}
+				if item.VariantID != 0 {
+					createOrerItemParam.VariantID = pgtype.Int8{Valid: true, Int64: int64(item.VariantID)}
 				}
 				orderItemParams[idx] = createOrerItemParam
 			}
+
+			if messageCard != nil {
+				orderItemParams[idx].CardMessage = pgtype.Text{Valid: true, String: messageCard.Message}
+				orderItemParams[idx].CardSenderName = pgtype.Text{Valid: messageCard.SenderName != "", String: messageCard.SenderName}
+				orderItemParams[idx].CardRecipientName = pgtype.Text{Valid: messageCard.RecipientName != "", String: messageCard.RecipientName}
+				orderItemParams[idx].CardAnonymous = messageCard.Anonymous
+			}
+		}
+
+		if !quote.TotalAmount.Equal(order.TotalAmount) {
+			return pkg.Errorf(pkg.INVALID_ERROR, "order items add up to %s but %s was paid", quote.TotalAmount, order.TotalAmount)
 		}
 
-		createOrderParams.TotalAmount = pkg.Float64ToPgTypeNumeric(totalAmount)
+		createOrderParams.TotalAmount = quote.TotalAmount.Numeric()
+		createOrderParams.NetAmount = quote.NetAmount.Numeric()
+		createOrderParams.TaxAmount = quote.TaxAmount.Numeric()
 		orderId, err := q.CreateOrder(ctx, createOrderParams)
 		if err != nil {
 			return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create order: %s", err.Error())
 		}
 
+		// stock of unpaid orders stays reserved until they are paid
+		kind := repository.StockMovementReservation
+		if order.PaymentStatus {
+			kind = repository.StockMovementSale
+		}
+		for _, movement := range stockMovements {
+			movement.Kind = kind
+			movement.Reason = "order placed"
+			movement.OrderID = pgtype.Int8{Valid: true, Int64: orderId}
+			if err := recordStockMovement(ctx, q, movement); err != nil {
+				return err
+			}
+		}
+
 		// create subscriptions with orderId as parent id
 		subScriptionIds := map[int]int64{}
 		for idx, subParams := range clientSubscriptionParams {
 			subParams.ParentOrderID = pgtype.Int8{Valid: true, Int64: orderId}
+			subParams.Price = subscriptionPrices[idx].Numeric()
 			subscriptionId, err := q.CreateSubscription(ctx, subParams)
 			if err != nil {
 				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create subscription: %s", err.Error())
@@ -175,10 +272,15 @@ func (or *OrderRepository) CreateOrder(ctx context.Context, order *repository.Or
 			}
 		}
 
-		// create order items
-		for _, param := range orderItemParams {
+		// create order items, attached items come after their parent
+		itemIDs := make([]int64, len(orderItemParams))
+		for idx, param := range orderItemParams {
 			param.OrderID = orderId
-			if _, err := q.CreateOrderItem(ctx, param); err != nil {
+			if parentIdx := parents[idx]; parentIdx >= 0 {
+				param.ParentItemID = pgtype.Int8{Valid: true, Int64: itemIDs[parentIdx]}
+			}
+
+			if itemIDs[idx], err = q.CreateOrderItem(ctx, param); err != nil {
 				return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create order item: %s", err.Error())
 			}
 		}
@@ -203,26 +305,71 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id int64) (*reposit
 		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching order by id: %s", err.Error())
 	}
 
+	return fullDataOrderToRepo(order)
+}
+
+// ListOrdersByDeliveryDate returns the orders, with their items, that are due
+// for delivery on the calendar day of date.
+func (or *OrderRepository) ListOrdersByDeliveryDate(ctx context.Context, date time.Time) ([]*repository.Order, error) {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	generatedOrders, err := or.queries.ListOrdersByDeliveryDate(ctx, generated.ListOrdersByDeliveryDateParams{
+		DayStart: dayStart,
+		DayEnd:   dayStart.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing orders by delivery date: %s", err.Error())
+	}
+
+	orders := make([]*repository.Order, len(generatedOrders))
+	for i, order := range generatedOrders {
+		if orders[i], err = fullDataOrderToRepo(generated.GetOrderByFullDataIDRow(order)); err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+func fullDataOrderToRepo(order generated.GetOrderByFullDataIDRow) (*repository.Order, error) {
 	orderItemData := []repository.OrderItem{}
 	if err := json.Unmarshal(order.OrderItemData, &orderItemData); err != nil {
 		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmashaling order_item_data to order: %s", err.Error())
 	}
 
+	currency := pkg.Currency(order.Currency)
+	for i := range orderItemData {
+		orderItemData[i].Amount = orderItemData[i].Amount.WithCurrency(currency)
+		orderItemData[i].NetAmount = orderItemData[i].NetAmount.WithCurrency(currency)
+		orderItemData[i].TaxAmount = orderItemData[i].TaxAmount.WithCurrency(currency)
+	}
+
+	amounts, err := numericsToMoney(currency, order.TotalAmount, order.NetAmount, order.TaxAmount)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amounts for order %d: %s", order.ID, err.Error())
+	}
+
 	rslt := &repository.Order{
-		ID:              uint32(order.ID),
-		UserName:        order.UserName,
-		UserPhoneNumber: order.UserPhoneNumber,
-		TotalAmount:     pkg.PgTypeNumericToFloat64(order.TotalAmount),
-		PaymentStatus:   order.PaymentStatus,
-		Status:          order.Status,
-		ShippingAddress: nil,
-		DeletedAt:       nil,
-		CreatedAt:       order.CreatedAt,
-		OrderItemsData:  orderItemData,
+		ID:               uint32(order.ID),
+		Buyer:            buyerToRepo(order.UserName, order.UserPhoneNumber, order.BuyerEmail),
+		Recipient:        repository.Contact{Name: order.RecipientName, PhoneNumber: order.RecipientPhoneNumber},
+		TotalAmount:      amounts[0],
+		NetAmount:        amounts[1],
+		TaxAmount:        amounts[2],
+		Currency:         currency,
+		PaymentStatus:    order.PaymentStatus,
+		Status:           order.Status,
+		DeliveryDate:     order.DeliveryDate,
+		TimeSlot:         order.TimeSlot,
+		ByAdmin:          order.ByAdmin,
+		DeliveryAddress:  deliveryAddressToRepo(order.AddressArea, order.AddressStreet, order.AddressBuilding, order.AddressLandmark, order.AddressLatitude, order.AddressLongitude),
+		PaymentReference: nil,
+		DeletedAt:        nil,
+		CreatedAt:        order.CreatedAt,
+		OrderItemsData:   nestStoredOrderItems(orderItemData),
 	}
 
-	if order.ShippingAddress.Valid {
-		rslt.ShippingAddress = &order.ShippingAddress.String
+	if order.PaymentReference.Valid {
+		rslt.PaymentReference = &order.PaymentReference.String
 	}
 
 	if order.DeletedAt.Valid {
@@ -234,26 +381,31 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id int64) (*reposit
 
 func (or *OrderRepository) UpdateOrder(ctx context.Context, order *repository.UpdateOrder) (*repository.Order, error) {
 	params := generated.UpdateOrderParams{
-		ID:              int64(order.ID),
-		UserName:        pgtype.Text{Valid: false},
-		UserPhoneNumber: pgtype.Text{Valid: false},
-		PaymentStatus:   pgtype.Bool{Valid: false},
-		Status:          pgtype.Text{Valid: false},
-		ShippingAddress: pgtype.Text{Valid: false},
+		ID:                   int64(order.ID),
+		UserName:             pgtype.Text{Valid: false},
+		UserPhoneNumber:      pgtype.Text{Valid: false},
+		BuyerEmail:           pgtype.Text{Valid: false},
+		RecipientName:        pgtype.Text{Valid: false},
+		RecipientPhoneNumber: pgtype.Text{Valid: false},
+		PaymentStatus:        pgtype.Bool{Valid: false},
+		Status:               pgtype.Text{Valid: false},
+		AddressArea:          pgtype.Text{Valid: false},
+		AddressStreet:        pgtype.Text{Valid: false},
+		AddressBuilding:      pgtype.Text{Valid: false},
+		AddressLandmark:      pgtype.Text{Valid: false},
+		AddressLatitude:      pgtype.Float8{Valid: false},
+		AddressLongitude:     pgtype.Float8{Valid: false},
 	}
 
-	if order.UserName != nil {
-		params.UserName = pgtype.Text{
-			Valid:  true,
-			String: *order.UserName,
-		}
+	if order.Buyer != nil {
+		params.UserName = textFromPtr(order.Buyer.Name)
+		params.UserPhoneNumber = textFromPtr(order.Buyer.PhoneNumber)
+		params.BuyerEmail = textFromPtr(order.Buyer.Email)
 	}
 
-	if order.UserPhoneNumber != nil {
-		params.UserPhoneNumber = pgtype.Text{
-			Valid:  true,
-			String: *order.UserPhoneNumber,
-		}
+	if order.Recipient != nil {
+		params.RecipientName = textFromPtr(order.Recipient.Name)
+		params.RecipientPhoneNumber = textFromPtr(order.Recipient.PhoneNumber)
 	}
 
 	if order.PaymentStatus != nil {
@@ -269,45 +421,65 @@ func (or *OrderRepository) UpdateOrder(ctx context.Context, order *repository.Up
 			String: *order.Status,
 		}
 	}
-	if order.ShippingAddress != nil {
-		params.ShippingAddress = pgtype.Text{
-			Valid:  true,
-			String: *order.ShippingAddress,
+
+	if order.DeliveryAddress != nil {
+		if err := validateCoordinates(order.DeliveryAddress.Latitude, order.DeliveryAddress.Longitude); err != nil {
+			return nil, err
 		}
+
+		params.AddressArea = textFromPtr(order.DeliveryAddress.Area)
+		params.AddressStreet = textFromPtr(order.DeliveryAddress.Street)
+		params.AddressBuilding = textFromPtr(order.DeliveryAddress.Building)
+		params.AddressLandmark = textFromPtr(order.DeliveryAddress.Landmark)
+		params.AddressLatitude = float8FromPtr(order.DeliveryAddress.Latitude)
+		params.AddressLongitude = float8FromPtr(order.DeliveryAddress.Longitude)
 	}
 
-	orderId, err := or.queries.UpdateOrder(ctx, params)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "order with ID %d not found", order.ID)
+	err := or.db.ExecTx(ctx, func(q *generated.Queries) error {
+		current, err := q.GetOrderByID(ctx, int64(order.ID))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "order with ID %d not found", order.ID)
+			}
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching order by id: %s", err.Error())
+		}
+
+		if _, err := q.UpdateOrder(ctx, params); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "order with ID %d not found", order.ID)
+			}
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error updating order: %s", err.Error())
+		}
+
+		if order.PaymentStatus != nil && *order.PaymentStatus && !current.PaymentStatus {
+			return releaseStock(ctx, q, current.ID, true, "order paid", order.UpdatedBy)
 		}
-		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error updating order: %s", err.Error())
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return or.GetOrderByID(ctx, orderId)
+	return or.GetOrderByID(ctx, int64(order.ID))
 }
 
 func (or *OrderRepository) ListOrders(ctx context.Context, filter *repository.OrderFilter) ([]*repository.Order, *pkg.Pagination, error) {
-	paramsListOrders := generated.ListOrderParams{
-		Limit:         int32(filter.Pagination.PageSize),
-		Offset:        pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
-		Search:        pgtype.Text{Valid: false},
-		PaymentStatus: pgtype.Bool{Valid: false},
-		Status:        pgtype.Text{Valid: false},
+	page, err := newListPage(filter.Pagination, defaultOrderSort, orderKeyset)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	paramsCountOrders := generated.ListCountOrderParams{
 		Search:        pgtype.Text{Valid: false},
 		PaymentStatus: pgtype.Bool{Valid: false},
 		Status:        pgtype.Text{Valid: false},
+		StartDate:     timestamptzFromPtr(filter.StartDate),
+		EndDate:       timestamptzFromPtr(filter.EndDate),
 	}
 
 	if filter.Search != nil {
 		search := strings.ToLower(*filter.Search)
-		paramsListOrders.Search = pgtype.Text{
-			Valid:  true,
-			String: "%" + search + "%",
-		}
 		paramsCountOrders.Search = pgtype.Text{
 			Valid:  true,
 			String: "%" + search + "%",
@@ -315,10 +487,6 @@ func (or *OrderRepository) ListOrders(ctx context.Context, filter *repository.Or
 	}
 
 	if filter.PaymentStatus != nil {
-		paramsListOrders.PaymentStatus = pgtype.Bool{
-			Valid: true,
-			Bool:  *filter.PaymentStatus,
-		}
 		paramsCountOrders.PaymentStatus = pgtype.Bool{
 			Valid: true,
 			Bool:  *filter.PaymentStatus,
@@ -327,53 +495,86 @@ func (or *OrderRepository) ListOrders(ctx context.Context, filter *repository.Or
 
 	if filter.Status != nil {
 		status := strings.ToLower(*filter.Status)
-		paramsListOrders.Status = pgtype.Text{
-			Valid:  true,
-			String: "%" + status + "%",
-		}
 		paramsCountOrders.Status = pgtype.Text{
 			Valid:  true,
 			String: "%" + status + "%",
 		}
 	}
 
-	generatedOrders, err := or.queries.ListOrder(ctx, paramsListOrders)
+	// the list takes the filters of the count in the same order
+	generatedOrders, err := listRows[orderListRow](ctx, or.db.pool, page, orderList,
+		paramsCountOrders.Search,
+		paramsCountOrders.PaymentStatus,
+		paramsCountOrders.Status,
+		paramsCountOrders.StartDate,
+		paramsCountOrders.EndDate,
+	)
 	if err != nil {
 		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing orders: %s", err.Error())
 	}
 
-	totalCount, err := or.queries.ListCountOrder(ctx, paramsCountOrders)
-	if err != nil {
-		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting orders: %s", err.Error())
+	var totalCount int64
+	if !filter.Pagination.SkipTotal {
+		totalCount, err = or.queries.ListCountOrder(ctx, paramsCountOrders)
+		if err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting orders: %s", err.Error())
+		}
 	}
 
+	generatedOrders, pagination := pageRows(generatedOrders, filter.Pagination, totalCount, func(row orderListRow) []string {
+		return row.SortKeys
+	})
+
 	orders := make([]*repository.Order, len(generatedOrders))
 	for i, order := range generatedOrders {
+		amounts, err := numericsToMoney(pkg.Currency(order.Currency), order.TotalAmount, order.NetAmount, order.TaxAmount)
+		if err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amounts for order %d: %s", order.ID, err.Error())
+		}
+
 		orders[i] = &repository.Order{
-			ID:              uint32(order.ID),
-			UserName:        order.UserName,
-			UserPhoneNumber: order.UserPhoneNumber,
-			TotalAmount:     pkg.PgTypeNumericToFloat64(order.TotalAmount),
-			PaymentStatus:   order.PaymentStatus,
-			Status:          order.Status,
-			ShippingAddress: &order.ShippingAddress.String,
-			DeletedAt:       &order.DeletedAt.Time,
-			CreatedAt:       order.CreatedAt,
+			ID:               uint32(order.ID),
+			Buyer:            buyerToRepo(order.UserName, order.UserPhoneNumber, order.BuyerEmail),
+			Recipient:        repository.Contact{Name: order.RecipientName, PhoneNumber: order.RecipientPhoneNumber},
+			TotalAmount:      amounts[0],
+			NetAmount:        amounts[1],
+			TaxAmount:        amounts[2],
+			Currency:         pkg.Currency(order.Currency),
+			PaymentStatus:    order.PaymentStatus,
+			Status:           order.Status,
+			DeliveryAddress:  deliveryAddressToRepo(order.AddressArea, order.AddressStreet, order.AddressBuilding, order.AddressLandmark, order.AddressLatitude, order.AddressLongitude),
+			PaymentReference: &order.PaymentReference.String,
+			DeletedAt:        &order.DeletedAt.Time,
+			CreatedAt:        order.CreatedAt,
 		}
 	}
 
-	return orders, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+	return orders, pagination, nil
 }
 
-func (or *OrderRepository) DeleteOrder(ctx context.Context, id int64) error {
-	if err := or.queries.DeleteOrder(ctx, id); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "order with ID %d not found", id)
+func (or *OrderRepository) DeleteOrder(ctx context.Context, id int64, userID uint32) error {
+	return or.db.ExecTx(ctx, func(q *generated.Queries) error {
+		order, err := q.GetOrderByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "order with ID %d not found", id)
+			}
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching order by id: %s", err.Error())
 		}
-		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting order by id: %s", err.Error())
-	}
 
-	return nil
+		// the stock of a sold order is only returned by a return adjustment
+		if !order.PaymentStatus && !order.DeletedAt.Valid {
+			if err := releaseStock(ctx, q, id, false, "order deleted", userID); err != nil {
+				return err
+			}
+		}
+
+		if err := q.DeleteOrder(ctx, id); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting order by id: %s", err.Error())
+		}
+
+		return nil
+	})
 }
 
 func (or *OrderRepository) GetOrderItemsByProductID(ctx context.Context, productID int64, filter *repository.OrderFilter) ([]*repository.OrderItem, *pkg.Pagination, error) {
@@ -401,12 +602,22 @@ func (or *OrderRepository) GetOrderItemsByProductID(ctx context.Context, product
 			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling order data: %s", err.Error())
 		}
 
+		amounts, err := numericsToMoney(orderData.Currency, item.Amount, item.NetAmount, item.TaxAmount)
+		if err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amounts for order item %d: %s", item.ID, err.Error())
+		}
+		orderData.TotalAmount = orderData.TotalAmount.WithCurrency(orderData.Currency)
+
 		orderItemList[i] = &repository.OrderItem{
 			ID:                    uint32(item.ID),
 			OrderID:               uint32(item.OrderID),
 			ProductID:             uint32(item.ProductID),
 			Quantity:              item.Quantity,
-			Amount:                pkg.PgTypeNumericToFloat64(item.Amount),
+			Amount:                amounts[0],
+			NetAmount:             amounts[1],
+			TaxAmount:             amounts[2],
+			TaxRateBps:            uint32(item.TaxRateBps),
+			ParentItemID:          uint32(item.ParentItemID.Int64),
 			OrderData:             &orderData,
 			CurrentProductDetails: nil,
 		}
@@ -414,3 +625,534 @@ func (or *OrderRepository) GetOrderItemsByProductID(ctx context.Context, product
 
 	return orderItemList, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
 }
+
+type pricedOrderItem struct {
+	product   generated.GetProductByIDRow
+	variant   *generated.ProductVariant
+	unitPrice pkg.Money
+	item      repository.OrderItem
+}
+
+// priceOrderItem prices an item from the product (or variant) price, with the
+// price rule in force at the given time applied, and splits the tax inclusive
+// amount into net and tax at the rate in effect at that time. Products with variants can only be ordered through one of them.
+func priceOrderItem(ctx context.Context, q *generated.Queries, currency pkg.Currency, item repository.OrderItem, at time.Time) (*pricedOrderItem, error) {
+	// a negative quantity would put stock back instead of taking it
+	if item.Quantity <= 0 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "quantity of product with id %d must be greater than 0", item.ProductID)
+	}
+
+	product, err := q.GetProductByID(ctx, int64(item.ProductID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with ID %d not found", item.ProductID)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching product by id: %s", err.Error())
+	}
+
+	var variant *generated.ProductVariant
+	if item.VariantID != 0 {
+		productVariant, err := q.GetProductVariantByID(ctx, int64(item.VariantID))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "variant with ID %d not found", item.VariantID)
+			}
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching variant by id: %s", err.Error())
+		}
+		if productVariant.ProductID != product.ID {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "variant with id %d is not a variant of product with id %d", item.VariantID, item.ProductID)
+		}
+		variant = &productVariant
+	} else if product.HasVariants {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "product with id %d has variants, variant_id is required", item.ProductID)
+	}
+
+	// quotes and orders are priced with the price rule in force at the time
+	product.Price, err = q.GetEffectivePrice(ctx, generated.GetEffectivePriceParams{
+		At:        at,
+		VariantID: pgtype.Int8{Int64: int64(item.VariantID), Valid: item.VariantID != 0},
+		ProductID: int64(item.ProductID),
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching price for product with id %d: %s", item.ProductID, err.Error())
+	}
+
+	if pkg.Currency(product.Currency) != currency {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "product with id %d is priced in %s but the order is in %s", item.ProductID, product.Currency, currency)
+	}
+
+	unitPrice, err := pkg.NumericToMoney(product.Price, currency)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for product with id %d: %s", item.ProductID, err.Error())
+	}
+
+	amount, err := unitPrice.Mul(int64(item.Quantity))
+	if err != nil {
+		return nil, err
+	}
+
+	rateBps, err := q.GetProductTaxRate(ctx, generated.GetProductTaxRateParams{
+		ProductID: int64(item.ProductID),
+		At:        at,
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching tax rate for product with id %d: %s", item.ProductID, err.Error())
+		}
+		// products without a tax class are not taxed
+		rateBps = 0
+	}
+
+	netAmount, taxAmount, err := pkg.SplitInclusiveTax(amount, uint32(rateBps))
+	if err != nil {
+		return nil, err
+	}
+
+	item.Amount = amount
+	item.NetAmount = netAmount
+	item.TaxAmount = taxAmount
+	item.TaxRateBps = uint32(rateBps)
+
+	return &pricedOrderItem{
+		product:   product,
+		variant:   variant,
+		unitPrice: unitPrice,
+		item:      item,
+	}, nil
+}
+
+// flattenOrderItems lists every item followed by the items attached to it and
+// returns, for each of them, the index of its parent or -1 for top level items.
+// Attached items are bought with their parent, so they take its payment method
+// and frequency.
+func flattenOrderItems(orderItems []repository.OrderItem) ([]repository.OrderItem, []int, error) {
+	items := make([]repository.OrderItem, 0, len(orderItems))
+	parents := make([]int, 0, len(orderItems))
+	for _, item := range orderItems {
+		parentIdx := len(items)
+		children := item.Children
+		item.Children = nil
+		items = append(items, item)
+		parents = append(parents, -1)
+
+		for _, child := range children {
+			if len(child.Children) > 0 {
+				return nil, nil, pkg.Errorf(pkg.INVALID_ERROR, "product with id %d is attached to another item and cannot have items of its own", child.ProductID)
+			}
+
+			child.PaymentMethod = item.PaymentMethod
+			child.Frequency = item.Frequency
+			items = append(items, child)
+			parents = append(parents, parentIdx)
+		}
+	}
+
+	return items, parents, nil
+}
+
+// nestOrderItems reverses flattenOrderItems.
+func nestOrderItems(items []repository.OrderItem, parents []int) []repository.OrderItem {
+	nested := make([]repository.OrderItem, 0, len(items))
+	positions := make([]int, len(items))
+	for idx, item := range items {
+		if parents[idx] < 0 {
+			positions[idx] = len(nested)
+			nested = append(nested, item)
+			continue
+		}
+
+		parent := &nested[positions[parents[idx]]]
+		parent.Children = append(parent.Children, item)
+	}
+
+	return nested
+}
+
+// nestStoredOrderItems nests stored items, ordered by id, under their parent.
+func nestStoredOrderItems(items []repository.OrderItem) []repository.OrderItem {
+	positions := make(map[uint32]int, len(items))
+	parents := make([]int, len(items))
+	for idx, item := range items {
+		positions[item.ID] = idx
+		parents[idx] = -1
+		if parentIdx, ok := positions[item.ParentItemID]; ok && item.ParentItemID != 0 {
+			parents[idx] = parentIdx
+		}
+	}
+
+	return nestOrderItems(items, parents)
+}
+
+// checkOrderItemNesting only lets add-ons and message cards be attached to an
+// item, and only to one that is neither of them.
+func checkOrderItemNesting(product generated.GetProductByIDRow, parents []int, idx int) error {
+	attachable := product.IsAddOn || product.IsMessageCard
+	if parents[idx] >= 0 && !attachable {
+		return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d is not an add-on or a message card and cannot be attached to another item", product.ID)
+	}
+
+	hasChildren := idx+1 < len(parents) && parents[idx+1] == idx
+	if hasChildren && attachable {
+		return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d is an add-on or a message card and cannot have items attached", product.ID)
+	}
+
+	return nil
+}
+
+// reserveStock takes an ordered item out of the stock of its variant, or of its
+// product when the variant has no stock of its own, and out of its batches
+// first expiring first. Items made from a recipe take their components out of
+// stock instead. The movements to record once the order exists are returned.
+func reserveStock(ctx context.Context, q *generated.Queries, priced *pricedOrderItem) ([]generated.CreateStockMovementParams, error) {
+	quantity := int64(priced.item.Quantity)
+	variantID := pgtype.Int8{Valid: false}
+	if priced.variant != nil {
+		variantID = pgtype.Int8{Valid: true, Int64: priced.variant.ID}
+	}
+
+	components, err := q.ListItemRecipeComponents(ctx, generated.ListItemRecipeComponentsParams{
+		ProductID: priced.product.ID,
+		VariantID: variantID,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing recipe of product with id %d: %s", priced.product.ID, err.Error())
+	}
+	if len(components) > 0 {
+		return reserveComponents(ctx, q, priced.product.ID, components, quantity)
+	}
+
+	movement := generated.CreateStockMovementParams{
+		ProductID:    priced.product.ID,
+		VariantID:    pgtype.Int8{Valid: false},
+		Quantity:     -quantity,
+		UserID:       pgtype.Int8{Valid: false},
+		StockBatchID: pgtype.Int8{Valid: false},
+	}
+
+	if variant := priced.variant; variant != nil && variant.StockQuantity.Valid {
+		movement.VariantID = variantID
+	}
+
+	// the stock read with the product is not locked, only what the update
+	// actually took tells whether another order got to it first
+	movements, taken, err := takeStock(ctx, q, movement)
+	if err != nil {
+		return nil, err
+	}
+	if taken != quantity {
+		if movement.VariantID.Valid {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "variant with id %d has stock_quantity of %d and trying to make an order of stock_quantity %d. Need to add stock first", movement.VariantID.Int64, taken, quantity)
+		}
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "product with id %d has stock_quantity of %d and trying to make an order of stock_quantity %d. Need to add stock first", movement.ProductID, taken, quantity)
+	}
+
+	return movements, nil
+}
+
+// reserveComponents takes the components of quantity of a product made from a
+// recipe out of stock.
+func reserveComponents(ctx context.Context, q *generated.Queries, productID int64, components []generated.ListItemRecipeComponentsRow, quantity int64) ([]generated.CreateStockMovementParams, error) {
+	movements := make([]generated.CreateStockMovementParams, 0, len(components))
+	for _, component := range components {
+		needed := quantity * component.Quantity
+		movement := generated.CreateStockMovementParams{
+			ProductID:    component.ComponentProductID,
+			VariantID:    pgtype.Int8{Valid: false},
+			Quantity:     -needed,
+			UserID:       pgtype.Int8{Valid: false},
+			StockBatchID: pgtype.Int8{Valid: false},
+		}
+
+		// a component variant that no longer has stock of its own draws on its product's
+		if component.VariantStockQuantity.Valid {
+			movement.VariantID = component.ComponentVariantID
+		}
+
+		taken, takenQuantity, err := takeStock(ctx, q, movement)
+		if err != nil {
+			return nil, err
+		}
+		if takenQuantity != needed {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "component product with id %d of product with id %d has stock_quantity of %d and the order needs %d. Need to add stock first", component.ComponentProductID, productID, takenQuantity, needed)
+		}
+		movements = append(movements, taken...)
+	}
+
+	return movements, nil
+}
+
+// takeStock takes what a movement reserves out of the stock of its product or
+// variant and out of its batches, and returns the movement split by the batch
+// each part came from so a release can put it back. The quantity actually
+// taken is returned too, less than reserved when there was not enough in
+// stock, in which case the caller must fail the transaction as the stock is
+// left at 0.
+func takeStock(ctx context.Context, q *generated.Queries, movement generated.CreateStockMovementParams) ([]generated.CreateStockMovementParams, int64, error) {
+	quantity := -movement.Quantity
+	added, err := addStock(ctx, q, movement.ProductID, movement.VariantID, movement.Quantity)
+	if err != nil {
+		return nil, 0, err
+	}
+	if -added != quantity {
+		return nil, -added, nil
+	}
+
+	allocations, err := allocateStockBatches(ctx, q, movement.ProductID, movement.VariantID, quantity)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	movements := make([]generated.CreateStockMovementParams, 0, len(allocations)+1)
+	unbatched := quantity
+	for _, allocation := range allocations {
+		batchMovement := movement
+		batchMovement.Quantity = -allocation.Quantity
+		batchMovement.StockBatchID = pgtype.Int8{Valid: true, Int64: allocation.ID}
+		movements = append(movements, batchMovement)
+		unbatched -= allocation.Quantity
+	}
+	if unbatched > 0 {
+		movement.Quantity = -unbatched
+		movements = append(movements, movement)
+	}
+
+	return movements, quantity, nil
+}
+
+// releaseStock puts the stock an order still has reserved back into stock, and
+// into the batches it was allocated from, or when sold is set records it as
+// sold.
+func releaseStock(ctx context.Context, q *generated.Queries, orderID int64, sold bool, reason string, userID uint32) error {
+	orderIDParam := pgtype.Int8{Valid: true, Int64: orderID}
+	reservations, err := q.ListOrderStockReservations(ctx, orderIDParam)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error listing stock reserved by order %d: %s", orderID, err.Error())
+	}
+
+	for _, reservation := range reservations {
+		release := generated.CreateStockMovementParams{
+			ProductID:    reservation.ProductID,
+			VariantID:    reservation.VariantID,
+			Kind:         repository.StockMovementRelease,
+			Quantity:     reservation.Quantity,
+			Reason:       reason,
+			UserID:       optionalID(userID),
+			OrderID:      orderIDParam,
+			StockBatchID: reservation.StockBatchID,
+		}
+
+		if !sold {
+			added, err := addStock(ctx, q, release.ProductID, release.VariantID, release.Quantity)
+			if err != nil {
+				return err
+			}
+			release.Quantity = added
+			if err := recordStockMovement(ctx, q, release); err != nil {
+				return err
+			}
+
+			// a variant that no longer has stock of its own takes nothing back
+			if release.StockBatchID.Valid && added > 0 {
+				if err := q.ReleaseStockBatch(ctx, generated.ReleaseStockBatchParams{Quantity: added, ID: release.StockBatchID.Int64}); err != nil {
+					return pkg.Errorf(pkg.INTERNAL_ERROR, "error releasing stock batch with id %d: %s", release.StockBatchID.Int64, err.Error())
+				}
+			}
+			continue
+		}
+
+		// the stock doesn't move, only the reservation turns into a sale
+		if err := recordStockMovement(ctx, q, release); err != nil {
+			return err
+		}
+		sale := release
+		sale.Kind = repository.StockMovementSale
+		sale.Quantity = -reservation.Quantity
+		if err := recordStockMovement(ctx, q, sale); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newOrderQuote(currency pkg.Currency, size int) *repository.OrderQuote {
+	return &repository.OrderQuote{
+		Currency:    currency,
+		NetAmount:   pkg.NewMoney(0, currency),
+		TaxAmount:   pkg.NewMoney(0, currency),
+		TotalAmount: pkg.NewMoney(0, currency),
+		Items:       make([]repository.OrderItem, 0, size),
+	}
+}
+
+func addQuoteItem(quote *repository.OrderQuote, item repository.OrderItem) error {
+	var err error
+	if quote.NetAmount, err = quote.NetAmount.Add(item.NetAmount); err != nil {
+		return err
+	}
+	if quote.TaxAmount, err = quote.TaxAmount.Add(item.TaxAmount); err != nil {
+		return err
+	}
+	if quote.TotalAmount, err = quote.TotalAmount.Add(item.Amount); err != nil {
+		return err
+	}
+	quote.Items = append(quote.Items, item)
+
+	return nil
+}
+
+func numericsToMoney(currency pkg.Currency, values ...pgtype.Numeric) ([]pkg.Money, error) {
+	amounts := make([]pkg.Money, len(values))
+	for i, value := range values {
+		amount, err := pkg.NumericToMoney(value, currency)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = amount
+	}
+
+	return amounts, nil
+}
+
+const (
+	maxCardMessageLength = 250
+	maxCardMessageLines  = 8
+	maxCardNameLength    = 100
+)
+
+// normalizeMessageCard requires a card on message card products and rejects it
+// anywhere else. It returns the trimmed card, without the sender's name when
+// the card is anonymous.
+func normalizeMessageCard(isMessageCard bool, item repository.OrderItem) (*repository.MessageCard, error) {
+	if !isMessageCard {
+		if item.MessageCard != nil {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "product with id %d is not a message card and cannot carry a message", item.ProductID)
+		}
+		return nil, nil
+	}
+
+	if item.MessageCard == nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "message card product with id %d requires a message_card", item.ProductID)
+	}
+
+	card := repository.MessageCard{
+		Message:       strings.TrimSpace(item.MessageCard.Message),
+		SenderName:    strings.TrimSpace(item.MessageCard.SenderName),
+		RecipientName: strings.TrimSpace(item.MessageCard.RecipientName),
+		Anonymous:     item.MessageCard.Anonymous,
+	}
+
+	if card.Message == "" {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "message card for product with id %d has no message", item.ProductID)
+	}
+	if utf8.RuneCountInString(card.Message) > maxCardMessageLength {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "message card message cannot be longer than %d characters", maxCardMessageLength)
+	}
+	if strings.Count(card.Message, "\n")+1 > maxCardMessageLines {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "message card message cannot have more than %d lines", maxCardMessageLines)
+	}
+	if utf8.RuneCountInString(card.SenderName) > maxCardNameLength || utf8.RuneCountInString(card.RecipientName) > maxCardNameLength {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "message card names cannot be longer than %d characters", maxCardNameLength)
+	}
+
+	if card.Anonymous {
+		card.SenderName = ""
+	} else if card.SenderName == "" {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "message card for product with id %d needs a sender_name unless it is anonymous", item.ProductID)
+	}
+
+	return &card, nil
+}
+
+func buyerToRepo(name, phoneNumber string, email pgtype.Text) repository.Contact {
+	buyer := repository.Contact{
+		Name:        name,
+		PhoneNumber: phoneNumber,
+		Email:       nil,
+	}
+
+	if email.Valid {
+		buyer.Email = &email.String
+	}
+
+	return buyer
+}
+
+func deliveryAddressToRepo(area string, street, building, landmark pgtype.Text, latitude, longitude pgtype.Float8) repository.Address {
+	address := repository.Address{
+		Area: area,
+	}
+
+	if street.Valid {
+		address.Street = &street.String
+	}
+
+	if building.Valid {
+		address.Building = &building.String
+	}
+
+	if landmark.Valid {
+		address.Landmark = &landmark.String
+	}
+
+	if latitude.Valid && longitude.Valid {
+		address.Latitude = &latitude.Float64
+		address.Longitude = &longitude.Float64
+	}
+
+	return address
+}
+
+// validateCoordinates makes sure a pin is dropped with both coordinates and
+// within range. The columns carry the same range checks.
+func validateCoordinates(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return pkg.Errorf(pkg.INVALID_ERROR, "latitude and longitude must be set together")
+	}
+
+	if latitude == nil {
+		return nil
+	}
+
+	if *latitude < -90 || *latitude > 90 {
+		return pkg.Errorf(pkg.INVALID_ERROR, "latitude must be between -90 and 90")
+	}
+
+	if *longitude < -180 || *longitude > 180 {
+		return pkg.Errorf(pkg.INVALID_ERROR, "longitude must be between -180 and 180")
+	}
+
+	return nil
+}
+
+func textFromPtr(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{Valid: false}
+	}
+
+	return pgtype.Text{Valid: true, String: *s}
+}
+
+func float8FromPtr(f *float64) pgtype.Float8 {
+	if f == nil {
+		return pgtype.Float8{Valid: false}
+	}
+
+	return pgtype.Float8{Valid: true, Float64: *f}
+}
+
+func int8FromPtr(i *int64) pgtype.Int8 {
+	if i == nil {
+		return pgtype.Int8{Valid: false}
+	}
+
+	return pgtype.Int8{Valid: true, Int64: *i}
+}
+
+func timestamptzFromPtr(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{Valid: false}
+	}
+
+	return pgtype.Timestamptz{Valid: true, Time: *t}
+}
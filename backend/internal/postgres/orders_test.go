@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+func TestQuoteOrderRejectsQuantityBelowOne(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	product := createTestProduct(t, store, 5)
+	orders := NewOrderRepository(store)
+
+	for _, quantity := range []int32{0, -1} {
+		_, err := orders.QuoteOrder(ctx, pkg.KES, []repository.OrderItem{{
+			ProductID:     uint32(product.ID),
+			PaymentMethod: "normal",
+			Quantity:      quantity,
+		}})
+		if pkg.ErrorCode(err) != pkg.INVALID_ERROR {
+			t.Errorf("QuoteOrder of quantity %d error = %v, want an %s error", quantity, err, pkg.INVALID_ERROR)
+		}
+	}
+
+	quote, err := orders.QuoteOrder(ctx, pkg.KES, []repository.OrderItem{{
+		ProductID:     uint32(product.ID),
+		PaymentMethod: "normal",
+		Quantity:      2,
+	}})
+	if err != nil {
+		t.Fatalf("QuoteOrder: %v", err)
+	}
+	if want := pkg.NewMoney(2000, pkg.KES); !quote.TotalAmount.Equal(want) {
+		t.Errorf("quote total = %s, want %s", quote.TotalAmount, want)
+	}
+}
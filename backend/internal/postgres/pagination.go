@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5"
+)
+
+// sortColumn is a column a list is ordered by, with the type its text form is
+// cast back to when compared against a cursor.
+type sortColumn struct {
+	expr    string
+	sqlType string
+}
+
+// keyset maps the sort fields of a list query to the columns they order by.
+// Amounts expand to the currency and the amount, since amounts in different
+// currencies don't compare. id is the unique column that ends every order.
+type keyset struct {
+	id     string
+	fields map[string][]sortColumn
+}
+
+type pageColumn struct {
+	sortColumn
+	desc bool
+}
+
+// listPage holds the order, keyset and limits of a list query, see listQuery.
+type listPage struct {
+	columns []pageColumn
+	cursor  []string
+	Offset  int32
+	Limit   int32
+}
+
+// newListPage resolves the pagination against the list's keyset, falling back
+// to defaultSort. One row more than the page size is fetched to tell whether a
+// next page exists.
+func newListPage(pagination *pkg.Pagination, defaultSort pkg.Sort, keys keyset) (listPage, error) {
+	// an empty page would fetch the one extra row and never a cursor
+	if pagination.PageSize == 0 {
+		return listPage{}, pkg.Errorf(pkg.INVALID_ERROR, "limit must be greater than 0")
+	}
+	if pagination.Page == 0 && pagination.Cursor == nil {
+		return listPage{}, pkg.Errorf(pkg.INVALID_ERROR, "page must be greater than 0")
+	}
+
+	sort := pagination.Sort
+	if len(sort) == 0 {
+		sort = defaultSort
+	}
+
+	page := listPage{
+		Offset: pkg.Offset(pagination.Page, pagination.PageSize),
+		Limit:  int32(pagination.PageSize) + 1,
+	}
+
+	for _, field := range sort {
+		columns, ok := keys.fields[field.Name]
+		if !ok {
+			return listPage{}, pkg.Errorf(pkg.INVALID_ERROR, "cannot sort by %q", field.Name)
+		}
+
+		for _, column := range columns {
+			page.columns = append(page.columns, pageColumn{sortColumn: column, desc: field.Desc})
+		}
+	}
+
+	page.columns = append(page.columns, pageColumn{
+		sortColumn: sortColumn{expr: keys.id, sqlType: "bigint"},
+		desc:       sort[len(sort)-1].Desc,
+	})
+
+	if cursor := pagination.Cursor; cursor != nil {
+		if len(cursor.Keys) != len(page.columns) {
+			return listPage{}, pkg.Errorf(pkg.INVALID_ERROR, "invalid cursor")
+		}
+
+		page.cursor = cursor.Keys
+		page.Offset = 0
+	}
+
+	return page, nil
+}
+
+// sql completes query with the sort keys, keyset, order and limits of page.
+// args are the values of the query's own parameters, the cursor and limits
+// are appended to them.
+func (page listPage) sql(query listQuery, args []interface{}) (string, []interface{}) {
+	keys := make([]string, len(page.columns))
+	order := make([]string, len(page.columns))
+	for i, column := range page.columns {
+		keys[i] = column.expr + "::text"
+		order[i] = column.expr
+		if column.desc {
+			order[i] += " DESC"
+		}
+	}
+
+	predicate := "TRUE"
+	if page.cursor != nil {
+		predicate, args = page.predicate(0, args)
+	}
+
+	args = append(args, page.Limit, page.Offset)
+
+	var sql strings.Builder
+	sql.WriteString("SELECT\n    " + query.columns + ",\n    ARRAY[" + strings.Join(keys, ", ") + "] AS sort_keys\n")
+	sql.WriteString(query.from + "\n")
+	sql.WriteString("    AND " + predicate + "\n")
+	sql.WriteString("ORDER BY " + strings.Join(order, ", ") + "\n")
+	sql.WriteString(fmt.Sprintf("LIMIT $%d OFFSET $%d", len(args)-1, len(args)))
+
+	return sql.String(), args
+}
+
+// predicate compares the columns from the i-th on with the cursor. Columns
+// sorted the same way are compared as one row value, e.g.
+// (created_at, id) < ($1, $2), so the matching index can serve the page.
+func (page listPage) predicate(i int, args []interface{}) (string, []interface{}) {
+	end := i + 1
+	for end < len(page.columns) && page.columns[end].desc == page.columns[i].desc {
+		end++
+	}
+
+	columns := make([]string, 0, end-i)
+	values := make([]string, 0, end-i)
+	for j := i; j < end; j++ {
+		args = append(args, page.cursor[j])
+		columns = append(columns, page.columns[j].expr)
+		values = append(values, fmt.Sprintf("$%d::text::%s", len(args), page.columns[j].sqlType))
+	}
+
+	op := ">"
+	if page.columns[i].desc {
+		op = "<"
+	}
+
+	row := "(" + strings.Join(columns, ", ") + ")"
+	cursor := "(" + strings.Join(values, ", ") + ")"
+	if end == len(page.columns) {
+		return fmt.Sprintf("%s %s %s", row, op, cursor), args
+	}
+
+	rest, args := page.predicate(end, args)
+
+	return fmt.Sprintf("(%s %s %s OR (%s = %s AND %s))", row, op, cursor, row, cursor, rest), args
+}
+
+// listRows runs query paged by page and scans its rows into T, whose fields
+// are the columns of the query in order followed by the sort keys.
+func listRows[T any](ctx context.Context, db generated.DBTX, page listPage, query listQuery, args ...interface{}) ([]T, error) {
+	sql, args := page.sql(query, args)
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[T])
+}
+
+// pageRows drops the extra row fetched by newListPage and builds the
+// response pagination, with a cursor for the next page when there is one.
+func pageRows[T any](rows []T, pagination *pkg.Pagination, total int64, sortKeys func(T) []string) ([]T, *pkg.Pagination) {
+	result := pkg.CalculatePagination(uint32(total), pagination.PageSize, pagination.Page)
+	if pagination.SkipTotal {
+		result = &pkg.Pagination{PageSize: pagination.PageSize}
+	}
+
+	if len(rows) <= int(pagination.PageSize) {
+		if pagination.Cursor != nil {
+			result.HasNext = false
+		}
+
+		return rows, result
+	}
+
+	rows = rows[:pagination.PageSize]
+	result.NextCursor = pkg.Cursor{
+		Sort: pagination.Sort.String(),
+		Keys: sortKeys(rows[len(rows)-1]),
+	}.Encode()
+
+	if pagination.Cursor != nil || pagination.SkipTotal {
+		result.HasNext = true
+	}
+
+	return rows, result
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+var testKeyset = keyset{
+	id: "t.id",
+	fields: map[string][]sortColumn{
+		"created_at": {{"t.created_at", "timestamptz"}},
+		"price":      {{"t.currency", "text"}, {"t.price", "numeric"}},
+	},
+}
+
+var testQuery = listQuery{
+	columns: "t.id, t.name",
+	from:    "FROM things t\nWHERE\n    t.name LIKE $1",
+}
+
+func TestNewListPage(t *testing.T) {
+	defaultSort := pkg.Sort{{Name: "created_at", Desc: true}}
+
+	tests := []struct {
+		name       string
+		pagination pkg.Pagination
+		wantErr    bool
+	}{
+		{name: "default sort", pagination: pkg.Pagination{Page: 1, PageSize: 10}},
+		{name: "cursor", pagination: pkg.Pagination{PageSize: 10, Sort: pkg.Sort{{Name: "price"}}, Cursor: &pkg.Cursor{Keys: []string{"KES", "10.00", "7"}}}},
+		{name: "zero page size", pagination: pkg.Pagination{Page: 1}, wantErr: true},
+		{name: "zero page", pagination: pkg.Pagination{PageSize: 10}, wantErr: true},
+		{name: "unknown field", pagination: pkg.Pagination{Page: 1, PageSize: 10, Sort: pkg.Sort{{Name: "stock"}}}, wantErr: true},
+		{name: "cursor of another keyset", pagination: pkg.Pagination{PageSize: 10, Sort: pkg.Sort{{Name: "price"}}, Cursor: &pkg.Cursor{Keys: []string{"10.00", "7"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		_, err := newListPage(&tt.pagination, defaultSort, testKeyset)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: newListPage succeeded, want an error", tt.name)
+			} else if pkg.ErrorCode(err) != pkg.INVALID_ERROR {
+				t.Errorf("%s: newListPage error code = %s, want %s", tt.name, pkg.ErrorCode(err), pkg.INVALID_ERROR)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: newListPage unexpected error: %v", tt.name, err)
+		}
+	}
+}
+
+func TestListPageSQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination pkg.Pagination
+		keyset     string
+		order      string
+		args       []interface{}
+	}{
+		{
+			name:       "first page",
+			pagination: pkg.Pagination{Page: 3, PageSize: 10},
+			keyset:     "TRUE",
+			order:      "t.created_at DESC, t.id DESC",
+			args:       []interface{}{"%a%", int32(11), int32(20)},
+		},
+		{
+			name:       "same direction columns compare as one row",
+			pagination: pkg.Pagination{PageSize: 10, Sort: pkg.Sort{{Name: "price"}}, Cursor: &pkg.Cursor{Keys: []string{"KES", "10.00", "7"}}},
+			keyset:     "(t.currency, t.price, t.id) > ($2::text::text, $3::text::numeric, $4::text::bigint)",
+			order:      "t.currency, t.price, t.id",
+			args:       []interface{}{"%a%", "KES", "10.00", "7", int32(11), int32(0)},
+		},
+		{
+			name:       "mixed directions",
+			pagination: pkg.Pagination{PageSize: 5, Sort: pkg.Sort{{Name: "price", Desc: true}, {Name: "created_at"}}, Cursor: &pkg.Cursor{Keys: []string{"KES", "10.00", "2024-01-02", "7"}}},
+			keyset:     "((t.currency, t.price) < ($2::text::text, $3::text::numeric) OR ((t.currency, t.price) = ($2::text::text, $3::text::numeric) AND (t.created_at, t.id) > ($4::text::timestamptz, $5::text::bigint)))",
+			order:      "t.currency DESC, t.price DESC, t.created_at, t.id",
+			args:       []interface{}{"%a%", "KES", "10.00", "2024-01-02", "7", int32(6), int32(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		page, err := newListPage(&tt.pagination, pkg.Sort{{Name: "created_at", Desc: true}}, testKeyset)
+		if err != nil {
+			t.Errorf("%s: newListPage unexpected error: %v", tt.name, err)
+			continue
+		}
+
+		sql, args := page.sql(testQuery, []interface{}{"%a%"})
+
+		limits := len(tt.args)
+		want := "SELECT\n    t.id, t.name,\n    ARRAY[" + sortKeys(tt.order) + "] AS sort_keys\n" +
+			testQuery.from + "\n" +
+			"    AND " + tt.keyset + "\n" +
+			"ORDER BY " + tt.order + "\n" +
+			"LIMIT $" + strconv.Itoa(limits-1) + " OFFSET $" + strconv.Itoa(limits)
+		if sql != want {
+			t.Errorf("%s: sql =\n%s\nwant\n%s", tt.name, sql, want)
+		}
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: args = %#v, want %#v", tt.name, args, tt.args)
+		}
+	}
+}
+
+// sortKeys turns an ORDER BY list into the text sort keys selected with it.
+func sortKeys(order string) string {
+	columns := strings.Split(order, ", ")
+	for i, column := range columns {
+		columns[i] = strings.TrimSuffix(column, " DESC") + "::text"
+	}
+
+	return strings.Join(columns, ", ")
+}
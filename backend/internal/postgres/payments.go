@@ -14,18 +14,31 @@ import (
 
 var _ repository.PaymentRepository = (*PaymentRepository)(nil)
 
+var defaultPaymentSort = pkg.Sort{{Name: "paid_at", Desc: true}}
+
+var paymentKeyset = keyset{id: "payments.id", fields: map[string][]sortColumn{
+	"paid_at":    {{"payments.paid_at", "timestamptz"}},
+	"created_at": {{"payments.created_at", "timestamptz"}},
+	"amount":     {{"payments.currency", "text"}, {"payments.amount", "numeric"}},
+}}
+
 type PaymentRepository struct {
 	queries *generated.Queries
+	db      *Store
 }
 
-func NewPaymentRepository(queries *generated.Queries) *PaymentRepository {
-	return &PaymentRepository{queries: queries}
+func NewPaymentRepository(db *Store) *PaymentRepository {
+	return &PaymentRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
 }
 
 func (pr *PaymentRepository) CreatePayment(ctx context.Context, payment *repository.Payment) (*repository.Payment, error) {
 	params := generated.CreatePaymentParams{
-		Amount:             pkg.Float64ToPgTypeNumeric(payment.Amount),
+		Amount:             payment.Amount.Numeric(),
 		PaymentMethod:      payment.PaymentMethod,
+		Currency:           string(payment.Currency),
 		PaidAt:             payment.PaidAt,
 		OrderID:            pgtype.Int8{Valid: false},
 		Description:        pgtype.Text{Valid: false},
@@ -71,13 +84,19 @@ func (pr *PaymentRepository) GetPaymentByID(ctx context.Context, id int64) (*rep
 		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching payment by id: %s", err.Error())
 	}
 
+	amount, err := pkg.NumericToMoney(generatedPayment.Amount, pkg.Currency(generatedPayment.Currency))
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amount for payment %d: %s", id, err.Error())
+	}
+
 	payment := &repository.Payment{
 		ID:                 uint32(generatedPayment.ID),
 		Description:        nil,
 		OrderID:            nil,
 		UserSubscriptionID: nil,
 		PaymentMethod:      generatedPayment.PaymentMethod,
-		Amount:             pkg.PgTypeNumericToFloat64(generatedPayment.Amount),
+		Amount:             amount,
+		Currency:           amount.Currency(),
 		PaidAt:             generatedPayment.PaidAt,
 		CreatedAt:          generatedPayment.CreatedAt,
 	}
@@ -119,7 +138,7 @@ func (pr *PaymentRepository) UpdatePayment(ctx context.Context, payment *reposit
 		}
 	}
 	if payment.Amount != nil {
-		params.Amount = pkg.Float64ToPgTypeNumeric(*payment.Amount)
+		params.Amount = payment.Amount.Numeric()
 	}
 	if payment.PaidAt != nil {
 		params.PaidAt = pgtype.Timestamptz{
@@ -140,12 +159,9 @@ func (pr *PaymentRepository) UpdatePayment(ctx context.Context, payment *reposit
 }
 
 func (pr *PaymentRepository) ListPayments(ctx context.Context, filter *repository.PaymentFilter) ([]*repository.Payment, *pkg.Pagination, error) {
-	paramsListPayments := generated.ListPaymentsParams{
-		Limit:         int32(filter.Pagination.PageSize),
-		Offset:        pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
-		PaymentMethod: pgtype.Text{Valid: false},
-		StartDate:     pgtype.Timestamptz{Valid: false},
-		EndDate:       pgtype.Timestamptz{Valid: false},
+	page, err := newListPage(filter.Pagination, defaultPaymentSort, paymentKeyset)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	paramsCountPayments := generated.ListCountPaymentsParams{
@@ -156,25 +172,12 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, filter *repositor
 
 	if filter.PaymentMethod != nil {
 		search := strings.ToLower(*filter.PaymentMethod)
-		paramsListPayments.PaymentMethod = pgtype.Text{
-			Valid:  true,
-			String: "%" + search + "%",
-		}
 		paramsCountPayments.PaymentMethod = pgtype.Text{
 			Valid:  true,
 			String: "%" + search + "%",
 		}
 	}
 	if filter.StartDate != nil && filter.EndDate != nil {
-		paramsListPayments.StartDate = pgtype.Timestamptz{
-			Valid: true,
-			Time:  *filter.StartDate,
-		}
-		paramsListPayments.EndDate = pgtype.Timestamptz{
-			Valid: true,
-			Time:  *filter.EndDate,
-		}
-
 		paramsCountPayments.StartDate = pgtype.Timestamptz{
 			Valid: true,
 			Time:  *filter.StartDate,
@@ -184,25 +187,44 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, filter *repositor
 			Time:  *filter.EndDate,
 		}
 	}
-	generatedPayments, err := pr.queries.ListPayments(ctx, paramsListPayments)
+
+	// the list takes the filters of the count in the same order
+	generatedPayments, err := listRows[paymentListRow](ctx, pr.db.pool, page, paymentList,
+		paramsCountPayments.PaymentMethod,
+		paramsCountPayments.StartDate,
+		paramsCountPayments.EndDate,
+	)
 	if err != nil {
 		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing payments: %s", err.Error())
 	}
 
-	totalCount, err := pr.queries.ListCountPayments(ctx, paramsCountPayments)
-	if err != nil {
-		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting payments: %s", err.Error())
+	var totalCount int64
+	if !filter.Pagination.SkipTotal {
+		totalCount, err = pr.queries.ListCountPayments(ctx, paramsCountPayments)
+		if err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting payments: %s", err.Error())
+		}
 	}
 
+	generatedPayments, pagination := pageRows(generatedPayments, filter.Pagination, totalCount, func(row paymentListRow) []string {
+		return row.SortKeys
+	})
+
 	payments := make([]*repository.Payment, len(generatedPayments))
 	for i, gp := range generatedPayments {
+		amount, err := pkg.NumericToMoney(gp.Amount, pkg.Currency(gp.Currency))
+		if err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid amount for payment %d: %s", gp.ID, err.Error())
+		}
+
 		payments[i] = &repository.Payment{
 			ID:                 uint32(gp.ID),
 			Description:        nil,
 			OrderID:            nil,
 			UserSubscriptionID: nil,
 			PaymentMethod:      gp.PaymentMethod,
-			Amount:             pkg.PgTypeNumericToFloat64(gp.Amount),
+			Amount:             amount,
+			Currency:           amount.Currency(),
 			PaidAt:             gp.PaidAt,
 			CreatedAt:          gp.CreatedAt,
 		}
@@ -220,5 +242,5 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, filter *repositor
 		}
 	}
 
-	return payments, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+	return payments, pagination, nil
 }
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
@@ -23,11 +25,12 @@ func NewPaystackRepository(queries *generated.Queries) *PaystackRepository {
 	}
 }
 
-func (ps *PaystackRepository) CreatePayment(ctx context.Context, email string, amount int64, reference string) error {
+func (ps *PaystackRepository) CreatePayment(ctx context.Context, email string, amount pkg.Money, reference string) error {
 	if err := ps.queries.CreatePaystackPayment(ctx, generated.CreatePaystackPaymentParams{
 		Email:     email,
-		Amount:    fmt.Sprintf("%d", amount),
+		Amount:    fmt.Sprintf("%d", amount.MinorUnits()),
 		Reference: reference,
+		Currency:  string(amount.Currency()),
 	}); err != nil {
 		return pkg.Errorf(pkg.INTERNAL_ERROR, "failed to create paystack payment: %s", err.Error())
 	}
@@ -43,6 +46,7 @@ func (ps *PaystackRepository) GetPaymentByReference(ctx context.Context, referen
 		ID:        payment.ID,
 		Email:     payment.Email,
 		Amount:    payment.Amount,
+		Currency:  pkg.Currency(payment.Currency),
 		Reference: payment.Reference,
 		Status:    payment.Status,
 		CreatedAt: payment.CreatedAt,
@@ -88,6 +92,7 @@ func (ps *PaystackRepository) ListPaystackPayments(ctx context.Context, status s
 			ID:        p.ID,
 			Email:     p.Email,
 			Amount:    p.Amount,
+			Currency:  pkg.Currency(p.Currency),
 			Reference: p.Reference,
 			Status:    p.Status,
 			CreatedAt: p.CreatedAt,
@@ -145,3 +150,22 @@ func (ps *PaystackRepository) ListPaystackEvents(ctx context.Context, event stri
 
 	return result, pkg.CalculatePagination(uint32(totalCount), pagination.PageSize, pagination.Page), nil
 }
+
+func (ps *PaystackRepository) GetPaystackEvent(ctx context.Context, id int64) (repository.PaystackEvent, error) {
+	e, err := ps.queries.GetPaystackEventByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.PaystackEvent{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "paystack event with id %d not found", id)
+		}
+		return repository.PaystackEvent{}, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to get paystack event: %s", err.Error())
+	}
+
+	event := repository.PaystackEvent{
+		ID:        e.ID,
+		Event:     e.Event,
+		CreatedAt: e.CreatedAt,
+	}
+	json.Unmarshal(e.Data, &event.Data)
+
+	return event, nil
+}
@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.PriceRuleRepository = (*PriceRuleRepository)(nil)
+
+type PriceRuleRepository struct {
+	queries *generated.Queries
+}
+
+func NewPriceRuleRepository(queries *generated.Queries) *PriceRuleRepository {
+	return &PriceRuleRepository{queries: queries}
+}
+
+func (prr *PriceRuleRepository) CreatePriceRule(ctx context.Context, priceRule *repository.PriceRule) (*repository.PriceRule, error) {
+	scopes := 0
+	for _, id := range []*uint32{priceRule.ProductID, priceRule.CategoryID, priceRule.TagID} {
+		if id != nil {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "a price rule is scoped to exactly one of a product, a category or a tag")
+	}
+	if priceRule.VariantID != nil && priceRule.ProductID == nil {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "a variant price rule needs the variant's product_id")
+	}
+	if priceRule.EndsAt != nil && !priceRule.EndsAt.After(priceRule.StartsAt) {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "ends_at must be after starts_at")
+	}
+
+	params := generated.CreatePriceRuleParams{
+		Name:       priceRule.Name,
+		Kind:       priceRule.Kind,
+		Price:      pgtype.Numeric{Valid: false},
+		Currency:   pgtype.Text{Valid: false},
+		UpliftBps:  pgtype.Int4{Valid: false},
+		ProductID:  pgtype.Int8{Valid: false},
+		VariantID:  pgtype.Int8{Valid: false},
+		CategoryID: pgtype.Int8{Valid: false},
+		TagID:      pgtype.Int8{Valid: false},
+		StartsAt:   priceRule.StartsAt,
+		EndsAt:     pgtype.Timestamptz{Valid: false},
+		Priority:   priceRule.Priority,
+	}
+
+	hasVariants := false
+	if priceRule.ProductID != nil {
+		product, err := prr.queries.GetProductByID(ctx, int64(*priceRule.ProductID))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with id %d not found", *priceRule.ProductID)
+			}
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching product by id: %s", err.Error())
+		}
+		params.ProductID = pgtype.Int8{Valid: true, Int64: product.ID}
+		hasVariants = product.HasVariants
+		// absolute prices are in the product's currency
+		params.Currency = pgtype.Text{Valid: true, String: product.Currency}
+
+		if priceRule.VariantID != nil {
+			variant, err := prr.queries.GetProductVariantByID(ctx, int64(*priceRule.VariantID))
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "variant with id %d not found", *priceRule.VariantID)
+				}
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching variant by id: %s", err.Error())
+			}
+			if variant.ProductID != product.ID {
+				return nil, pkg.Errorf(pkg.INVALID_ERROR, "variant with id %d is not a variant of product with id %d", *priceRule.VariantID, *priceRule.ProductID)
+			}
+			params.VariantID = pgtype.Int8{Valid: true, Int64: variant.ID}
+		}
+	}
+	if priceRule.CategoryID != nil {
+		if exists, _ := prr.queries.CategoryExists(ctx, int64(*priceRule.CategoryID)); !exists {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "category with id %d not found", *priceRule.CategoryID)
+		}
+		params.CategoryID = pgtype.Int8{Valid: true, Int64: int64(*priceRule.CategoryID)}
+	}
+	if priceRule.TagID != nil {
+		if live, _ := prr.queries.CountLiveTags(ctx, []int64{int64(*priceRule.TagID)}); live != 1 {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tag with id %d not found", *priceRule.TagID)
+		}
+		params.TagID = pgtype.Int8{Valid: true, Int64: int64(*priceRule.TagID)}
+	}
+
+	switch priceRule.Kind {
+	case repository.PriceRuleAbsolute:
+		if priceRule.ProductID == nil {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "absolute price rules can only be scoped to a product")
+		}
+		// one price for the whole product would flatten the prices of its variants
+		if hasVariants && priceRule.VariantID == nil {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "product with id %d has variants, absolute price rules on it must be scoped to one of them", *priceRule.ProductID)
+		}
+		if priceRule.Price == nil || priceRule.UpliftBps != nil {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "absolute price rules need a price and no uplift_bps")
+		}
+		if priceRule.Price.IsNegative() {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "price cannot be negative")
+		}
+		params.Price = priceRule.Price.Numeric()
+	case repository.PriceRulePercentage:
+		if priceRule.UpliftBps == nil || priceRule.Price != nil {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "percentage price rules need an uplift_bps and no price")
+		}
+		if *priceRule.UpliftBps <= -10000 {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "uplift_bps must be greater than -10000")
+		}
+		params.UpliftBps = pgtype.Int4{Valid: true, Int32: *priceRule.UpliftBps}
+		params.Currency = pgtype.Text{Valid: false}
+	default:
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "price rule kind must be %s or %s", repository.PriceRuleAbsolute, repository.PriceRulePercentage)
+	}
+
+	if priceRule.EndsAt != nil {
+		params.EndsAt = pgtype.Timestamptz{Valid: true, Time: *priceRule.EndsAt}
+	}
+
+	generatedPriceRule, err := prr.queries.CreatePriceRule(ctx, params)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating price rule: %s", err.Error())
+	}
+
+	return generatedPriceRuleToRepo(generatedPriceRule)
+}
+
+func (prr *PriceRuleRepository) GetPriceRuleByID(ctx context.Context, id int64) (*repository.PriceRule, error) {
+	generatedPriceRule, err := prr.queries.GetPriceRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "price rule with ID %d not found", id)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching price rule by id: %s", err.Error())
+	}
+
+	return generatedPriceRuleToRepo(generatedPriceRule)
+}
+
+func (prr *PriceRuleRepository) ListPriceRules(ctx context.Context, filter *repository.PriceRuleFilter) ([]*repository.PriceRule, error) {
+	params := generated.ListPriceRulesParams{
+		IncludeDeleted: filter.IncludeDeleted,
+		ProductID:      pgtype.Int8{Valid: false},
+		CategoryID:     pgtype.Int8{Valid: false},
+		TagID:          pgtype.Int8{Valid: false},
+		ActiveAt:       pgtype.Timestamptz{Valid: false},
+	}
+
+	if filter.ProductID != nil {
+		params.ProductID = pgtype.Int8{Valid: true, Int64: int64(*filter.ProductID)}
+	}
+	if filter.CategoryID != nil {
+		params.CategoryID = pgtype.Int8{Valid: true, Int64: int64(*filter.CategoryID)}
+	}
+	if filter.TagID != nil {
+		params.TagID = pgtype.Int8{Valid: true, Int64: int64(*filter.TagID)}
+	}
+	if filter.ActiveAt != nil {
+		params.ActiveAt = pgtype.Timestamptz{Valid: true, Time: *filter.ActiveAt}
+	}
+
+	generatedPriceRules, err := prr.queries.ListPriceRules(ctx, params)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing price rules: %s", err.Error())
+	}
+
+	return generatedPriceRulesToRepo(generatedPriceRules)
+}
+
+func (prr *PriceRuleRepository) EndPriceRule(ctx context.Context, id int64) error {
+	ended, err := prr.queries.EndPriceRule(ctx, id)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error ending price rule: %s", err.Error())
+	}
+	if ended == 0 {
+		return pkg.Errorf(pkg.NOT_FOUND_ERROR, "price rule with ID %d not found", id)
+	}
+
+	return nil
+}
+
+func (prr *PriceRuleRepository) GetPriceHistory(ctx context.Context, productID int64) (*repository.PriceHistory, error) {
+	product, err := prr.queries.GetProductByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with ID %d not found", productID)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching product by id: %s", err.Error())
+	}
+	currency := pkg.Currency(product.Currency)
+
+	generatedChanges, err := prr.queries.ListPriceChangesByProductID(ctx, productID)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing price changes: %s", err.Error())
+	}
+
+	history := &repository.PriceHistory{
+		Changes: make([]repository.PriceChange, len(generatedChanges)),
+	}
+	for i, change := range generatedChanges {
+		newPrice, err := pkg.NumericToMoney(change.NewPrice, currency)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for price change %d: %s", change.ID, err.Error())
+		}
+
+		history.Changes[i] = repository.PriceChange{
+			ID:        uint32(change.ID),
+			ProductID: uint32(change.ProductID),
+			VariantID: nil,
+			OldPrice:  nil,
+			NewPrice:  newPrice,
+			ChangedAt: change.ChangedAt,
+		}
+
+		if change.VariantID.Valid {
+			variantID := uint32(change.VariantID.Int64)
+			history.Changes[i].VariantID = &variantID
+		}
+		if change.OldPrice.Valid {
+			oldPrice, err := pkg.NumericToMoney(change.OldPrice, currency)
+			if err != nil {
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for price change %d: %s", change.ID, err.Error())
+			}
+			history.Changes[i].OldPrice = &oldPrice
+		}
+	}
+
+	generatedPriceRules, err := prr.queries.ListPriceRulesForProduct(ctx, productID)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing price rules for product: %s", err.Error())
+	}
+
+	if history.Rules, err = generatedPriceRulesToRepo(generatedPriceRules); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func generatedPriceRulesToRepo(generatedPriceRules []generated.PriceRule) ([]*repository.PriceRule, error) {
+	priceRules := make([]*repository.PriceRule, len(generatedPriceRules))
+	for i, priceRule := range generatedPriceRules {
+		var err error
+		if priceRules[i], err = generatedPriceRuleToRepo(priceRule); err != nil {
+			return nil, err
+		}
+	}
+
+	return priceRules, nil
+}
+
+func generatedPriceRuleToRepo(generatedPriceRule generated.PriceRule) (*repository.PriceRule, error) {
+	priceRule := &repository.PriceRule{
+		ID:         uint32(generatedPriceRule.ID),
+		Name:       generatedPriceRule.Name,
+		Kind:       generatedPriceRule.Kind,
+		Price:      nil,
+		UpliftBps:  nil,
+		ProductID:  nil,
+		VariantID:  nil,
+		CategoryID: nil,
+		TagID:      nil,
+		StartsAt:   generatedPriceRule.StartsAt,
+		EndsAt:     nil,
+		Priority:   generatedPriceRule.Priority,
+		DeletedAt:  nil,
+		CreatedAt:  generatedPriceRule.CreatedAt,
+	}
+
+	if generatedPriceRule.Price.Valid {
+		price, err := pkg.NumericToMoney(generatedPriceRule.Price, pkg.Currency(generatedPriceRule.Currency.String))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for price rule %d: %s", generatedPriceRule.ID, err.Error())
+		}
+		priceRule.Price = &price
+	}
+	if generatedPriceRule.UpliftBps.Valid {
+		priceRule.UpliftBps = &generatedPriceRule.UpliftBps.Int32
+	}
+	if generatedPriceRule.ProductID.Valid {
+		productID := uint32(generatedPriceRule.ProductID.Int64)
+		priceRule.ProductID = &productID
+	}
+	if generatedPriceRule.VariantID.Valid {
+		variantID := uint32(generatedPriceRule.VariantID.Int64)
+		priceRule.VariantID = &variantID
+	}
+	if generatedPriceRule.CategoryID.Valid {
+		categoryID := uint32(generatedPriceRule.CategoryID.Int64)
+		priceRule.CategoryID = &categoryID
+	}
+	if generatedPriceRule.TagID.Valid {
+		tagID := uint32(generatedPriceRule.TagID.Int64)
+		priceRule.TagID = &tagID
+	}
+	if generatedPriceRule.EndsAt.Valid {
+		priceRule.EndsAt = &generatedPriceRule.EndsAt.Time
+	}
+	if generatedPriceRule.DeletedAt.Valid {
+		priceRule.DeletedAt = &generatedPriceRule.DeletedAt.Time
+	}
+
+	return priceRule, nil
+}
@@ -0,0 +1,439 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.ProductImportRepository = (*ProductImportRepository)(nil)
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+type ProductImportRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewProductImportRepository(db *Store) *ProductImportRepository {
+	return &ProductImportRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+// productImport is a product row of an import with the rows of its variants.
+// exists is whether a product has its sku, which is then updated.
+type productImport struct {
+	row        *repository.ProductImportRow
+	variants   []*repository.ProductImportRow
+	categoryID uint32
+	exists     bool
+	valid      bool
+}
+
+func (r *ProductImportRepository) ImportProducts(ctx context.Context, rows []repository.ProductImportRow, options repository.ProductImportOptions) (*repository.ProductImportResult, error) {
+	mode := options.Mode
+	if mode == "" {
+		mode = repository.ImportModeAtomic
+	}
+	if mode != repository.ImportModeAtomic && mode != repository.ImportModePerRow {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "invalid import mode %q, expected %s or %s", mode, repository.ImportModeAtomic, repository.ImportModePerRow)
+	}
+	if len(rows) == 0 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "there are no rows to import")
+	}
+
+	result := &repository.ProductImportResult{
+		Mode:    mode,
+		DryRun:  options.DryRun,
+		Created: []repository.ImportedProduct{},
+		Updated: []repository.ImportedProduct{},
+		Errors:  []repository.ProductImportError{},
+	}
+
+	products, err := r.planProductImport(ctx, rows, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == repository.ImportModeAtomic {
+		// nothing is imported unless every row is valid
+		if len(result.Errors) == 0 {
+			if err := r.importProducts(ctx, products, options, result); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for _, product := range products {
+			if !product.valid {
+				continue
+			}
+			if err := r.importProducts(ctx, []*productImport{product}, options, result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// products failing to import are added after the problems found planning
+	sortProductImportErrors(result.Errors)
+
+	return result, nil
+}
+
+// planProductImport checks the rows and groups them into products, adding the
+// problems found to the result. Products with a problem on any of their rows
+// are not valid. Name, category and price are only required of new products.
+func (r *ProductImportRepository) planProductImport(ctx context.Context, rows []repository.ProductImportRow, result *repository.ProductImportResult) ([]*productImport, error) {
+	addError := func(row *repository.ProductImportRow, format string, args ...any) {
+		result.Errors = append(result.Errors, repository.ProductImportError{
+			Line:    row.Line,
+			SKU:     row.SKU,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	// checkRow reports the problems a product and a variant row can have
+	checkRow := func(row *repository.ProductImportRow, lines map[string]int) bool {
+		for _, message := range row.Errors {
+			addError(row, "%s", message)
+		}
+		valid := len(row.Errors) == 0
+
+		if row.SKU == "" {
+			addError(row, "sku is required")
+			valid = false
+		} else if line, ok := lines[row.SKU]; ok {
+			addError(row, "sku %s is already used on line %d", row.SKU, line)
+			valid = false
+		} else {
+			lines[row.SKU] = row.Line
+		}
+		if row.Price != nil && !row.Price.IsPositive() {
+			addError(row, "price must be greater than 0")
+			valid = false
+		}
+		if row.StockQuantity != nil && *row.StockQuantity < 0 {
+			addError(row, "stock_quantity cannot be negative")
+			valid = false
+		}
+		if row.ReorderThreshold != nil && *row.ReorderThreshold < 0 {
+			addError(row, "reorder_threshold cannot be negative")
+			valid = false
+		}
+
+		return valid
+	}
+
+	lines := make(map[string]int, len(rows))
+	categoryIDs := make(map[string][]int64)
+	products := make([]*productImport, 0, len(rows))
+	bySKU := make(map[string]*productImport, len(rows))
+
+	for i := range rows {
+		row := &rows[i]
+		if row.ProductSKU != "" {
+			continue
+		}
+
+		product := &productImport{row: row, valid: checkRow(row, lines)}
+
+		if row.SKU != "" {
+			_, err := r.queries.GetProductBySKU(ctx, pgtype.Text{Valid: true, String: row.SKU})
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching product by sku: %s", err.Error())
+			}
+			product.exists = err == nil
+		}
+
+		if strings.TrimSpace(row.Name) == "" && !product.exists {
+			addError(row, "name is required")
+			product.valid = false
+		}
+		if len(row.Options) > 0 {
+			addError(row, "options are only set on variant rows")
+			product.valid = false
+		}
+
+		name := strings.ToLower(strings.TrimSpace(row.Category))
+		if name == "" {
+			if !product.exists {
+				addError(row, "category is required")
+				product.valid = false
+			}
+		} else {
+			ids, ok := categoryIDs[name]
+			if !ok {
+				var err error
+				if ids, err = r.queries.ListCategoryIDsByName(ctx, name); err != nil {
+					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching categories by name: %s", err.Error())
+				}
+				categoryIDs[name] = ids
+			}
+
+			switch len(ids) {
+			case 0:
+				addError(row, "category %q not found", row.Category)
+				product.valid = false
+			case 1:
+				product.categoryID = uint32(ids[0])
+			default:
+				addError(row, "%d categories are named %q", len(ids), row.Category)
+				product.valid = false
+			}
+		}
+
+		products = append(products, product)
+		if _, ok := bySKU[row.SKU]; !ok && row.SKU != "" {
+			bySKU[row.SKU] = product
+		}
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if row.ProductSKU == "" {
+			continue
+		}
+
+		valid := checkRow(row, lines)
+		if row.Price == nil {
+			addError(row, "price is required")
+			valid = false
+		}
+		if len(row.Options) == 0 {
+			addError(row, "options are required on variant rows")
+			valid = false
+		}
+
+		product, ok := bySKU[row.ProductSKU]
+		if !ok {
+			addError(row, "product_sku %s is not the sku of a product row", row.ProductSKU)
+			continue
+		}
+
+		product.variants = append(product.variants, row)
+		product.valid = product.valid && valid
+	}
+
+	for _, product := range products {
+		if len(product.variants) == 0 && product.row.Price == nil && !product.exists {
+			addError(product.row, "price is required")
+			product.valid = false
+		}
+	}
+
+	sortProductImportErrors(result.Errors)
+
+	return products, nil
+}
+
+// importProducts imports products in one transaction, adding them to the
+// result. The transaction is rolled back on a dry run and when one of the
+// products fails, which is added to the result's errors instead.
+func (r *ProductImportRepository) importProducts(ctx context.Context, products []*productImport, options repository.ProductImportOptions, result *repository.ProductImportResult) error {
+	var created, updated []repository.ImportedProduct
+	var failed *productImport
+	var failure error
+	done := false
+
+	err := r.db.ExecTx(ctx, func(q *generated.Queries) error {
+		for _, product := range products {
+			id, isNew, err := importProduct(ctx, q, product, options.UserID)
+			if err != nil {
+				failed, failure = product, err
+				return err
+			}
+
+			if isNew {
+				if options.DryRun {
+					id = 0
+				}
+				created = append(created, repository.ImportedProduct{ID: id, SKU: product.row.SKU})
+			} else {
+				updated = append(updated, repository.ImportedProduct{ID: id, SKU: product.row.SKU})
+			}
+		}
+
+		done = true
+		if options.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if failed != nil {
+		result.Errors = append(result.Errors, repository.ProductImportError{
+			Line:    failed.row.Line,
+			SKU:     failed.row.SKU,
+			Message: pkg.ErrorMessage(failure),
+		})
+		return nil
+	}
+	if err != nil && !(done && options.DryRun) {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error importing products: %s", err.Error())
+	}
+
+	result.Created = append(result.Created, created...)
+	result.Updated = append(result.Updated, updated...)
+
+	return nil
+}
+
+// importProduct updates the product with the sku of the product row, or
+// creates it when there is none, returning its ID and whether it was created.
+// Only the filled cells of the row are updated, and the variants only when the
+// product has variant rows.
+func importProduct(ctx context.Context, q *generated.Queries, product *productImport, userID uint32) (uint32, bool, error) {
+	row := product.row
+	options, variants := productImportVariants(product)
+	hasVariants := len(variants) > 0
+
+	existing, err := q.GetProductBySKU(ctx, pgtype.Text{Valid: true, String: row.SKU})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, false, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching product by sku: %s", err.Error())
+	}
+
+	if err == nil {
+		if existing.DeletedAt.Valid {
+			return 0, false, pkg.Errorf(pkg.INVALID_ERROR, "product with sku %s is deleted, restore it before importing it", row.SKU)
+		}
+
+		update := &repository.UpdateProduct{
+			ID:               uint32(existing.ID),
+			Description:      row.Description,
+			IsMessageCard:    row.IsMessageCard,
+			IsFlowers:        row.IsFlowers,
+			IsAddOn:          row.IsAddOn,
+			Price:            row.Price,
+			StockQuantity:    row.StockQuantity,
+			ReorderThreshold: row.ReorderThreshold,
+			UpdatedBy:        userID,
+		}
+		if name := strings.TrimSpace(row.Name); name != "" {
+			update.Name = &name
+		}
+		if product.categoryID != 0 {
+			update.CategoryID = &product.categoryID
+		}
+		if hasVariants {
+			update.HasVariants = &hasVariants
+			update.Options = options
+			update.Variants = variants
+		}
+		if row.Currency != "" {
+			currency := string(row.Currency)
+			update.Currency = &currency
+		}
+		if row.ImageUrl != nil {
+			update.ImageURL = &row.ImageUrl
+		}
+
+		return update.ID, false, updateProduct(ctx, q, update)
+	}
+
+	// the sku may have left the product it was planned as an update of
+	if strings.TrimSpace(row.Name) == "" || product.categoryID == 0 || (row.Price == nil && !hasVariants) {
+		return 0, false, pkg.Errorf(pkg.INVALID_ERROR, "name, category and price are required to create product with sku %s", row.SKU)
+	}
+
+	currency := row.Currency
+	if currency == "" {
+		currency = pkg.DefaultCurrency
+	}
+
+	// a product with variants is priced as its first variant by default
+	var price pkg.Money
+	if row.Price != nil {
+		price = *row.Price
+	} else {
+		price = variants[0].Price
+	}
+
+	create := &repository.Product{
+		SKU:              &row.SKU,
+		Name:             row.Name,
+		Price:            price.WithCurrency(currency),
+		Currency:         currency,
+		CategoryID:       product.categoryID,
+		HasVariants:      hasVariants,
+		ImageUrl:         row.ImageUrl,
+		ReorderThreshold: row.ReorderThreshold,
+		Options:          options,
+		Variants:         variants,
+		CreatedBy:        userID,
+	}
+	if row.Description != nil {
+		create.Description = *row.Description
+	}
+	if row.IsMessageCard != nil {
+		create.IsMessageCard = *row.IsMessageCard
+	}
+	if row.IsFlowers != nil {
+		create.IsFlowers = *row.IsFlowers
+	}
+	if row.IsAddOn != nil {
+		create.IsAddOn = *row.IsAddOn
+	}
+	if row.StockQuantity != nil {
+		create.StockQuantity = *row.StockQuantity
+	}
+	if create.ImageUrl == nil {
+		create.ImageUrl = []string{}
+	}
+
+	if err := createProduct(ctx, q, create); err != nil {
+		return 0, false, err
+	}
+
+	return create.ID, true, nil
+}
+
+// productImportVariants turns the variant rows of a product into its variants
+// and options, listing options and their values in the order they first
+// appear in.
+func productImportVariants(product *productImport) ([]repository.ProductOption, []repository.ProductVariant) {
+	options := []repository.ProductOption{}
+	positions := make(map[string]int)
+	variants := make([]repository.ProductVariant, 0, len(product.variants))
+
+	for _, row := range product.variants {
+		for _, option := range row.Options {
+			i, ok := positions[option.Name]
+			if !ok {
+				i = len(options)
+				positions[option.Name] = i
+				options = append(options, repository.ProductOption{Name: option.Name})
+			}
+			if !slices.Contains(options[i].Values, option.Value) {
+				options[i].Values = append(options[i].Values, option.Value)
+			}
+		}
+
+		variants = append(variants, repository.ProductVariant{
+			SKU:              row.SKU,
+			Price:            *row.Price,
+			StockQuantity:    row.StockQuantity,
+			ReorderThreshold: row.ReorderThreshold,
+			ImageUrl:         row.ImageUrl,
+			Options:          row.Options,
+		})
+	}
+
+	return options, variants
+}
+
+func sortProductImportErrors(errs []repository.ProductImportError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Line < errs[j].Line
+	})
+}
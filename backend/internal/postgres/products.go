@@ -5,7 +5,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
 	"github.com/flexGURU/flower-haven/backend/internal/repository"
@@ -15,6 +18,19 @@ import (
 
 var _ repository.ProductRepository = (*ProductRepository)(nil)
 
+var (
+	defaultProductSort = pkg.Sort{{Name: "created_at", Desc: true}}
+	productSearchSort  = pkg.Sort{{Name: "relevance", Desc: true}, {Name: "created_at", Desc: true}}
+
+	productKeyset = keyset{id: "p.id", fields: map[string][]sortColumn{
+		// the price customers see, with the price rule in force applied
+		"price":      {{"p.currency", "text"}, {"product_price(p, NULL, p.price, now())", "numeric"}},
+		"created_at": {{"p.created_at", "timestamptz"}},
+		"popularity": {{"p.popularity", "bigint"}},
+		"relevance":  {{"sr.search_rank", "float8"}},
+	}}
+)
+
 type ProductRepository struct {
 	queries *generated.Queries
 	db      *Store
@@ -29,49 +45,13 @@ func NewProductRepository(db *Store) *ProductRepository {
 
 func (pr *ProductRepository) CreateProduct(ctx context.Context, product *repository.Product) (*repository.Product, error) {
 	err := pr.db.ExecTx(ctx, func(q *generated.Queries) error {
-		generatedProduct, err := q.CreateProduct(ctx, generated.CreateProductParams{
-			Name:          product.Name,
-			Description:   product.Description,
-			Price:         pkg.Float64ToPgTypeNumeric(product.Price),
-			CategoryID:    int64(product.CategoryID),
-			HasStems:      product.HasStems,
-			IsMessageCard: product.IsMessageCard,
-			IsFlowers:     product.IsFlowers,
-			IsAddOn:       product.IsAddOn,
-			ImageUrl:      product.ImageUrl,
-			StockQuantity: product.StockQuantity,
-		})
-		if err != nil {
-			if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
-				return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "%s", err.Error())
-			}
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating product: %s", err.Error())
-		}
-
-		product.ID = uint32(generatedProduct.ID)
-		product.CreatedAt = generatedProduct.CreatedAt
-		product.CategoryData = nil
-
-		if product.HasStems {
-			for _, stem := range product.Stems {
-				_, err := q.CreateProductStem(ctx, generated.CreateProductStemParams{
-					ProductID: int64(product.ID),
-					StemCount: int64(stem.StemCount),
-					Price:     pkg.Float64ToPgTypeNumeric(stem.Price),
-				})
-				if err != nil {
-					if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
-						return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "%s", err.Error())
-					}
-					return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating product stem: %s", err.Error())
-				}
-			}
-		}
-
-		return nil
+		return createProduct(ctx, q, product)
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return product, err
+	return pr.GetProductByID(ctx, int64(product.ID))
 }
 
 func (pr *ProductRepository) GetProductByID(ctx context.Context, id int64) (*repository.Product, error) {
@@ -83,21 +63,41 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, id int64) (*rep
 		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching product by id: %s", err.Error())
 	}
 
+	currency := pkg.Currency(generatedProduct.Currency)
+	price, basePrice, err := productPrices(generatedProduct.EffectivePrice, generatedProduct.Price, currency)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for product %d: %s", id, err.Error())
+	}
+
 	product := &repository.Product{
-		ID:            uint32(generatedProduct.ID),
-		Name:          generatedProduct.Name,
-		Description:   generatedProduct.Description,
-		Price:         pkg.PgTypeNumericToFloat64(generatedProduct.Price),
-		CategoryID:    uint32(generatedProduct.CategoryID),
-		ImageUrl:      generatedProduct.ImageUrl,
-		HasStems:      generatedProduct.HasStems,
-		IsMessageCard: generatedProduct.IsMessageCard,
-		IsFlowers:     generatedProduct.IsFlowers,
-		IsAddOn:       generatedProduct.IsAddOn,
-		StockQuantity: generatedProduct.StockQuantity,
-		DeletedAt:     nil,
-		CreatedAt:     generatedProduct.CreatedAt,
-		CategoryData:  nil,
+		ID:                uint32(generatedProduct.ID),
+		Name:              generatedProduct.Name,
+		Description:       generatedProduct.Description,
+		Price:             price,
+		BasePrice:         basePrice,
+		Currency:          currency,
+		CategoryID:        uint32(generatedProduct.CategoryID),
+		ImageUrl:          generatedProduct.ImageUrl,
+		HasVariants:       generatedProduct.HasVariants,
+		IsMessageCard:     generatedProduct.IsMessageCard,
+		IsFlowers:         generatedProduct.IsFlowers,
+		IsAddOn:           generatedProduct.IsAddOn,
+		StockQuantity:     generatedProduct.StockQuantity,
+		AvailableQuantity: generatedProduct.AvailableQuantity,
+		DeletedAt:         nil,
+		CreatedAt:         generatedProduct.CreatedAt,
+		CategoryData:      nil,
+	}
+
+	if generatedProduct.TaxClassID.Valid {
+		taxClassID := uint32(generatedProduct.TaxClassID.Int64)
+		product.TaxClassID = &taxClassID
+	}
+	if generatedProduct.ReorderThreshold.Valid {
+		product.ReorderThreshold = &generatedProduct.ReorderThreshold.Int64
+	}
+	if generatedProduct.Sku.Valid {
+		product.SKU = &generatedProduct.Sku.String
 	}
 
 	if generatedProduct.DeletedAt.Valid {
@@ -112,118 +112,35 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, id int64) (*rep
 		}
 	}
 
-	if product.HasStems {
-		var stems []repository.ProductStem
-		productStems, err := pr.queries.GetProductStemsByProductID(ctx, int64(product.ID))
-		if err != nil {
-			if !errors.Is(err, sql.ErrNoRows) {
-				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting product stems: %s", err.Error())
-			}
-		}
-
-		for _, stem := range productStems {
-			stems = append(stems, repository.ProductStem{
-				ID:        uint32(stem.ID),
-				ProductID: uint32(stem.ProductID),
-				StemCount: uint32(stem.StemCount),
-				Price:     pkg.PgTypeNumericToFloat64(stem.Price),
-			})
+	if product.HasVariants {
+		if product.Options, product.Variants, err = listProductVariants(ctx, pr.queries, product.ID, currency); err != nil {
+			return nil, err
 		}
-		product.Stems = stems
 	}
 
-	return product, nil
-}
-
-func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *repository.UpdateProduct) (*repository.Product, error) {
-	params := generated.UpdateProductParams{
-		ID:            int64(product.ID),
-		Name:          pgtype.Text{Valid: false},
-		Description:   pgtype.Text{Valid: false},
-		Price:         pgtype.Numeric{Valid: false},
-		CategoryID:    pgtype.Int8{Valid: false},
-		HasStems:      pgtype.Bool{Valid: false},
-		IsMessageCard: pgtype.Bool{Valid: false},
-		IsFlowers:     pgtype.Bool{Valid: false},
-		IsAddOn:       pgtype.Bool{Valid: false},
-		ImageUrl:      nil,
-		StockQuantity: pgtype.Int8{Valid: false},
+	if product.Tags, err = listProductTags(ctx, pr.queries, product.ID); err != nil {
+		return nil, err
 	}
 
-	if product.Name != nil {
-		params.Name = pgtype.Text{
-			Valid:  true,
-			String: *product.Name,
-		}
-	}
-	if product.Description != nil {
-		params.Description = pgtype.Text{
-			Valid:  true,
-			String: *product.Description,
-		}
-	}
-	if product.Price != nil {
-		params.Price = pkg.Float64ToPgTypeNumeric(*product.Price)
+	ancestors, err := pr.queries.GetCategoryAncestors(ctx, generatedProduct.CategoryID)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting category breadcrumbs: %s", err.Error())
 	}
-	if product.CategoryID != nil {
-		params.CategoryID = pgtype.Int8{
-			Valid: true,
-			Int64: int64(*product.CategoryID),
+	product.Breadcrumbs = make([]repository.CategoryBreadcrumb, len(ancestors))
+	for i, ancestor := range ancestors {
+		product.Breadcrumbs[i] = repository.CategoryBreadcrumb{
+			ID:   uint32(ancestor.ID),
+			Name: ancestor.Name,
 		}
 	}
-	if product.ImageURL != nil {
-		params.ImageUrl = *product.ImageURL
-	}
-	if product.StockQuantity != nil {
-		params.StockQuantity = pgtype.Int8{Int64: int64(*product.StockQuantity), Valid: true}
-	}
-	if product.HasStems != nil {
-		params.HasStems = pgtype.Bool{Bool: *product.HasStems, Valid: true}
-	}
-	if product.IsMessageCard != nil {
-		params.IsMessageCard = pgtype.Bool{Bool: *product.IsMessageCard, Valid: true}
-	}
-	if product.IsFlowers != nil {
-		params.IsFlowers = pgtype.Bool{Bool: *product.IsFlowers, Valid: true}
-	}
-	if product.IsAddOn != nil {
-		params.IsAddOn = pgtype.Bool{Bool: *product.IsAddOn, Valid: true}
-	}
-
-	err := pr.db.ExecTx(ctx, func(q *generated.Queries) error {
-		if product.Stems != nil {
-			// delete all existing stems and recreate them
-			if err := q.DeleteProductStemsByProductID(ctx, int64(product.ID)); err != nil {
-				return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting existing product stems: %s", err.Error())
-			}
 
-			for _, stem := range product.Stems {
-				stemProductId := product.ID
-				_, err := q.CreateProductStem(ctx, generated.CreateProductStemParams{
-					ProductID: int64(stemProductId),
-					StemCount: int64(*stem.StemCount),
-					Price:     pkg.Float64ToPgTypeNumeric(*stem.Price),
-				})
-				if err != nil {
-					if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
-						return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "%s", err.Error())
-					}
-					return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating product stem: %s", err.Error())
-				}
-			}
-		}
-
-		_, err := pr.queries.UpdateProduct(ctx, params)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with ID %d not found", product.ID)
-			}
-			return pkg.Errorf(pkg.INTERNAL_ERROR, "error updating product: %s", err.Error())
-		}
+	return product, nil
+}
 
-		return nil
+func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *repository.UpdateProduct) (*repository.Product, error) {
+	err := pr.db.ExecTx(ctx, func(q *generated.Queries) error {
+		return updateProduct(ctx, q, product)
 	})
-
 	if err != nil {
 		return nil, err
 	}
@@ -326,17 +243,14 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *reposit
 // 	return products, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
 // }
 
-func (pr *ProductRepository) ListProducts(ctx context.Context, filter *repository.ProductFilter) ([]*repository.Product, *pkg.Pagination, error) {
-	paramsListProducts := generated.ListProductsParams{
-		Limit:         int32(filter.Pagination.PageSize),
-		Offset:        pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
-		Search:        pgtype.Text{Valid: false},
-		PriceFrom:     pgtype.Float8{Valid: false},
-		PriceTo:       pgtype.Float8{Valid: false},
-		IsMessageCard: pgtype.Bool{Valid: false},
-		IsFlowers:     pgtype.Bool{Valid: false},
-		IsAddOn:       pgtype.Bool{Valid: false},
-		CategoryIds:   nil,
+func (pr *ProductRepository) ListProducts(ctx context.Context, filter *repository.ProductFilter) ([]*repository.Product, *pkg.Pagination, *repository.ProductFacets, error) {
+	defaultSort := defaultProductSort
+	if filter.Search != nil && strings.TrimSpace(*filter.Search) != "" {
+		defaultSort = productSearchSort
+	}
+	page, err := newListPage(filter.Pagination, defaultSort, productKeyset)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	paramsCountProducts := generated.ListCountProductsParams{
@@ -347,78 +261,117 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, filter *repositor
 		IsFlowers:     pgtype.Bool{Valid: false},
 		IsAddOn:       pgtype.Bool{Valid: false},
 		CategoryIds:   nil,
+		InStock:       pgtype.Bool{Valid: false},
+		TagIds:        nil,
+		WithFacets:    filter.WithFacets,
+		PriceBounds:   repository.ProductPriceBucketBounds,
 	}
 
 	if filter.Search != nil {
-		search := strings.ToLower(*filter.Search)
-		paramsListProducts.Search = pgtype.Text{
-			Valid:  true,
-			String: "%" + search + "%",
-		}
-		paramsCountProducts.Search = pgtype.Text{
-			Valid:  true,
-			String: "%" + search + "%",
-		}
+		search := strings.TrimSpace(*filter.Search)
+		paramsCountProducts.Search = pgtype.Text{Valid: search != "", String: search}
 	}
 
 	if filter.PriceFrom != nil && filter.PriceTo != nil {
-		paramsListProducts.PriceFrom = pgtype.Float8{Valid: true, Float64: *filter.PriceFrom}
-		paramsListProducts.PriceTo = pgtype.Float8{Valid: true, Float64: *filter.PriceTo}
 		paramsCountProducts.PriceFrom = pgtype.Float8{Valid: true, Float64: *filter.PriceFrom}
 		paramsCountProducts.PriceTo = pgtype.Float8{Valid: true, Float64: *filter.PriceTo}
 	}
 
 	if filter.CategoryIDs != nil {
-		paramsListProducts.CategoryIds = make([]int32, 0, len(*filter.CategoryIDs))
 		paramsCountProducts.CategoryIds = make([]int32, 0, len(*filter.CategoryIDs))
 		for _, id := range *filter.CategoryIDs {
-			paramsListProducts.CategoryIds = append(paramsListProducts.CategoryIds, int32(id))
 			paramsCountProducts.CategoryIds = append(paramsCountProducts.CategoryIds, int32(id))
 		}
 	}
 
 	if filter.IsMessageCard != nil {
-		paramsListProducts.IsMessageCard = pgtype.Bool{Valid: true, Bool: *filter.IsMessageCard}
 		paramsCountProducts.IsMessageCard = pgtype.Bool{Valid: true, Bool: *filter.IsMessageCard}
 	}
 
 	if filter.IsFlowers != nil {
-		paramsListProducts.IsFlowers = pgtype.Bool{Valid: true, Bool: *filter.IsFlowers}
 		paramsCountProducts.IsFlowers = pgtype.Bool{Valid: true, Bool: *filter.IsFlowers}
 	}
 
 	if filter.IsAddOn != nil {
-		paramsListProducts.IsAddOn = pgtype.Bool{Valid: true, Bool: *filter.IsAddOn}
 		paramsCountProducts.IsAddOn = pgtype.Bool{Valid: true, Bool: *filter.IsAddOn}
 	}
 
-	generatedProducts, err := pr.queries.ListProducts(ctx, paramsListProducts)
+	if filter.InStock != nil {
+		paramsCountProducts.InStock = pgtype.Bool{Valid: true, Bool: *filter.InStock}
+	}
+
+	if filter.TagIDs != nil && len(*filter.TagIDs) > 0 {
+		paramsCountProducts.TagIds = *filter.TagIDs
+	}
+
+	// the list takes the filters of the count in the same order
+	generatedProducts, err := listRows[productListRow](ctx, pr.db.pool, page, productList,
+		paramsCountProducts.Search,
+		paramsCountProducts.PriceFrom,
+		paramsCountProducts.IsMessageCard,
+		paramsCountProducts.IsFlowers,
+		paramsCountProducts.IsAddOn,
+		paramsCountProducts.PriceTo,
+		paramsCountProducts.CategoryIds,
+		paramsCountProducts.InStock,
+		paramsCountProducts.TagIds,
+	)
 	if err != nil {
-		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing products: %s", err.Error())
+		return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing products: %s", err.Error())
 	}
 
-	totalCount, err := pr.queries.ListCountProducts(ctx, paramsCountProducts)
+	counts, err := pr.queries.ListCountProducts(ctx, paramsCountProducts)
 	if err != nil {
-		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting products: %s", err.Error())
+		return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting products: %s", err.Error())
+	}
+
+	var facets *repository.ProductFacets
+	if filter.WithFacets {
+		if facets, err = productFacetsFromJSON(counts.Facets, paramsCountProducts.PriceBounds); err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
+	generatedProducts, pagination := pageRows(generatedProducts, filter.Pagination, counts.TotalProducts, func(row productListRow) []string {
+		return row.SortKeys
+	})
+
 	products := make([]*repository.Product, len(generatedProducts))
 	for i, p := range generatedProducts {
+		price, basePrice, err := productPrices(p.EffectivePrice, p.Price, pkg.Currency(p.Currency))
+		if err != nil {
+			return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for product %d: %s", p.ID, err.Error())
+		}
+
 		product := &repository.Product{
-			ID:            uint32(p.ID),
-			Name:          p.Name,
-			Description:   p.Description,
-			Price:         pkg.PgTypeNumericToFloat64(p.Price),
-			CategoryID:    uint32(p.CategoryID),
-			ImageUrl:      p.ImageUrl,
-			HasStems:      p.HasStems,
-			IsMessageCard: p.IsMessageCard,
-			IsFlowers:     p.IsFlowers,
-			IsAddOn:       p.IsAddOn,
-			StockQuantity: p.StockQuantity,
-			DeletedAt:     nil,
-			CreatedAt:     p.CreatedAt,
-			CategoryData:  nil,
+			ID:                uint32(p.ID),
+			Name:              p.Name,
+			Description:       p.Description,
+			Price:             price,
+			BasePrice:         basePrice,
+			Currency:          pkg.Currency(p.Currency),
+			CategoryID:        uint32(p.CategoryID),
+			ImageUrl:          p.ImageUrl,
+			HasVariants:       p.HasVariants,
+			IsMessageCard:     p.IsMessageCard,
+			IsFlowers:         p.IsFlowers,
+			IsAddOn:           p.IsAddOn,
+			StockQuantity:     p.StockQuantity,
+			AvailableQuantity: p.AvailableQuantity,
+			DeletedAt:         nil,
+			CreatedAt:         p.CreatedAt,
+			CategoryData:      nil,
+		}
+
+		if p.TaxClassID.Valid {
+			taxClassID := uint32(p.TaxClassID.Int64)
+			product.TaxClassID = &taxClassID
+		}
+		if p.ReorderThreshold.Valid {
+			product.ReorderThreshold = &p.ReorderThreshold.Int64
+		}
+		if p.Sku.Valid {
+			product.SKU = &p.Sku.String
 		}
 
 		if p.CategoryID_2.Valid {
@@ -429,34 +382,97 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, filter *repositor
 			}
 		}
 
-		// Unmarshal stems JSON
-		if p.Stems != nil {
-			var stems []repository.ProductStem
+		if paramsCountProducts.Search.Valid {
+			product.SearchRank = p.SearchRank
+			product.Highlight = &repository.ProductHighlight{
+				Name:        p.NameHighlight.String,
+				Description: p.DescriptionHighlight.String,
+			}
+		}
+
+		// Unmarshal variants JSON
+		if p.Variants != nil {
+			var variants []repository.ProductVariant
 
-			switch v := p.Stems.(type) {
+			switch v := p.Variants.(type) {
 			case []byte:
-				if err := json.Unmarshal(v, &stems); err != nil {
-					return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling stems: %s", err.Error())
+				if err := json.Unmarshal(v, &variants); err != nil {
+					return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling variants: %s", err.Error())
 				}
 			case []interface{}:
 				raw, err := json.Marshal(v)
 				if err != nil {
-					return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error marshaling stems interface{}: %s", err.Error())
+					return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error marshaling variants interface{}: %s", err.Error())
 				}
-				if err := json.Unmarshal(raw, &stems); err != nil {
-					return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling stems: %s", err.Error())
+				if err := json.Unmarshal(raw, &variants); err != nil {
+					return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling variants: %s", err.Error())
 				}
 			default:
-				return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "unexpected stems type: %T", p.Stems)
+				return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "unexpected variants type: %T", p.Variants)
 			}
 
-			product.Stems = stems
+			for j := range variants {
+				variants[j].Price = variants[j].Price.WithCurrency(product.Currency)
+				variants[j].BasePrice = ruleBasePrice(variants[j].Price, variants[j].BasePrice)
+			}
+			product.Variants = variants
+		}
+
+		if p.Tags != nil {
+			if err := unmarshalJSONColumn(p.Tags, &product.Tags); err != nil {
+				return nil, nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling tags: %s", err.Error())
+			}
 		}
 
 		products[i] = product
 	}
 
-	return products, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+	return products, pagination, facets, nil
+}
+
+// productFacetsFromJSON decodes the facets of ListCountProducts, filling in the
+// price buckets without products so that every bucket is listed.
+func productFacetsFromJSON(data []byte, bounds []float64) (*repository.ProductFacets, error) {
+	var raw struct {
+		Categories   []repository.CategoryFacet `json:"categories"`
+		Tags         []repository.TagFacet      `json:"tags"`
+		PriceBuckets []struct {
+			Bucket int   `json:"bucket"`
+			Count  int64 `json:"count"`
+		} `json:"price_buckets"`
+		Types repository.ProductTypeFacets `json:"types"`
+		Stock repository.StockFacets       `json:"stock"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling product facets: %s", err.Error())
+	}
+
+	facets := &repository.ProductFacets{
+		Categories:   raw.Categories,
+		Tags:         raw.Tags,
+		PriceBuckets: make([]repository.PriceBucketFacet, len(bounds)+1),
+		Types:        raw.Types,
+		Stock:        raw.Stock,
+	}
+
+	// width_bucket numbers the buckets from 0, below the first bound, to
+	// len(bounds), at or above the last one
+	for i := range facets.PriceBuckets {
+		if i > 0 {
+			facets.PriceBuckets[i].From = bounds[i-1]
+		}
+		if i < len(bounds) {
+			to := bounds[i]
+			facets.PriceBuckets[i].To = &to
+		}
+	}
+	for _, bucket := range raw.PriceBuckets {
+		if bucket.Bucket >= 0 && bucket.Bucket < len(facets.PriceBuckets) {
+			facets.PriceBuckets[bucket.Bucket].Count = bucket.Count
+		}
+	}
+
+	return facets, nil
 }
 
 func (pr *ProductRepository) ListAddOns(ctx context.Context) ([]*repository.Product, error) {
@@ -467,21 +483,40 @@ func (pr *ProductRepository) ListAddOns(ctx context.Context) ([]*repository.Prod
 
 	products := make([]*repository.Product, len(generatedProducts))
 	for i, p := range generatedProducts {
+		price, basePrice, err := productPrices(p.EffectivePrice, p.Price, pkg.Currency(p.Currency))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for product %d: %s", p.ID, err.Error())
+		}
+
 		product := &repository.Product{
-			ID:            uint32(p.ID),
-			Name:          p.Name,
-			Description:   p.Description,
-			Price:         pkg.PgTypeNumericToFloat64(p.Price),
-			CategoryID:    uint32(p.CategoryID),
-			ImageUrl:      p.ImageUrl,
-			HasStems:      p.HasStems,
-			IsMessageCard: p.IsMessageCard,
-			IsFlowers:     p.IsFlowers,
-			IsAddOn:       p.IsAddOn,
-			StockQuantity: p.StockQuantity,
-			DeletedAt:     nil,
-			CreatedAt:     p.CreatedAt,
-			CategoryData:  nil,
+			ID:                uint32(p.ID),
+			Name:              p.Name,
+			Description:       p.Description,
+			Price:             price,
+			BasePrice:         basePrice,
+			Currency:          pkg.Currency(p.Currency),
+			CategoryID:        uint32(p.CategoryID),
+			ImageUrl:          p.ImageUrl,
+			HasVariants:       p.HasVariants,
+			IsMessageCard:     p.IsMessageCard,
+			IsFlowers:         p.IsFlowers,
+			IsAddOn:           p.IsAddOn,
+			StockQuantity:     p.StockQuantity,
+			AvailableQuantity: p.AvailableQuantity,
+			DeletedAt:         nil,
+			CreatedAt:         p.CreatedAt,
+			CategoryData:      nil,
+		}
+
+		if p.TaxClassID.Valid {
+			taxClassID := uint32(p.TaxClassID.Int64)
+			product.TaxClassID = &taxClassID
+		}
+		if p.ReorderThreshold.Valid {
+			product.ReorderThreshold = &p.ReorderThreshold.Int64
+		}
+		if p.Sku.Valid {
+			product.SKU = &p.Sku.String
 		}
 
 		if p.CategoryID_2.Valid {
@@ -492,28 +527,32 @@ func (pr *ProductRepository) ListAddOns(ctx context.Context) ([]*repository.Prod
 			}
 		}
 
-		// Unmarshal stems JSON
-		if p.Stems != nil {
-			var stems []repository.ProductStem
+		// Unmarshal variants JSON
+		if p.Variants != nil {
+			var variants []repository.ProductVariant
 
-			switch v := p.Stems.(type) {
+			switch v := p.Variants.(type) {
 			case []byte:
-				if err := json.Unmarshal(v, &stems); err != nil {
-					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling stems: %s", err.Error())
+				if err := json.Unmarshal(v, &variants); err != nil {
+					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling variants: %s", err.Error())
 				}
 			case []interface{}:
 				raw, err := json.Marshal(v)
 				if err != nil {
-					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error marshaling stems interface{}: %s", err.Error())
+					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error marshaling variants interface{}: %s", err.Error())
 				}
-				if err := json.Unmarshal(raw, &stems); err != nil {
-					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling stems: %s", err.Error())
+				if err := json.Unmarshal(raw, &variants); err != nil {
+					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling variants: %s", err.Error())
 				}
 			default:
-				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "unexpected stems type: %T", p.Stems)
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "unexpected variants type: %T", p.Variants)
 			}
 
-			product.Stems = stems
+			for j := range variants {
+				variants[j].Price = variants[j].Price.WithCurrency(product.Currency)
+				variants[j].BasePrice = ruleBasePrice(variants[j].Price, variants[j].BasePrice)
+			}
+			product.Variants = variants
 		}
 
 		products[i] = product
@@ -530,21 +569,40 @@ func (pr *ProductRepository) ListMessageCards(ctx context.Context) ([]*repositor
 
 	products := make([]*repository.Product, len(generatedProducts))
 	for i, p := range generatedProducts {
+		price, basePrice, err := productPrices(p.EffectivePrice, p.Price, pkg.Currency(p.Currency))
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for product %d: %s", p.ID, err.Error())
+		}
+
 		product := &repository.Product{
-			ID:            uint32(p.ID),
-			Name:          p.Name,
-			Description:   p.Description,
-			Price:         pkg.PgTypeNumericToFloat64(p.Price),
-			CategoryID:    uint32(p.CategoryID),
-			ImageUrl:      p.ImageUrl,
-			HasStems:      p.HasStems,
-			IsMessageCard: p.IsMessageCard,
-			IsFlowers:     p.IsFlowers,
-			IsAddOn:       p.IsAddOn,
-			StockQuantity: p.StockQuantity,
-			DeletedAt:     nil,
-			CreatedAt:     p.CreatedAt,
-			CategoryData:  nil,
+			ID:                uint32(p.ID),
+			Name:              p.Name,
+			Description:       p.Description,
+			Price:             price,
+			BasePrice:         basePrice,
+			Currency:          pkg.Currency(p.Currency),
+			CategoryID:        uint32(p.CategoryID),
+			ImageUrl:          p.ImageUrl,
+			HasVariants:       p.HasVariants,
+			IsMessageCard:     p.IsMessageCard,
+			IsFlowers:         p.IsFlowers,
+			IsAddOn:           p.IsAddOn,
+			StockQuantity:     p.StockQuantity,
+			AvailableQuantity: p.AvailableQuantity,
+			DeletedAt:         nil,
+			CreatedAt:         p.CreatedAt,
+			CategoryData:      nil,
+		}
+
+		if p.TaxClassID.Valid {
+			taxClassID := uint32(p.TaxClassID.Int64)
+			product.TaxClassID = &taxClassID
+		}
+		if p.ReorderThreshold.Valid {
+			product.ReorderThreshold = &p.ReorderThreshold.Int64
+		}
+		if p.Sku.Valid {
+			product.SKU = &p.Sku.String
 		}
 
 		if p.CategoryID_2.Valid {
@@ -555,28 +613,32 @@ func (pr *ProductRepository) ListMessageCards(ctx context.Context) ([]*repositor
 			}
 		}
 
-		// Unmarshal stems JSON
-		if p.Stems != nil {
-			var stems []repository.ProductStem
+		// Unmarshal variants JSON
+		if p.Variants != nil {
+			var variants []repository.ProductVariant
 
-			switch v := p.Stems.(type) {
+			switch v := p.Variants.(type) {
 			case []byte:
-				if err := json.Unmarshal(v, &stems); err != nil {
-					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling stems: %s", err.Error())
+				if err := json.Unmarshal(v, &variants); err != nil {
+					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling variants: %s", err.Error())
 				}
 			case []interface{}:
 				raw, err := json.Marshal(v)
 				if err != nil {
-					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error marshaling stems interface{}: %s", err.Error())
+					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error marshaling variants interface{}: %s", err.Error())
 				}
-				if err := json.Unmarshal(raw, &stems); err != nil {
-					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling stems: %s", err.Error())
+				if err := json.Unmarshal(raw, &variants); err != nil {
+					return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling variants: %s", err.Error())
 				}
 			default:
-				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "unexpected stems type: %T", p.Stems)
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "unexpected variants type: %T", p.Variants)
 			}
 
-			product.Stems = stems
+			for j := range variants {
+				variants[j].Price = variants[j].Price.WithCurrency(product.Currency)
+				variants[j].BasePrice = ruleBasePrice(variants[j].Price, variants[j].BasePrice)
+			}
+			product.Variants = variants
 		}
 
 		products[i] = product
@@ -595,38 +657,535 @@ func (pr *ProductRepository) DeleteProduct(ctx context.Context, id int64) error
 	return nil
 }
 
-func (pr *ProductRepository) GetDashboardData(ctx context.Context) (interface{}, error) {
-	totalRevenue, err := pr.queries.TotalRevenue(ctx)
+func (pr *ProductRepository) RestoreProduct(ctx context.Context, id int64) (*repository.Product, error) {
+	restored, err := pr.queries.RestoreProduct(ctx, id)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error restoring product: %s", err.Error())
+	}
+	if restored == 0 {
+		return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "no deleted product with ID %d in a live category", id)
+	}
+
+	return pr.GetProductByID(ctx, id)
+}
+
+// stockReceipt is stock received from a supplier for a product or, when
+// VariantID is set, for one of its variants with stock of its own.
+type stockReceipt struct {
+	ProductID  int64
+	VariantID  pgtype.Int8
+	Quantity   int64
+	Supplier   pgtype.Text
+	ReceivedAt time.Time
+	// ExpiresAt receives perishable stock as a batch that expires then.
+	ExpiresAt *time.Time
+	Reason    string
+	UserID    uint32
+}
+
+// createProduct creates a product with its stock, variants and tags, setting
+// its ID and CreatedAt.
+func createProduct(ctx context.Context, q *generated.Queries, product *repository.Product) error {
+	taxClassID := pgtype.Int8{Valid: false}
+	if product.TaxClassID != nil {
+		if exists, _ := q.TaxClassExists(ctx, int64(*product.TaxClassID)); !exists {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "tax class with id %d not found", *product.TaxClassID)
+		}
+		taxClassID = pgtype.Int8{Valid: true, Int64: int64(*product.TaxClassID)}
+	}
+
+	generatedProduct, err := q.CreateProduct(ctx, generated.CreateProductParams{
+		Name:             product.Name,
+		Description:      product.Description,
+		Price:            product.Price.Numeric(),
+		CategoryID:       int64(product.CategoryID),
+		HasVariants:      product.HasVariants,
+		IsMessageCard:    product.IsMessageCard,
+		IsFlowers:        product.IsFlowers,
+		IsAddOn:          product.IsAddOn,
+		ImageUrl:         product.ImageUrl,
+		StockQuantity:    product.StockQuantity,
+		Currency:         string(product.Currency),
+		TaxClassID:       taxClassID,
+		ReorderThreshold: int8FromPtr(product.ReorderThreshold),
+		Sku:              textFromPtr(product.SKU),
+	})
+	if err != nil {
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "%s", err.Error())
+		}
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating product: %s", err.Error())
+	}
+
+	product.ID = uint32(generatedProduct.ID)
+	product.CreatedAt = generatedProduct.CreatedAt
+	product.CategoryData = nil
+
+	err = recordStockSet(ctx, q, generatedProduct.ID, pgtype.Int8{Valid: false}, &generatedProduct.StockQuantity, "stock set when creating the product", product.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	if product.HasVariants {
+		if err := saveProductVariants(ctx, q, product.ID, product.Options, product.Variants, product.CreatedBy); err != nil {
+			return err
+		}
+	}
+
+	if len(product.TagIDs) > 0 {
+		if err := saveProductTags(ctx, q, product.ID, product.TagIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateProduct sets the fields of a product that are not nil.
+func updateProduct(ctx context.Context, q *generated.Queries, product *repository.UpdateProduct) error {
+	params := generated.UpdateProductParams{
+		ID:               int64(product.ID),
+		Name:             pgtype.Text{Valid: false},
+		Description:      pgtype.Text{Valid: false},
+		Price:            pgtype.Numeric{Valid: false},
+		CategoryID:       pgtype.Int8{Valid: false},
+		HasVariants:      pgtype.Bool{Valid: false},
+		IsMessageCard:    pgtype.Bool{Valid: false},
+		IsFlowers:        pgtype.Bool{Valid: false},
+		IsAddOn:          pgtype.Bool{Valid: false},
+		ImageUrl:         nil,
+		StockQuantity:    pgtype.Int8{Valid: false},
+		Currency:         pgtype.Text{Valid: false},
+		TaxClassID:       pgtype.Int8{Valid: false},
+		ReorderThreshold: pgtype.Int8{Valid: false},
+		Sku:              textFromPtr(product.SKU),
+	}
+
+	if product.Name != nil {
+		params.Name = pgtype.Text{
+			Valid:  true,
+			String: *product.Name,
+		}
+	}
+	if product.Description != nil {
+		params.Description = pgtype.Text{
+			Valid:  true,
+			String: *product.Description,
+		}
+	}
+	if product.Price != nil {
+		params.Price = product.Price.Numeric()
+	}
+	if product.Currency != nil {
+		params.Currency = pgtype.Text{
+			Valid:  true,
+			String: *product.Currency,
+		}
+	}
+	if product.CategoryID != nil {
+		params.CategoryID = pgtype.Int8{
+			Valid: true,
+			Int64: int64(*product.CategoryID),
+		}
+	}
+	if product.TaxClassID != nil {
+		if exists, _ := q.TaxClassExists(ctx, int64(*product.TaxClassID)); !exists {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "tax class with id %d not found", *product.TaxClassID)
+		}
+		params.TaxClassID = pgtype.Int8{
+			Valid: true,
+			Int64: int64(*product.TaxClassID),
+		}
+	}
+	if product.ImageURL != nil {
+		params.ImageUrl = *product.ImageURL
+	}
+	if product.StockQuantity != nil {
+		params.StockQuantity = pgtype.Int8{Int64: int64(*product.StockQuantity), Valid: true}
+	}
+	if product.ReorderThreshold != nil {
+		if *product.ReorderThreshold < 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "reorder_threshold cannot be negative")
+		}
+		params.ReorderThreshold = pgtype.Int8{Int64: *product.ReorderThreshold, Valid: true}
+	}
+	if product.HasVariants != nil {
+		params.HasVariants = pgtype.Bool{Bool: *product.HasVariants, Valid: true}
+	}
+	if product.IsMessageCard != nil {
+		params.IsMessageCard = pgtype.Bool{Bool: *product.IsMessageCard, Valid: true}
+	}
+	if product.IsFlowers != nil {
+		params.IsFlowers = pgtype.Bool{Bool: *product.IsFlowers, Valid: true}
+	}
+	if product.IsAddOn != nil {
+		params.IsAddOn = pgtype.Bool{Bool: *product.IsAddOn, Valid: true}
+	}
+
+	if product.Variants != nil {
+		// variants missing from the list are removed
+		if err := saveProductVariants(ctx, q, product.ID, product.Options, product.Variants, product.UpdatedBy); err != nil {
+			return err
+		}
+	}
+
+	if product.TagIDs != nil {
+		if err := saveProductTags(ctx, q, product.ID, *product.TagIDs); err != nil {
+			return err
+		}
+	}
+
+	_, err := q.UpdateProduct(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with ID %d not found", product.ID)
+		}
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "%s", err.Error())
+		}
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error updating product: %s", err.Error())
+	}
+
+	if product.StockQuantity != nil {
+		return recordStockSet(ctx, q, int64(product.ID), pgtype.Int8{Valid: false}, product.StockQuantity, "stock_quantity set on the product", product.UpdatedBy)
+	}
+
+	return nil
+}
+
+// receiveProductStock adds received stock to its product or variant, through
+// a stock batch when it expires. The batch created, if any, is returned.
+func receiveProductStock(ctx context.Context, q *generated.Queries, receipt stockReceipt) (*generated.StockBatch, error) {
+	movement := generated.CreateStockMovementParams{
+		ProductID:    receipt.ProductID,
+		VariantID:    receipt.VariantID,
+		Kind:         repository.StockMovementReceipt,
+		Quantity:     receipt.Quantity,
+		Reason:       receipt.Reason,
+		UserID:       optionalID(receipt.UserID),
+		OrderID:      pgtype.Int8{Valid: false},
+		StockBatchID: pgtype.Int8{Valid: false},
+	}
+
+	if receipt.ExpiresAt == nil {
+		return nil, moveStock(ctx, q, movement)
+	}
+
+	if !receipt.ExpiresAt.After(receipt.ReceivedAt) {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "expires_at must be after received_at")
+	}
+
+	batch, err := q.CreateStockBatch(ctx, generated.CreateStockBatchParams{
+		ProductID:  receipt.ProductID,
+		VariantID:  receipt.VariantID,
+		Supplier:   receipt.Supplier,
+		ReceivedAt: receipt.ReceivedAt,
+		ExpiresAt:  *receipt.ExpiresAt,
+		Quantity:   receipt.Quantity,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating stock batch: %s", err.Error())
+	}
+	movement.StockBatchID = pgtype.Int8{Valid: true, Int64: batch.ID}
+
+	if err := moveStock(ctx, q, movement); err != nil {
+		return nil, err
+	}
+
+	return &batch, nil
+}
+
+// saveProductVariants upserts the options and variants of a product. Variants
+// missing from the list are soft deleted, since order items refer to them.
+func saveProductVariants(ctx context.Context, q *generated.Queries, productID uint32, options []repository.ProductOption, variants []repository.ProductVariant, userID uint32) error {
+	names := make([]string, 0, len(options))
+	valueIDs := make(map[string]map[string]int64, len(options))
+	for position, option := range options {
+		name := strings.TrimSpace(option.Name)
+		if name == "" {
+			return pkg.Errorf(pkg.INVALID_ERROR, "option name is required")
+		}
+		if _, ok := valueIDs[name]; ok {
+			return pkg.Errorf(pkg.INVALID_ERROR, "option %q is listed more than once", name)
+		}
+
+		optionTypeID, err := q.UpsertProductOptionType(ctx, generated.UpsertProductOptionTypeParams{
+			ProductID: int64(productID),
+			Name:      name,
+			Position:  int32(position),
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error saving product option: %s", err.Error())
+		}
+
+		names = append(names, name)
+		valueIDs[name] = make(map[string]int64, len(option.Values))
+		for valuePosition, value := range option.Values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return pkg.Errorf(pkg.INVALID_ERROR, "option %q has an empty value", name)
+			}
+
+			valueID, err := q.UpsertProductOptionValue(ctx, generated.UpsertProductOptionValueParams{
+				OptionTypeID: optionTypeID,
+				Value:        value,
+				Position:     int32(valuePosition),
+			})
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error saving product option value: %s", err.Error())
+			}
+			valueIDs[name][value] = valueID
+		}
+	}
+
+	skus := make([]string, 0, len(variants))
+	combinations := make(map[string]string, len(variants))
+	for _, variant := range variants {
+		sku := strings.TrimSpace(variant.SKU)
+		if sku == "" {
+			return pkg.Errorf(pkg.INVALID_ERROR, "variant sku is required")
+		}
+		if slices.Contains(skus, sku) {
+			return pkg.Errorf(pkg.INVALID_ERROR, "sku %s is listed more than once", sku)
+		}
+		if variant.StockQuantity != nil && *variant.StockQuantity < 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "variant %s cannot have a negative stock_quantity", sku)
+		}
+		if variant.ReorderThreshold != nil && *variant.ReorderThreshold < 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "variant %s cannot have a negative reorder_threshold", sku)
+		}
+
+		// a variant takes exactly one value of every option
+		values := make(map[string]string, len(variant.Options))
+		for _, option := range variant.Options {
+			values[strings.TrimSpace(option.Name)] = strings.TrimSpace(option.Value)
+		}
+		if len(values) != len(variant.Options) || len(values) != len(names) {
+			return pkg.Errorf(pkg.INVALID_ERROR, "variant %s must have one value for each of the options %s", sku, strings.Join(names, ", "))
+		}
+
+		optionValueIDs := make([]int64, len(names))
+		for i, name := range names {
+			valueID, ok := valueIDs[name][values[name]]
+			if !ok {
+				return pkg.Errorf(pkg.INVALID_ERROR, "variant %s has unknown %s %q", sku, name, values[name])
+			}
+			optionValueIDs[i] = valueID
+		}
+
+		combination := fmt.Sprint(optionValueIDs)
+		if other, ok := combinations[combination]; ok {
+			return pkg.Errorf(pkg.INVALID_ERROR, "variants %s and %s have the same options", other, sku)
+		}
+		combinations[combination] = sku
+
+		imageUrl := variant.ImageUrl
+		if imageUrl == nil {
+			imageUrl = []string{}
+		}
+
+		variantID, err := q.UpsertProductVariant(ctx, generated.UpsertProductVariantParams{
+			ProductID:        int64(productID),
+			Sku:              sku,
+			Price:            variant.Price.Numeric(),
+			StockQuantity:    int8FromPtr(variant.StockQuantity),
+			ImageUrl:         imageUrl,
+			ReorderThreshold: int8FromPtr(variant.ReorderThreshold),
+		})
+		if err != nil {
+			// the upsert only updates variants of the same product
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "sku %s is used by another product", sku)
+			}
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error saving product variant: %s", err.Error())
+		}
+
+		err = recordStockSet(ctx, q, int64(productID), pgtype.Int8{Valid: true, Int64: variantID}, variant.StockQuantity, "stock_quantity set on the variant", userID)
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteProductVariantOptions(ctx, variantID); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error saving product variant options: %s", err.Error())
+		}
+		for _, optionValueID := range optionValueIDs {
+			err := q.CreateProductVariantOption(ctx, generated.CreateProductVariantOptionParams{
+				VariantID:     variantID,
+				OptionValueID: optionValueID,
+			})
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error saving product variant options: %s", err.Error())
+			}
+		}
+
+		skus = append(skus, sku)
+	}
+
+	err := q.DeleteProductVariantsNotIn(ctx, generated.DeleteProductVariantsNotInParams{
+		ProductID: int64(productID),
+		Skus:      skus,
+	})
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error removing product variants: %s", err.Error())
+	}
+
+	return nil
+}
+
+func listProductVariants(ctx context.Context, q *generated.Queries, productID uint32, currency pkg.Currency) ([]repository.ProductOption, []repository.ProductVariant, error) {
+	optionRows, err := q.ListProductOptionsByProductID(ctx, int64(productID))
 	if err != nil {
-		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching total revenue: %s", err.Error())
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting product options: %s", err.Error())
 	}
-	totalProducts, err := pr.queries.TotalProducts(ctx)
+
+	options := []repository.ProductOption{}
+	for i, row := range optionRows {
+		if i == 0 || optionRows[i-1].OptionTypeID != row.OptionTypeID {
+			options = append(options, repository.ProductOption{Name: row.Name})
+		}
+		option := &options[len(options)-1]
+		option.Values = append(option.Values, row.Value)
+	}
+
+	variantRows, err := q.ListProductVariantsByProductID(ctx, int64(productID))
 	if err != nil {
-		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching total products: %s", err.Error())
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting product variants: %s", err.Error())
 	}
-	totalOrders, err := pr.queries.TotalOrders(ctx)
+
+	variants := make([]repository.ProductVariant, len(variantRows))
+	for i, row := range variantRows {
+		price, basePrice, err := productPrices(row.EffectivePrice, row.Price, currency)
+		if err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for product variant %d: %s", row.ID, err.Error())
+		}
+
+		variants[i] = repository.ProductVariant{
+			ID:            uint32(row.ID),
+			ProductID:     uint32(row.ProductID),
+			SKU:           row.Sku,
+			Price:         price,
+			BasePrice:     basePrice,
+			StockQuantity: nil,
+			ImageUrl:      row.ImageUrl,
+		}
+
+		if row.StockQuantity.Valid {
+			variants[i].StockQuantity = &row.StockQuantity.Int64
+		}
+		if row.AvailableQuantity.Valid {
+			variants[i].AvailableQuantity = &row.AvailableQuantity.Int64
+		}
+		if row.ReorderThreshold.Valid {
+			variants[i].ReorderThreshold = &row.ReorderThreshold.Int64
+		}
+
+		if err := json.Unmarshal(row.Options, &variants[i].Options); err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error unmarshaling variant options: %s", err.Error())
+		}
+	}
+
+	return options, variants, nil
+}
+
+// productPrices converts the price customers pay and the base price of a
+// product or variant, the base price is nil unless a price rule changes it.
+func productPrices(effective, base pgtype.Numeric, currency pkg.Currency) (pkg.Money, *pkg.Money, error) {
+	price, err := pkg.NumericToMoney(effective, currency)
 	if err != nil {
-		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching total orders: %s", err.Error())
+		return pkg.Money{}, nil, err
+	}
+
+	basePrice, err := pkg.NumericToMoney(base, currency)
+	if err != nil {
+		return pkg.Money{}, nil, err
+	}
+
+	return price, ruleBasePrice(price, &basePrice), nil
+}
+
+// ruleBasePrice returns the base price when a price rule makes the price
+// differ from it.
+func ruleBasePrice(price pkg.Money, base *pkg.Money) *pkg.Money {
+	if base == nil {
+		return nil
+	}
+
+	basePrice := base.WithCurrency(price.Currency())
+	if basePrice.Equal(price) {
+		return nil
 	}
-	activeSubscriptions, err := pr.queries.ActiveSubscriptions(ctx)
+
+	return &basePrice
+}
+
+// saveProductTags replaces the tags of a product, every tag has to exist.
+func saveProductTags(ctx context.Context, q *generated.Queries, productID uint32, tagIDs []uint32) error {
+	ids := make([]int64, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		if !slices.Contains(ids, int64(id)) {
+			ids = append(ids, int64(id))
+		}
+	}
+
+	liveTags, err := q.CountLiveTags(ctx, ids)
 	if err != nil {
-		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching active subscriptions: %s", err.Error())
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error checking tags: %s", err.Error())
 	}
-	recentOrders, err := pr.queries.GetRecentOrders(ctx)
+	if liveTags != int64(len(ids)) {
+		return pkg.Errorf(pkg.NOT_FOUND_ERROR, "one or more of the tags %v not found", tagIDs)
+	}
+
+	if err := q.DeleteProductTags(ctx, int64(productID)); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error removing product tags: %s", err.Error())
+	}
+
+	err = q.AddProductTags(ctx, generated.AddProductTagsParams{
+		ProductID: int64(productID),
+		TagIds:    ids,
+	})
 	if err != nil {
-		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching recent orders: %s", err.Error())
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error saving product tags: %s", err.Error())
 	}
-	categoriesData, err := pr.queries.GetCategoriesWithProductCount(ctx)
+
+	return nil
+}
+
+func listProductTags(ctx context.Context, q *generated.Queries, productID uint32) ([]repository.Tag, error) {
+	rows, err := q.ListTagsByProductID(ctx, int64(productID))
 	if err != nil {
-		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching categories with product count: %s", err.Error())
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting product tags: %s", err.Error())
+	}
+
+	tags := make([]repository.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = *generatedTagToRepo(generated.Tag{
+			ID:          row.ID,
+			TagGroupID:  row.TagGroupID,
+			Name:        row.Name,
+			Slug:        row.Slug,
+			Description: row.Description,
+			ImageUrl:    row.ImageUrl,
+			Position:    row.Position,
+			DeletedAt:   row.DeletedAt,
+			CreatedAt:   row.CreatedAt,
+		})
+		tags[i].GroupName = row.GroupName
+		tags[i].GroupSlug = row.GroupSlug
+	}
+
+	return tags, nil
+}
+
+// unmarshalJSONColumn decodes a json column pgx scanned into an interface{},
+// which holds either the raw bytes or the already decoded value.
+func unmarshalJSONColumn(column interface{}, v any) error {
+	raw, ok := column.([]byte)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(column); err != nil {
+			return err
+		}
 	}
 
-	return map[string]interface{}{
-		"total_revenue":        totalRevenue,
-		"total_products":       totalProducts,
-		"total_orders":         totalOrders,
-		"active_subscriptions": activeSubscriptions,
-		"recent_orders":        recentOrders,
-		"categories":           categoriesData,
-	}, nil
+	return json.Unmarshal(raw, v)
 }
@@ -0,0 +1,516 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.PurchaseOrderRepository = (*PurchaseOrderRepository)(nil)
+
+type PurchaseOrderRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewPurchaseOrderRepository(db *Store) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+func (por *PurchaseOrderRepository) CreatePurchaseOrder(ctx context.Context, order *repository.PurchaseOrder) (*repository.PurchaseOrder, error) {
+	if order.Currency == "" {
+		order.Currency = pkg.DefaultCurrency
+	}
+	if _, err := por.queries.GetSupplierByID(ctx, int64(order.SupplierID)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "supplier with id %d not found", order.SupplierID)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching supplier by id: %s", err.Error())
+	}
+
+	var createdBy uint32
+	if order.CreatedBy != nil {
+		createdBy = *order.CreatedBy
+	}
+
+	var orderID int64
+	err := por.db.ExecTx(ctx, func(q *generated.Queries) error {
+		newOrder, err := q.CreatePurchaseOrder(ctx, generated.CreatePurchaseOrderParams{
+			SupplierID: int64(order.SupplierID),
+			Currency:   string(order.Currency),
+			ExpectedAt: timestamptzFromPtr(order.ExpectedAt),
+			Notes:      textFromPtr(order.Notes),
+			CreatedBy:  optionalID(createdBy),
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating purchase order: %s", err.Error())
+		}
+		orderID = newOrder.ID
+
+		return savePurchaseOrderItems(ctx, q, orderID, order.Items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return por.GetPurchaseOrderByID(ctx, orderID)
+}
+
+func (por *PurchaseOrderRepository) GetPurchaseOrderByID(ctx context.Context, id int64) (*repository.PurchaseOrder, error) {
+	row, err := por.queries.GetPurchaseOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "purchase order with id %d not found", id)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching purchase order by id: %s", err.Error())
+	}
+
+	order, err := generatedPurchaseOrderToRepo(generated.ListPurchaseOrdersRow(row))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := por.queries.ListPurchaseOrderItems(ctx, id)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing purchase order items: %s", err.Error())
+	}
+
+	order.Items = make([]repository.PurchaseOrderItem, len(items))
+	for i, item := range items {
+		unitCost, err := pkg.NumericToMoney(item.UnitCost, order.Currency)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid unit cost for purchase order item with id %d: %s", item.ID, err.Error())
+		}
+
+		order.Items[i] = repository.PurchaseOrderItem{
+			ID:               uint32(item.ID),
+			ProductID:        uint32(item.ProductID),
+			ProductName:      item.ProductName,
+			VariantID:        nil,
+			VariantSku:       nil,
+			Quantity:         item.Quantity,
+			ReceivedQuantity: item.ReceivedQuantity,
+			UnitCost:         unitCost,
+		}
+		if item.VariantID.Valid {
+			variantID := uint32(item.VariantID.Int64)
+			order.Items[i].VariantID = &variantID
+		}
+		if item.VariantSku.Valid {
+			order.Items[i].VariantSku = &item.VariantSku.String
+		}
+	}
+
+	return order, nil
+}
+
+// UpdatePurchaseOrder edits the supplier, currency and items of drafts only.
+// Status moves a draft with items to ordered, or cancels a purchase order
+// nothing has been received against.
+func (por *PurchaseOrderRepository) UpdatePurchaseOrder(ctx context.Context, order *repository.UpdatePurchaseOrder) (*repository.PurchaseOrder, error) {
+	err := por.db.ExecTx(ctx, func(q *generated.Queries) error {
+		current, err := q.GetPurchaseOrderForUpdate(ctx, int64(order.ID))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "purchase order with id %d not found", order.ID)
+			}
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching purchase order by id: %s", err.Error())
+		}
+
+		if current.Status != repository.PurchaseOrderDraft && (order.SupplierID != nil || order.Currency != nil || order.Items != nil) {
+			return pkg.Errorf(pkg.INVALID_ERROR, "purchase order with id %d is %s, only drafts can be edited", order.ID, current.Status)
+		}
+
+		params := generated.UpdatePurchaseOrderParams{
+			SupplierID:  pgtype.Int8{Valid: false},
+			Currency:    pgtype.Text{Valid: false},
+			Status:      pgtype.Text{Valid: false},
+			ExpectedAt:  timestamptzFromPtr(order.ExpectedAt),
+			Notes:       textFromPtr(order.Notes),
+			OrderedAt:   pgtype.Timestamptz{Valid: false},
+			ReceivedAt:  pgtype.Timestamptz{Valid: false},
+			CancelledAt: pgtype.Timestamptz{Valid: false},
+			ID:          current.ID,
+		}
+
+		if order.SupplierID != nil {
+			if _, err := q.GetSupplierByID(ctx, int64(*order.SupplierID)); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return pkg.Errorf(pkg.NOT_FOUND_ERROR, "supplier with id %d not found", *order.SupplierID)
+				}
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching supplier by id: %s", err.Error())
+			}
+			params.SupplierID = pgtype.Int8{Valid: true, Int64: int64(*order.SupplierID)}
+		}
+		if order.Currency != nil {
+			params.Currency = pgtype.Text{Valid: true, String: string(*order.Currency)}
+		}
+		if order.Items != nil {
+			if err := q.DeletePurchaseOrderItems(ctx, current.ID); err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting purchase order items: %s", err.Error())
+			}
+			if err := savePurchaseOrderItems(ctx, q, current.ID, *order.Items); err != nil {
+				return err
+			}
+		}
+
+		if order.Status != nil && *order.Status != current.Status {
+			now := time.Now()
+			switch {
+			case current.Status == repository.PurchaseOrderDraft && *order.Status == repository.PurchaseOrderOrdered:
+				items, err := q.ListPurchaseOrderItems(ctx, current.ID)
+				if err != nil {
+					return pkg.Errorf(pkg.INTERNAL_ERROR, "error listing purchase order items: %s", err.Error())
+				}
+				if len(items) == 0 {
+					return pkg.Errorf(pkg.INVALID_ERROR, "purchase order with id %d has no items to order", order.ID)
+				}
+				params.OrderedAt = pgtype.Timestamptz{Valid: true, Time: now}
+			case (current.Status == repository.PurchaseOrderDraft || current.Status == repository.PurchaseOrderOrdered) && *order.Status == repository.PurchaseOrderCancelled:
+				params.CancelledAt = pgtype.Timestamptz{Valid: true, Time: now}
+			default:
+				return pkg.Errorf(pkg.INVALID_ERROR, "purchase order with id %d cannot be moved from %s to %s", order.ID, current.Status, *order.Status)
+			}
+			params.Status = pgtype.Text{Valid: true, String: *order.Status}
+		}
+
+		if _, err := q.UpdatePurchaseOrder(ctx, params); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error updating purchase order: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return por.GetPurchaseOrderByID(ctx, int64(order.ID))
+}
+
+func (por *PurchaseOrderRepository) ListPurchaseOrders(ctx context.Context, filter *repository.PurchaseOrderFilter) ([]*repository.PurchaseOrder, *pkg.Pagination, error) {
+	countParams := generated.ListPurchaseOrdersCountParams{
+		SupplierID: pgtype.Int8{Valid: false},
+		Status:     textFromPtr(filter.Status),
+	}
+	if filter.SupplierID != nil {
+		countParams.SupplierID = pgtype.Int8{Valid: true, Int64: int64(*filter.SupplierID)}
+	}
+
+	rows, err := por.queries.ListPurchaseOrders(ctx, generated.ListPurchaseOrdersParams{
+		SupplierID: countParams.SupplierID,
+		Status:     countParams.Status,
+		Offset:     pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
+		Limit:      int32(filter.Pagination.PageSize),
+	})
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing purchase orders: %s", err.Error())
+	}
+
+	totalCount, err := por.queries.ListPurchaseOrdersCount(ctx, countParams)
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting purchase orders: %s", err.Error())
+	}
+
+	orders := make([]*repository.PurchaseOrder, len(rows))
+	for i, row := range rows {
+		orders[i], err = generatedPurchaseOrderToRepo(row)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return orders, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+}
+
+func (por *PurchaseOrderRepository) ReceivePurchaseOrder(ctx context.Context, receipt *repository.PurchaseOrderReceipt) (*repository.PurchaseOrder, error) {
+	if len(receipt.Items) == 0 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "no items received")
+	}
+
+	err := por.db.ExecTx(ctx, func(q *generated.Queries) error {
+		if _, err := q.GetPurchaseOrderForUpdate(ctx, int64(receipt.PurchaseOrderID)); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "purchase order with id %d not found", receipt.PurchaseOrderID)
+			}
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching purchase order by id: %s", err.Error())
+		}
+
+		order, err := q.GetPurchaseOrderByID(ctx, int64(receipt.PurchaseOrderID))
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching purchase order by id: %s", err.Error())
+		}
+		if order.Status != repository.PurchaseOrderOrdered && order.Status != repository.PurchaseOrderPartiallyReceived {
+			return pkg.Errorf(pkg.INVALID_ERROR, "purchase order with id %d is %s, only ordered purchase orders are received", order.ID, order.Status)
+		}
+
+		items, err := q.ListPurchaseOrderItems(ctx, order.ID)
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error listing purchase order items: %s", err.Error())
+		}
+		outstanding := make(map[int64]int64, len(items))
+		for _, item := range items {
+			outstanding[item.ID] = item.Quantity - item.ReceivedQuantity
+		}
+
+		now := time.Now()
+		for _, received := range receipt.Items {
+			itemID := int64(received.ItemID)
+			left, ok := outstanding[itemID]
+			if !ok {
+				return pkg.Errorf(pkg.NOT_FOUND_ERROR, "item with id %d not found on purchase order with id %d", received.ItemID, order.ID)
+			}
+			if received.Quantity <= 0 {
+				return pkg.Errorf(pkg.INVALID_ERROR, "quantity received of item with id %d must be greater than 0", received.ItemID)
+			}
+			if received.Quantity > left {
+				return pkg.Errorf(pkg.INVALID_ERROR, "only %d of item with id %d are still to be received", left, received.ItemID)
+			}
+			outstanding[itemID] = left - received.Quantity
+
+			item, err := q.ReceivePurchaseOrderItem(ctx, generated.ReceivePurchaseOrderItemParams{
+				Quantity:        received.Quantity,
+				ID:              itemID,
+				PurchaseOrderID: order.ID,
+			})
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error receiving purchase order item with id %d: %s", received.ItemID, err.Error())
+			}
+
+			_, err = receiveProductStock(ctx, q, stockReceipt{
+				ProductID:  item.ProductID,
+				VariantID:  item.VariantID,
+				Quantity:   received.Quantity,
+				Supplier:   pgtype.Text{Valid: true, String: order.SupplierName},
+				ReceivedAt: now,
+				ExpiresAt:  received.ExpiresAt,
+				Reason:     fmt.Sprintf("received against purchase order %d", order.ID),
+				UserID:     receipt.UserID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		params := generated.UpdatePurchaseOrderParams{
+			SupplierID:  pgtype.Int8{Valid: false},
+			Currency:    pgtype.Text{Valid: false},
+			Status:      pgtype.Text{Valid: true, String: repository.PurchaseOrderReceived},
+			ExpectedAt:  pgtype.Timestamptz{Valid: false},
+			Notes:       pgtype.Text{Valid: false},
+			OrderedAt:   pgtype.Timestamptz{Valid: false},
+			ReceivedAt:  pgtype.Timestamptz{Valid: true, Time: now},
+			CancelledAt: pgtype.Timestamptz{Valid: false},
+			ID:          order.ID,
+		}
+		for _, left := range outstanding {
+			if left > 0 {
+				params.Status.String = repository.PurchaseOrderPartiallyReceived
+				params.ReceivedAt = pgtype.Timestamptz{Valid: false}
+				break
+			}
+		}
+
+		if _, err := q.UpdatePurchaseOrder(ctx, params); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error updating purchase order: %s", err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return por.GetPurchaseOrderByID(ctx, int64(receipt.PurchaseOrderID))
+}
+
+func (por *PurchaseOrderRepository) GetMarginReport(ctx context.Context, from, to time.Time) (*repository.MarginReport, error) {
+	if !to.After(from) {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "to must be after from")
+	}
+
+	rows, err := por.queries.GetMarginReport(ctx, generated.GetMarginReportParams{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting margin report: %s", err.Error())
+	}
+
+	report := &repository.MarginReport{
+		From:   from,
+		To:     to,
+		Totals: []repository.MarginReportTotal{},
+		Items:  make([]repository.MarginReportItem, len(rows)),
+	}
+
+	// rows come ordered by currency
+	var total *repository.MarginReportTotal
+	for i, row := range rows {
+		currency := pkg.Currency(row.Currency)
+		if total == nil || total.Currency != currency {
+			report.Totals = append(report.Totals, repository.MarginReportTotal{
+				Currency:        currency,
+				Revenue:         pkg.NewMoney(0, currency),
+				Cost:            pkg.NewMoney(0, currency),
+				Margin:          pkg.NewMoney(0, currency),
+				UncostedRevenue: pkg.NewMoney(0, currency),
+			})
+			total = &report.Totals[len(report.Totals)-1]
+		}
+
+		revenue, err := pkg.NumericToMoney(row.Revenue, currency)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid revenue for product with id %d: %s", row.ProductID, err.Error())
+		}
+
+		item := repository.MarginReportItem{
+			ProductID:   uint32(row.ProductID),
+			ProductName: row.ProductName,
+			VariantID:   nil,
+			VariantSku:  nil,
+			Currency:    currency,
+			Quantity:    row.Quantity,
+			Revenue:     revenue,
+			UnitCost:    nil,
+			Cost:        nil,
+			Margin:      nil,
+			Uncosted:    !row.Cost.Valid,
+		}
+		if row.VariantID.Valid {
+			variantID := uint32(row.VariantID.Int64)
+			item.VariantID = &variantID
+		}
+		if row.VariantSku.Valid {
+			item.VariantSku = &row.VariantSku.String
+		}
+
+		// no stock of the item, or of one of its components, was received
+		// through a purchase order in the currency it was sold in
+		if item.Uncosted {
+			if total.UncostedRevenue, err = total.UncostedRevenue.Add(revenue); err != nil {
+				return nil, err
+			}
+		} else {
+			unitCost, err := pkg.NumericToMoney(row.UnitCost, currency)
+			if err != nil {
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid unit cost for product with id %d: %s", row.ProductID, err.Error())
+			}
+			cost, err := pkg.NumericToMoney(row.Cost, currency)
+			if err != nil {
+				return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid cost for product with id %d: %s", row.ProductID, err.Error())
+			}
+			margin, err := revenue.Sub(cost)
+			if err != nil {
+				return nil, err
+			}
+			item.UnitCost, item.Cost, item.Margin = &unitCost, &cost, &margin
+
+			if total.Revenue, err = total.Revenue.Add(revenue); err != nil {
+				return nil, err
+			}
+			if total.Cost, err = total.Cost.Add(cost); err != nil {
+				return nil, err
+			}
+			if total.Margin, err = total.Margin.Add(margin); err != nil {
+				return nil, err
+			}
+		}
+
+		report.Items[i] = item
+	}
+
+	return report, nil
+}
+
+// savePurchaseOrderItems adds items to a purchase order, their unit costs are
+// in the purchase order's currency.
+func savePurchaseOrderItems(ctx context.Context, q *generated.Queries, orderID int64, items []repository.PurchaseOrderItem) error {
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return pkg.Errorf(pkg.INVALID_ERROR, "quantity of product with id %d must be greater than 0", item.ProductID)
+		}
+		if item.UnitCost.IsNegative() {
+			return pkg.Errorf(pkg.INVALID_ERROR, "unit cost of product with id %d cannot be negative", item.ProductID)
+		}
+
+		variantID, err := stockVariantID(ctx, q, item.ProductID, item.VariantID)
+		if err != nil {
+			return err
+		}
+
+		err = q.CreatePurchaseOrderItem(ctx, generated.CreatePurchaseOrderItemParams{
+			PurchaseOrderID: orderID,
+			ProductID:       int64(item.ProductID),
+			VariantID:       variantID,
+			Quantity:        item.Quantity,
+			UnitCost:        item.UnitCost.Numeric(),
+		})
+		if err != nil {
+			if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+				return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d is on the purchase order more than once", item.ProductID)
+			}
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating purchase order item: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func generatedPurchaseOrderToRepo(row generated.ListPurchaseOrdersRow) (*repository.PurchaseOrder, error) {
+	currency := pkg.Currency(row.Currency)
+	totalCost, err := pkg.NumericToMoney(row.TotalCost, currency)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid total cost for purchase order with id %d: %s", row.ID, err.Error())
+	}
+
+	order := &repository.PurchaseOrder{
+		ID:           uint32(row.ID),
+		SupplierID:   uint32(row.SupplierID),
+		SupplierName: row.SupplierName,
+		Status:       row.Status,
+		Currency:     currency,
+		TotalCost:    totalCost,
+		ExpectedAt:   nil,
+		Notes:        nil,
+		CreatedBy:    nil,
+		OrderedAt:    nil,
+		ReceivedAt:   nil,
+		CancelledAt:  nil,
+		CreatedAt:    row.CreatedAt,
+		Items:        nil,
+	}
+
+	if row.Notes.Valid {
+		order.Notes = &row.Notes.String
+	}
+	if row.CreatedBy.Valid {
+		createdBy := uint32(row.CreatedBy.Int64)
+		order.CreatedBy = &createdBy
+	}
+	for _, field := range []struct {
+		value  pgtype.Timestamptz
+		target **time.Time
+	}{
+		{row.ExpectedAt, &order.ExpectedAt},
+		{row.OrderedAt, &order.OrderedAt},
+		{row.ReceivedAt, &order.ReceivedAt},
+		{row.CancelledAt, &order.CancelledAt},
+	} {
+		if field.value.Valid {
+			value := field.value.Time
+			*field.target = &value
+		}
+	}
+
+	return order, nil
+}
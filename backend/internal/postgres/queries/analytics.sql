@@ -0,0 +1,115 @@
+-- name: GetRevenueByPeriod :many
+-- payments received between from and to per period of granularity (day, week
+-- or month) and currency. Periods without payments are left out.
+SELECT 
+    date_trunc(sqlc.arg('granularity')::text, paid_at)::timestamptz AS period,
+    currency,
+    COUNT(*) AS payments,
+    SUM(amount)::decimal AS revenue
+FROM payments
+WHERE paid_at >= sqlc.arg('from') AND paid_at < sqlc.arg('to')
+GROUP BY period, currency
+ORDER BY period, currency;
+
+-- name: GetOrdersByStatus :many
+SELECT status, COUNT(*) AS orders
+FROM orders
+WHERE deleted_at IS NULL
+    AND created_at >= sqlc.arg('from')
+    AND created_at < sqlc.arg('to')
+GROUP BY status
+ORDER BY orders DESC, status;
+
+-- name: GetAverageOrderValues :many
+-- the paid orders placed between from and to per currency.
+SELECT currency, COUNT(*) AS orders, SUM(total_amount)::decimal AS revenue
+FROM orders
+WHERE payment_status = TRUE
+    AND deleted_at IS NULL
+    AND created_at >= sqlc.arg('from')
+    AND created_at < sqlc.arg('to')
+GROUP BY currency
+ORDER BY currency;
+
+-- name: GetTopProducts :many
+-- the products sold most in the paid orders placed between from and to.
+SELECT 
+    oi.product_id,
+    p.name AS product_name,
+    o.currency,
+    SUM(oi.quantity)::bigint AS quantity,
+    SUM(oi.amount)::decimal AS revenue
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+JOIN products p ON p.id = oi.product_id
+WHERE o.payment_status = TRUE
+    AND o.deleted_at IS NULL
+    AND o.created_at >= sqlc.arg('from')
+    AND o.created_at < sqlc.arg('to')
+GROUP BY oi.product_id, p.name, o.currency
+ORDER BY quantity DESC, revenue DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetTopCategories :many
+-- the categories whose products sold most in the paid orders placed between
+-- from and to.
+SELECT 
+    c.id AS category_id,
+    c.name AS category_name,
+    o.currency,
+    SUM(oi.quantity)::bigint AS quantity,
+    SUM(oi.amount)::decimal AS revenue
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+JOIN products p ON p.id = oi.product_id
+JOIN categories c ON c.id = p.category_id
+WHERE o.payment_status = TRUE
+    AND o.deleted_at IS NULL
+    AND o.created_at >= sqlc.arg('from')
+    AND o.created_at < sqlc.arg('to')
+GROUP BY c.id, c.name, o.currency
+ORDER BY quantity DESC, revenue DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetCustomerCounts :one
+-- the customers, told apart by phone number, who ordered between from and to
+-- and those of them who had not ordered before.
+WITH customers AS (
+    SELECT user_phone_number, MIN(created_at) AS first_ordered_at
+    FROM orders
+    WHERE deleted_at IS NULL
+    GROUP BY user_phone_number
+    HAVING bool_or(created_at >= sqlc.arg('from') AND created_at < sqlc.arg('to'))
+)
+SELECT 
+    COUNT(*) AS customers,
+    COUNT(*) FILTER (WHERE first_ordered_at >= sqlc.arg('from')) AS new_customers
+FROM customers;
+
+-- name: GetSubscriptionCounts :one
+-- the subscriptions running at from and at to, those started between them and
+-- those churned, cancelled or run out, between them. A subscription stops at
+-- the first of its cancellation and its end date.
+WITH spans AS (
+    SELECT start_date, LEAST(cancelled_at, end_date) AS stopped_at
+    FROM user_subscriptions
+    WHERE deleted_at IS NULL
+)
+SELECT 
+    COUNT(*) FILTER (WHERE start_date < sqlc.arg('from') AND stopped_at >= sqlc.arg('from')) AS active_at_start,
+    COUNT(*) FILTER (WHERE start_date < sqlc.arg('to') AND stopped_at >= sqlc.arg('to')) AS active_at_end,
+    COUNT(*) FILTER (WHERE start_date >= sqlc.arg('from') AND start_date < sqlc.arg('to')) AS started,
+    COUNT(*) FILTER (WHERE stopped_at >= sqlc.arg('from') AND stopped_at < LEAST(sqlc.arg('to'), now())) AS churned
+FROM spans;
+
+-- name: ListSubscriptionPrices :many
+-- the price per delivery of the subscriptions running at at, per currency and
+-- delivery frequency.
+SELECT s.currency, us.frequency, COUNT(*) AS subscriptions, SUM(s.price)::decimal AS price
+FROM user_subscriptions us
+JOIN subscriptions s ON s.id = us.subscription_id
+WHERE us.deleted_at IS NULL
+    AND us.start_date <= sqlc.arg('at')
+    AND LEAST(us.cancelled_at, us.end_date) >= sqlc.arg('at')
+GROUP BY s.currency, us.frequency
+ORDER BY s.currency, us.frequency;
@@ -1,28 +1,71 @@
 -- name: CreateCategory :one
-INSERT INTO categories (name, description, image_url)
-VALUES ($1, $2, $3)
+INSERT INTO categories (name, description, image_url, tax_class_id, parent_id)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetCategoryByID :one
 SELECT * FROM categories WHERE id = $1;
 
 -- name: GetCategoriesWithProductCount :many
-SELECT 
-    c.*, 
-    COUNT(p.id) AS products_count
-FROM categories c
-LEFT JOIN products p 
-    ON p.category_id = c.id 
-   AND p.deleted_at IS NULL  
-WHERE c.deleted_at IS NULL
-GROUP BY c.id;
+SELECT * FROM categories
+WHERE deleted_at IS NULL
+ORDER BY name;
+
+-- name: RecountCategoryProducts :execrows
+-- repairs the product counts that drifted from the products table
+UPDATE categories c
+SET product_count = counted.product_count
+FROM (
+    SELECT c2.id, COUNT(p.id) AS product_count
+    FROM categories c2
+    LEFT JOIN products p ON p.category_id = c2.id AND p.deleted_at IS NULL
+    GROUP BY c2.id
+) counted
+WHERE c.id = counted.id AND c.product_count <> counted.product_count;
+
+-- name: GetCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT c.id, c.name, c.parent_id, 0 AS depth
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.name, c.parent_id, a.depth + 1
+    FROM categories c
+    JOIN ancestors a ON c.id = a.parent_id
+    WHERE a.depth < 32
+)
+SELECT id, name FROM ancestors
+ORDER BY depth DESC;
+
+-- name: CategoryExists :one
+SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL) AS exists;
+
+-- name: IsCategoryInSubtree :one
+SELECT sqlc.arg('category_id')::bigint = ANY(category_subtree_ids(ARRAY[sqlc.arg('root_id')::bigint])) AS in_subtree;
+
+-- name: CountCategoryChildren :one
+SELECT COUNT(*) AS total_children
+FROM categories
+WHERE parent_id = $1 AND deleted_at IS NULL;
+
+-- name: CountCategoryProducts :one
+SELECT COUNT(*) AS total_products
+FROM products
+WHERE category_id = $1 AND deleted_at IS NULL;
 
 
 -- name: UpdateCategory :one
+-- a parent_id of 0 moves the category to the top level
 UPDATE categories
 SET name = coalesce(sqlc.narg('name'), name),
     description = coalesce(sqlc.narg('description'), description),
-    image_url = coalesce(sqlc.narg('image_url'), image_url)
+    image_url = coalesce(sqlc.narg('image_url'), image_url),
+    tax_class_id = coalesce(sqlc.narg('tax_class_id'), tax_class_id),
+    parent_id = CASE
+        WHEN sqlc.narg('parent_id')::bigint IS NULL THEN parent_id
+        WHEN sqlc.narg('parent_id')::bigint = 0 THEN NULL
+        ELSE sqlc.narg('parent_id')::bigint
+    END
 WHERE id = sqlc.arg('id')
 RETURNING *;
 
@@ -38,6 +81,12 @@ WHERE
 ORDER BY created_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
+-- name: ListCategoryIDsByName :many
+-- the live categories named name, ignoring case.
+SELECT id FROM categories
+WHERE deleted_at IS NULL AND LOWER(name) = LOWER(sqlc.arg('name'))
+ORDER BY id;
+
 -- name: ListCategoriesCount :one
 SELECT COUNT(*) AS total_categories
 FROM categories
@@ -4,19 +4,35 @@ SELECT
     c.id AS category_id,
     c.name AS category_name, 
     c.description AS category_description,
-    COALESCE(
-        json_agg(
-            jsonb_build_object(
-                'id', ps.id,
-                'product_id', ps.product_id,
-                'stem_count', ps.stem_count,
-                'price', ps.price
-            )
-        ) FILTER (WHERE ps.id IS NOT NULL), '[]'
-    ) AS stems
+    product_price(p, NULL, p.price, now())::decimal AS effective_price,
+    product_availability(p, NULL, p.stock_quantity)::bigint AS available_quantity,
+    COALESCE((
+        SELECT json_agg(json_build_object(
+            'id', pv.id,
+            'product_id', pv.product_id,
+            'sku', pv.sku,
+            'price', product_price(p, pv.id, pv.price, now()),
+            'base_price', pv.price,
+            'stock_quantity', pv.stock_quantity,
+            'available_quantity', product_availability(p, pv.id, pv.stock_quantity),
+            'reorder_threshold', pv.reorder_threshold,
+            'image_url', pv.image_url,
+            'options', COALESCE((
+                SELECT json_agg(json_build_object(
+                    'name', pot.name,
+                    'value', pov.value
+                ) ORDER BY pot.position, pot.id)
+                FROM product_variant_options pvo
+                JOIN product_option_values pov ON pov.id = pvo.option_value_id
+                JOIN product_option_types pot ON pot.id = pov.option_type_id
+                WHERE pvo.variant_id = pv.id
+            ), '[]')
+        ) ORDER BY pv.id)
+        FROM product_variants pv
+        WHERE pv.product_id = p.id AND pv.deleted_at IS NULL
+    ), '[]') AS variants
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
-LEFT JOIN product_stems ps ON ps.product_id = p.id
 WHERE p.deleted_at IS NULL AND p.is_add_on = TRUE
 GROUP BY p.id, c.id, c.name, c.description
 ORDER BY p.created_at DESC;
@@ -27,19 +43,35 @@ SELECT
     c.id AS category_id,
     c.name AS category_name, 
     c.description AS category_description,
-    COALESCE(
-        json_agg(
-            jsonb_build_object(
-                'id', ps.id,
-                'product_id', ps.product_id,
-                'stem_count', ps.stem_count,
-                'price', ps.price
-            )
-        ) FILTER (WHERE ps.id IS NOT NULL), '[]'
-    ) AS stems
+    product_price(p, NULL, p.price, now())::decimal AS effective_price,
+    product_availability(p, NULL, p.stock_quantity)::bigint AS available_quantity,
+    COALESCE((
+        SELECT json_agg(json_build_object(
+            'id', pv.id,
+            'product_id', pv.product_id,
+            'sku', pv.sku,
+            'price', product_price(p, pv.id, pv.price, now()),
+            'base_price', pv.price,
+            'stock_quantity', pv.stock_quantity,
+            'available_quantity', product_availability(p, pv.id, pv.stock_quantity),
+            'reorder_threshold', pv.reorder_threshold,
+            'image_url', pv.image_url,
+            'options', COALESCE((
+                SELECT json_agg(json_build_object(
+                    'name', pot.name,
+                    'value', pov.value
+                ) ORDER BY pot.position, pot.id)
+                FROM product_variant_options pvo
+                JOIN product_option_values pov ON pov.id = pvo.option_value_id
+                JOIN product_option_types pot ON pot.id = pov.option_type_id
+                WHERE pvo.variant_id = pv.id
+            ), '[]')
+        ) ORDER BY pv.id)
+        FROM product_variants pv
+        WHERE pv.product_id = p.id AND pv.deleted_at IS NULL
+    ), '[]') AS variants
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
-LEFT JOIN product_stems ps ON ps.product_id = p.id
 WHERE p.deleted_at IS NULL AND p.is_message_card = TRUE
 GROUP BY p.id, c.id, c.name, c.description
 ORDER BY p.created_at DESC;
\ No newline at end of file
@@ -0,0 +1,48 @@
+-- name: CreateImage :one
+INSERT INTO images (owner_type, owner_id, key, url, thumbnail_key, thumbnail_url, content_type, size, width, height)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: AddProductImageUrls :exec
+UPDATE products
+SET image_url = image_url || sqlc.arg('image_urls')::text[]
+WHERE id = sqlc.arg('id');
+
+-- name: AddCategoryImageUrls :exec
+UPDATE categories
+SET image_url = image_url || sqlc.arg('image_urls')::text[]
+WHERE id = sqlc.arg('id');
+
+-- name: ListImagesByOwner :many
+SELECT * FROM images
+WHERE owner_type = $1 AND owner_id = $2
+ORDER BY id;
+
+-- name: ListOrphanImages :many
+-- images no longer in the image_url of their product, its variants or their
+-- category, for one owner or for all of them
+SELECT i.* FROM images i
+WHERE 
+    (
+        sqlc.narg('owner_type')::text IS NULL
+        OR (i.owner_type = sqlc.narg('owner_type') AND i.owner_id = sqlc.narg('owner_id')::bigint)
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM products p
+        WHERE i.owner_type = 'product' AND p.id = i.owner_id
+            AND (
+                i.url = ANY(p.image_url)
+                OR EXISTS (
+                    SELECT 1 FROM product_variants pv
+                    WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND i.url = ANY(pv.image_url)
+                )
+            )
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM categories c
+        WHERE i.owner_type = 'category' AND c.id = i.owner_id AND i.url = ANY(c.image_url)
+    )
+ORDER BY i.id;
+
+-- name: DeleteImage :exec
+DELETE FROM images WHERE id = $1;
@@ -0,0 +1,22 @@
+-- name: NextInvoiceNumber :one
+-- draws the next invoice number, the counter row stays locked until the
+-- invoice's transaction ends
+UPDATE invoice_counter
+SET next = next + 1
+RETURNING (next - 1)::bigint AS invoice_number;
+
+-- name: CreateOrderInvoice :one
+INSERT INTO invoices (order_id, invoice_number)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: CreatePaymentInvoice :one
+INSERT INTO invoices (payment_id, invoice_number)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetInvoiceByOrderID :one
+SELECT * FROM invoices WHERE order_id = $1;
+
+-- name: GetInvoiceByPaymentID :one
+SELECT * FROM invoices WHERE payment_id = $1;
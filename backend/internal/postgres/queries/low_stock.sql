@@ -0,0 +1,25 @@
+-- name: ListLowStockItems :many
+SELECT * FROM low_stock_items
+ORDER BY stock_quantity, product_name, product_id, variant_id NULLS FIRST;
+
+-- name: ResolveLowStockAlerts :execrows
+-- stock back above its threshold, or no longer tracked, alerts again when it
+-- next runs low
+DELETE FROM low_stock_alerts a
+WHERE NOT EXISTS (
+    SELECT 1 FROM low_stock_items l
+    WHERE l.product_id = a.product_id AND l.variant_id IS NOT DISTINCT FROM a.variant_id
+);
+
+-- name: CreateLowStockAlerts :many
+-- alerts on the low stock staff have not been notified of yet
+WITH alerted AS (
+    INSERT INTO low_stock_alerts (product_id, variant_id)
+    SELECT product_id, variant_id FROM low_stock_items
+    ON CONFLICT (product_id, COALESCE(variant_id, 0)) DO NOTHING
+    RETURNING product_id, variant_id
+)
+SELECT l.*
+FROM low_stock_items l
+JOIN alerted a ON a.product_id = l.product_id AND a.variant_id IS NOT DISTINCT FROM l.variant_id
+ORDER BY l.stock_quantity, l.product_name, l.product_id, l.variant_id NULLS FIRST;
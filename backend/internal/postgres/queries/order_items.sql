@@ -1,6 +1,6 @@
 -- name: CreateOrderItem :one
-INSERT INTO order_items (order_id, product_id, quantity, amount, payment_method, frequency, stem_id)
-VALUES (sqlc.arg('order_id'), sqlc.arg('product_id'), sqlc.arg('quantity'), sqlc.arg('amount'), sqlc.arg('payment_method'), sqlc.narg('frequency'), sqlc.narg('stem_id'))
+INSERT INTO order_items (order_id, product_id, quantity, amount, payment_method, frequency, variant_id, net_amount, tax_amount, tax_rate_bps, card_message, card_sender_name, card_recipient_name, card_anonymous, parent_item_id)
+VALUES (sqlc.arg('order_id'), sqlc.arg('product_id'), sqlc.arg('quantity'), sqlc.arg('amount'), sqlc.arg('payment_method'), sqlc.narg('frequency'), sqlc.narg('variant_id'), sqlc.arg('net_amount'), sqlc.arg('tax_amount'), sqlc.arg('tax_rate_bps'), sqlc.narg('card_message'), sqlc.narg('card_sender_name'), sqlc.narg('card_recipient_name'), sqlc.arg('card_anonymous'), sqlc.narg('parent_item_id'))
 RETURNING id;
 
 -- name: GetOrderItemsByProductID :many
@@ -11,9 +11,17 @@ FROM order_items oi
 LEFT JOIN LATERAL (
     SELECT json_build_object(
         'id', o.id,
-        'user_name', o.user_name,
-        'user_phone_number', o.user_phone_number,
+        'buyer', json_build_object(
+            'name', o.user_name,
+            'phone_number', o.user_phone_number,
+            'email', o.buyer_email
+        ),
+        'recipient', json_build_object(
+            'name', o.recipient_name,
+            'phone_number', o.recipient_phone_number
+        ),
         'total_amount', o.total_amount,
+        'currency', o.currency,
         'payment_status', o.payment_status,
         'status', o.status
     ) AS order_json
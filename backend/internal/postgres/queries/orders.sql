@@ -1,13 +1,13 @@
 -- name: CreateOrder :one
-INSERT INTO orders (user_name, user_phone_number, total_amount, payment_status, status, shipping_address, delivery_date, time_slot, by_admin)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO orders (user_name, user_phone_number, total_amount, payment_status, status, delivery_date, time_slot, by_admin, currency, net_amount, tax_amount, payment_reference, buyer_email, recipient_name, recipient_phone_number, address_area, address_street, address_building, address_landmark, address_latitude, address_longitude)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 RETURNING id;
 
 -- name: GetOrderByID :one
 SELECT * FROM orders WHERE id = $1;
 
 -- name: TotalOrders :one
-SELECT COALESCE(COUNT(*), 0) AS total_orders
+SELECT COUNT(*) AS total_orders
 FROM orders
 WHERE deleted_at IS NULL;
 
@@ -27,8 +27,21 @@ LEFT JOIN LATERAL (
     'id', oi.id,
     'order_id', oi.order_id,
     'product_id', oi.product_id,
+    'variant_id', oi.variant_id,
+    'payment_method', oi.payment_method,
+    'frequency', oi.frequency,
     'quantity', oi.quantity,
     'amount', oi.amount,
+    'net_amount', oi.net_amount,
+    'tax_amount', oi.tax_amount,
+    'tax_rate_bps', oi.tax_rate_bps,
+    'parent_item_id', oi.parent_item_id,
+    'message_card', CASE WHEN oi.card_message IS NULL THEN NULL ELSE json_build_object(
+      'message', oi.card_message,
+      'sender_name', oi.card_sender_name,
+      'recipient_name', oi.card_recipient_name,
+      'anonymous', oi.card_anonymous
+    ) END,
     'current_product_details', json_build_object(
       'id', p.id,
       'name', p.name,
@@ -37,11 +50,33 @@ LEFT JOIN LATERAL (
       'stock_quantity', p.stock_quantity,
       'image_url', p.image_url,
       'category_id', p.category_id,
-      'created_at', p.created_at
+      'has_variants', p.has_variants,
+      'is_message_card', p.is_message_card,
+      'is_flowers', p.is_flowers,
+      'is_add_on', p.is_add_on,
+      'created_at', p.created_at,
+      'variants', CASE WHEN pv.id IS NULL THEN '[]'::json ELSE json_build_array(json_build_object(
+        'id', pv.id,
+        'product_id', pv.product_id,
+        'sku', pv.sku,
+        'price', pv.price,
+        'image_url', pv.image_url,
+        'options', COALESCE((
+          SELECT json_agg(json_build_object(
+            'name', pot.name,
+            'value', pov.value
+          ) ORDER BY pot.position, pot.id)
+          FROM product_variant_options pvo
+          JOIN product_option_values pov ON pov.id = pvo.option_value_id
+          JOIN product_option_types pot ON pot.id = pov.option_type_id
+          WHERE pvo.variant_id = pv.id
+        ), '[]')
+      )) END
     )
-  )) AS items
+  ) ORDER BY oi.id) AS items
   FROM order_items oi
   JOIN products p ON p.id = oi.product_id
+  LEFT JOIN product_variants pv ON pv.id = oi.variant_id
   WHERE oi.order_id = o.id
 ) items ON true
 WHERE o.id = $1;
@@ -55,7 +90,15 @@ SET user_name = coalesce(sqlc.narg('user_name'), user_name),
     user_phone_number = coalesce(sqlc.narg('user_phone_number'), user_phone_number),
     payment_status = coalesce(sqlc.narg('payment_status'), payment_status),
     status = coalesce(sqlc.narg('status'), status),
-    shipping_address = coalesce(sqlc.narg('shipping_address'), shipping_address)
+    buyer_email = coalesce(sqlc.narg('buyer_email'), buyer_email),
+    recipient_name = coalesce(sqlc.narg('recipient_name'), recipient_name),
+    recipient_phone_number = coalesce(sqlc.narg('recipient_phone_number'), recipient_phone_number),
+    address_area = coalesce(sqlc.narg('address_area'), address_area),
+    address_street = coalesce(sqlc.narg('address_street'), address_street),
+    address_building = coalesce(sqlc.narg('address_building'), address_building),
+    address_landmark = coalesce(sqlc.narg('address_landmark'), address_landmark),
+    address_latitude = coalesce(sqlc.narg('address_latitude'), address_latitude),
+    address_longitude = coalesce(sqlc.narg('address_longitude'), address_longitude)
 WHERE id = sqlc.arg('id')
 RETURNING id;
 
@@ -64,15 +107,20 @@ UPDATE orders
 SET deleted_at = now()
 WHERE id = $1;
 
--- name: ListOrder :many
-SELECT * FROM orders
+-- name: ListCountOrder :one
+SELECT COUNT(*) AS total_orders
+FROM orders
 WHERE
     deleted_at IS NULL
     AND (
         COALESCE(sqlc.narg('search'), '') = '' 
         OR LOWER(user_name) LIKE sqlc.narg('search')
         OR LOWER(user_phone_number) LIKE sqlc.narg('search')
-        OR LOWER(shipping_address) LIKE sqlc.narg('search')
+        OR LOWER(buyer_email) LIKE sqlc.narg('search')
+        OR LOWER(recipient_name) LIKE sqlc.narg('search')
+        OR LOWER(recipient_phone_number) LIKE sqlc.narg('search')
+        OR LOWER(address_area) LIKE sqlc.narg('search')
+        OR LOWER(address_street) LIKE sqlc.narg('search')
     )
     AND (
         sqlc.narg('payment_status')::boolean IS NULL 
@@ -82,25 +130,78 @@ WHERE
         COALESCE(sqlc.narg('status'), '') = '' 
         OR LOWER(status) LIKE sqlc.narg('status')
     )
-ORDER BY created_at DESC
-LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-
--- name: ListCountOrder :one
-SELECT COUNT(*) AS total_orders
-FROM orders
-WHERE
-    deleted_at IS NULL
     AND (
-        COALESCE(sqlc.narg('search'), '') = '' 
-        OR LOWER(user_name) LIKE sqlc.narg('search')
-        OR LOWER(user_phone_number) LIKE sqlc.narg('search')
-        OR LOWER(shipping_address) LIKE sqlc.narg('search')
+        sqlc.narg('start_date')::timestamptz IS NULL
+        OR created_at >= sqlc.narg('start_date')
     )
     AND (
-        sqlc.narg('payment_status')::boolean IS NULL 
-        OR payment_status = sqlc.narg('payment_status')
+        sqlc.narg('end_date')::timestamptz IS NULL
+        OR created_at < sqlc.narg('end_date')
+    );
+
+-- name: ListOrdersByDeliveryDate :many
+SELECT 
+  o.*,
+  COALESCE(items.items, '[]') AS order_item_data
+FROM orders o
+LEFT JOIN LATERAL (
+  SELECT json_agg(json_build_object(
+    'id', oi.id,
+    'order_id', oi.order_id,
+    'product_id', oi.product_id,
+    'variant_id', oi.variant_id,
+    'payment_method', oi.payment_method,
+    'frequency', oi.frequency,
+    'quantity', oi.quantity,
+    'amount', oi.amount,
+    'net_amount', oi.net_amount,
+    'tax_amount', oi.tax_amount,
+    'tax_rate_bps', oi.tax_rate_bps,
+    'parent_item_id', oi.parent_item_id,
+    'message_card', CASE WHEN oi.card_message IS NULL THEN NULL ELSE json_build_object(
+      'message', oi.card_message,
+      'sender_name', oi.card_sender_name,
+      'recipient_name', oi.card_recipient_name,
+      'anonymous', oi.card_anonymous
+    ) END,
+    'current_product_details', json_build_object(
+      'id', p.id,
+      'name', p.name,
+      'description', p.description,
+      'price', p.price,
+      'stock_quantity', p.stock_quantity,
+      'image_url', p.image_url,
+      'category_id', p.category_id,
+      'has_variants', p.has_variants,
+      'is_message_card', p.is_message_card,
+      'is_flowers', p.is_flowers,
+      'is_add_on', p.is_add_on,
+      'created_at', p.created_at,
+      'variants', CASE WHEN pv.id IS NULL THEN '[]'::json ELSE json_build_array(json_build_object(
+        'id', pv.id,
+        'product_id', pv.product_id,
+        'sku', pv.sku,
+        'price', pv.price,
+        'image_url', pv.image_url,
+        'options', COALESCE((
+          SELECT json_agg(json_build_object(
+            'name', pot.name,
+            'value', pov.value
+          ) ORDER BY pot.position, pot.id)
+          FROM product_variant_options pvo
+          JOIN product_option_values pov ON pov.id = pvo.option_value_id
+          JOIN product_option_types pot ON pot.id = pov.option_type_id
+          WHERE pvo.variant_id = pv.id
+        ), '[]')
+      )) END
     )
-    AND (
-        COALESCE(sqlc.narg('status'), '') = '' 
-        OR LOWER(status) LIKE sqlc.narg('status')
-    );
\ No newline at end of file
+  ) ORDER BY oi.id) AS items
+  FROM order_items oi
+  JOIN products p ON p.id = oi.product_id
+  LEFT JOIN product_variants pv ON pv.id = oi.variant_id
+  WHERE oi.order_id = o.id
+) items ON true
+WHERE o.deleted_at IS NULL
+  AND o.delivery_date >= sqlc.arg('day_start')
+  AND o.delivery_date < sqlc.arg('day_end')
+ORDER BY o.delivery_date, o.id;
\ No newline at end of file
@@ -1,11 +1,13 @@
 -- name: CreatePayment :one
-INSERT INTO payments (order_id, description, user_subscription_id, amount, payment_method, paid_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO payments (order_id, description, user_subscription_id, amount, payment_method, paid_at, currency)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
--- name: TotalRevenue :one
-SELECT COALESCE(SUM(amount), 0) AS total_revenue
-FROM payments;
+-- name: TotalRevenue :many
+SELECT currency, COALESCE(SUM(amount), 0)::numeric AS total_revenue
+FROM payments
+GROUP BY currency
+ORDER BY currency;
 
 -- name: GetPaymentByID :one
 SELECT * FROM payments WHERE id = $1;
@@ -25,17 +27,6 @@ SET payment_method = coalesce(sqlc.narg('payment_method'), payment_method),
 WHERE id = sqlc.arg('id')
 RETURNING id;
 
--- name: ListPayments :many
-SELECT * FROM payments
-WHERE 
-    (
-        COALESCE(sqlc.narg('payment_method'), '') = '' 
-        OR LOWER(payment_method) LIKE sqlc.narg('payment_method')
-    )
-    AND paid_at BETWEEN sqlc.narg('start_date') AND sqlc.narg('end_date')
-ORDER BY paid_at DESC
-LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-
 -- name: ListCountPayments :one
 SELECT COUNT(*) AS total_payments
 FROM payments
@@ -1,6 +1,6 @@
 -- name: CreatePaystackPayment :exec
-INSERT INTO paystack_payments (amount, email, reference)
-VALUES ($1, $2, $3);
+INSERT INTO paystack_payments (amount, email, reference, currency)
+VALUES ($1, $2, $3, $4);
 
 -- name: UpdatePaystackPaymentStatus :exec
 UPDATE paystack_payments
@@ -33,6 +33,9 @@ WHERE
 INSERT INTO paystack_events (event, data)
 VALUES ($1, $2);
 
+-- name: GetPaystackEventByID :one
+SELECT * FROM paystack_events WHERE id = $1;
+
 -- name: ListPaystackEvents :many
 SELECT * FROM paystack_events
 WHERE 
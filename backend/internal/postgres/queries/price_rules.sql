@@ -0,0 +1,53 @@
+-- name: CreatePriceRule :one
+INSERT INTO price_rules (name, kind, price, currency, uplift_bps, product_id, variant_id, category_id, tag_id, starts_at, ends_at, priority)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: GetPriceRuleByID :one
+SELECT * FROM price_rules WHERE id = $1;
+
+-- name: ListPriceRules :many
+SELECT * FROM price_rules
+WHERE 
+    (sqlc.arg('include_deleted')::boolean OR deleted_at IS NULL)
+    AND (sqlc.narg('product_id')::bigint IS NULL OR product_id = sqlc.narg('product_id'))
+    AND (sqlc.narg('category_id')::bigint IS NULL OR category_id = sqlc.narg('category_id'))
+    AND (sqlc.narg('tag_id')::bigint IS NULL OR tag_id = sqlc.narg('tag_id'))
+    AND (
+        sqlc.narg('active_at')::timestamptz IS NULL
+        OR (starts_at <= sqlc.narg('active_at') AND (ends_at IS NULL OR ends_at > sqlc.narg('active_at')))
+    )
+ORDER BY starts_at DESC, id DESC;
+
+-- name: ListPriceRulesForProduct :many
+-- every rule that has applied or will apply to the product, ended and
+-- deleted rules included
+SELECT r.*
+FROM price_rules r
+JOIN products p ON p.id = sqlc.arg('product_id')
+WHERE r.product_id = p.id
+    OR r.tag_id IN (SELECT pt.tag_id FROM product_tags pt WHERE pt.product_id = p.id)
+    OR r.category_id = ANY(category_ancestor_ids(p.category_id))
+ORDER BY r.starts_at DESC, r.id DESC;
+
+-- name: EndPriceRule :execrows
+-- rules that have started are ended now so the history shows when they were
+-- in force, rules that have not started yet, or start just now and so cannot
+-- end after they start, are deleted
+UPDATE price_rules
+SET ends_at = CASE WHEN starts_at < now() THEN LEAST(COALESCE(ends_at, now()), now()) ELSE ends_at END,
+    deleted_at = CASE WHEN starts_at >= now() THEN now() ELSE deleted_at END
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetEffectivePrice :one
+-- the price of the product, or of one of its variants, at a point in time
+-- with the price rule in force applied
+SELECT product_price(p, pv.id, COALESCE(pv.price, p.price), sqlc.arg('at')::timestamptz)::decimal AS price
+FROM products p
+LEFT JOIN product_variants pv ON pv.id = sqlc.narg('variant_id')::bigint AND pv.product_id = p.id
+WHERE p.id = sqlc.arg('product_id');
+
+-- name: ListPriceChangesByProductID :many
+SELECT * FROM price_changes
+WHERE product_id = $1
+ORDER BY changed_at DESC, id DESC;
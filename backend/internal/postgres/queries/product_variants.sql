@@ -0,0 +1,85 @@
+-- name: UpsertProductOptionType :one
+INSERT INTO product_option_types (product_id, name, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (product_id, name) DO UPDATE
+SET position = EXCLUDED.position
+RETURNING id;
+
+-- name: UpsertProductOptionValue :one
+INSERT INTO product_option_values (option_type_id, value, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (option_type_id, value) DO UPDATE
+SET position = EXCLUDED.position
+RETURNING id;
+
+-- name: ListProductOptionsByProductID :many
+SELECT 
+    pot.id AS option_type_id,
+    pot.name,
+    pov.value
+FROM product_option_types pot
+JOIN product_option_values pov ON pov.option_type_id = pot.id
+WHERE pot.product_id = $1
+    AND EXISTS (
+        SELECT 1
+        FROM product_variant_options pvo
+        JOIN product_variants pv ON pv.id = pvo.variant_id
+        WHERE pvo.option_value_id = pov.id AND pv.deleted_at IS NULL
+    )
+ORDER BY pot.position, pot.id, pov.position, pov.id;
+
+-- name: UpsertProductVariant :one
+INSERT INTO product_variants (product_id, sku, price, stock_quantity, image_url, reorder_threshold)
+VALUES (sqlc.arg('product_id'), sqlc.arg('sku'), sqlc.arg('price'), sqlc.narg('stock_quantity'), sqlc.arg('image_url'), sqlc.narg('reorder_threshold'))
+ON CONFLICT (sku) DO UPDATE
+SET price = EXCLUDED.price,
+    stock_quantity = EXCLUDED.stock_quantity,
+    image_url = EXCLUDED.image_url,
+    reorder_threshold = EXCLUDED.reorder_threshold,
+    deleted_at = NULL
+WHERE product_variants.product_id = EXCLUDED.product_id
+RETURNING id;
+
+-- name: CreateProductVariantOption :exec
+INSERT INTO product_variant_options (variant_id, option_value_id)
+VALUES ($1, $2);
+
+-- name: DeleteProductVariantOptions :exec
+DELETE FROM product_variant_options
+WHERE variant_id = $1;
+
+-- name: DeleteProductVariantsNotIn :exec
+UPDATE product_variants
+SET deleted_at = now()
+WHERE product_id = sqlc.arg('product_id')
+    AND deleted_at IS NULL
+    AND NOT (sku = ANY(sqlc.arg('skus')::text[]));
+
+-- name: GetProductVariantByID :one
+SELECT * FROM product_variants
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListProductVariantsByProductID :many
+SELECT 
+    pv.*,
+    COALESCE((
+        SELECT json_agg(json_build_object(
+            'name', pot.name,
+            'value', pov.value
+        ) ORDER BY pot.position, pot.id)
+        FROM product_variant_options pvo
+        JOIN product_option_values pov ON pov.id = pvo.option_value_id
+        JOIN product_option_types pot ON pot.id = pov.option_type_id
+        WHERE pvo.variant_id = pv.id
+    ), '[]') AS options,
+    product_price(p, pv.id, pv.price, now())::decimal AS effective_price,
+    product_availability(p, pv.id, pv.stock_quantity) AS available_quantity
+FROM product_variants pv
+JOIN products p ON p.id = pv.product_id
+WHERE pv.product_id = $1 AND pv.deleted_at IS NULL
+ORDER BY pv.id;
+
+-- name: UpdateProductVariantStock :exec
+UPDATE product_variants
+SET stock_quantity = sqlc.arg('stock_quantity')
+WHERE id = sqlc.arg('id');
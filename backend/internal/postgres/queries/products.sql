@@ -1,10 +1,10 @@
 -- name: CreateProduct :one
-INSERT INTO products (name, description, price, category_id, has_stems, is_message_card, is_add_on, is_flowers, image_url, stock_quantity)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+INSERT INTO products (name, description, price, category_id, has_variants, is_message_card, is_add_on, is_flowers, image_url, stock_quantity, currency, tax_class_id, reorder_threshold, sku)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 RETURNING *;
 
 -- name: TotalProducts :one
-SELECT COALESCE(COUNT(*), 0) AS total_products
+SELECT COUNT(*) AS total_products
 FROM products
 WHERE deleted_at IS NULL;
 
@@ -12,12 +12,19 @@ WHERE deleted_at IS NULL;
 SELECT p.*, 
        c.id AS category_id,
        c.name AS category_name, 
-       c.description AS category_description
+       c.description AS category_description,
+       product_price(p, NULL, p.price, now())::decimal AS effective_price,
+       product_availability(p, NULL, p.stock_quantity)::bigint AS available_quantity
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.id = $1
 GROUP BY p.id, c.id, c.name, c.description;
 
+-- name: GetProductBySKU :one
+-- the product with its own sku, deleted or not, since the sku stays taken.
+SELECT id, deleted_at FROM products
+WHERE sku = $1;
+
 -- name: ProductExists :one
 SELECT EXISTS(SELECT 1 FROM products WHERE id = $1) AS exists;
 
@@ -27,157 +34,153 @@ SET name = coalesce(sqlc.narg('name'), name),
     description = coalesce(sqlc.narg('description'), description),
     price = coalesce(sqlc.narg('price'), price),
     category_id = coalesce(sqlc.narg('category_id'), category_id),
-    has_stems = coalesce(sqlc.narg('has_stems'), has_stems),
+    has_variants = coalesce(sqlc.narg('has_variants'), has_variants),
     is_message_card = coalesce(sqlc.narg('is_message_card'), is_message_card),
     is_flowers = coalesce(sqlc.narg('is_flowers'), is_flowers),
     is_add_on = coalesce(sqlc.narg('is_add_on'), is_add_on),
     image_url = coalesce(sqlc.narg('image_url'), image_url),
-    stock_quantity = coalesce(sqlc.narg('stock_quantity'), stock_quantity)
+    stock_quantity = coalesce(sqlc.narg('stock_quantity'), stock_quantity),
+    currency = coalesce(sqlc.narg('currency'), currency),
+    tax_class_id = coalesce(sqlc.narg('tax_class_id'), tax_class_id),
+    reorder_threshold = coalesce(sqlc.narg('reorder_threshold'), reorder_threshold),
+    sku = coalesce(sqlc.narg('sku'), sku)
 WHERE id = sqlc.arg('id')
 RETURNING *;
 
--- -- name: ListProducts :many
--- SELECT p.*, 
---        c.id AS category_id,
---        c.name AS category_name, 
---        c.description AS category_description
--- FROM products p
--- LEFT JOIN categories c ON p.category_id = c.id
--- WHERE 
---     p.deleted_at IS NULL
---     AND (
---         COALESCE(sqlc.narg('search'), '') = '' 
---         OR LOWER(p.name) LIKE sqlc.narg('search')
---         OR LOWER(p.description) LIKE sqlc.narg('search')
---     )
---     AND (
---         sqlc.narg('price_from')::float IS NULL 
---         OR p.price >= sqlc.narg('price_from')
---     )
---     AND (
---         sqlc.narg('price_to')::float IS NULL 
---         OR p.price <= sqlc.narg('price_to')
---     )
---     AND (
---         sqlc.narg('category_ids')::int[] IS NULL 
---         OR p.category_id = ANY(sqlc.narg('category_ids')::int[])
---     )
--- ORDER BY p.created_at DESC
--- LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-
--- -- name: ListCountProducts :one
--- SELECT COUNT(*) AS total_products
--- FROM products
--- WHERE 
---     deleted_at IS NULL
---     AND (
---         COALESCE(sqlc.narg('search'), '') = '' 
---         OR LOWER(name) LIKE sqlc.narg('search')
---         OR LOWER(description) LIKE sqlc.narg('search')
---     )
---     AND (
---         sqlc.narg('price_from')::float IS NULL 
---         OR price >= sqlc.narg('price_from')
---     )
---     AND (
---         sqlc.narg('price_to')::float IS NULL 
---         OR price <= sqlc.narg('price_to')
---     )
---     AND (
---         sqlc.narg('category_ids')::int[] IS NULL 
---         OR category_id = ANY(sqlc.narg('category_ids')::int[])
---     );
-
--- name: ListProducts :many
-SELECT 
-    p.*,
-    c.id AS category_id,
-    c.name AS category_name, 
-    c.description AS category_description,
-    COALESCE(
-        json_agg(
-            jsonb_build_object(
-                'id', ps.id,
-                'product_id', ps.product_id,
-                'stem_count', ps.stem_count,
-                'price', ps.price
-            )
-        ) FILTER (WHERE ps.id IS NOT NULL), '[]'
-    ) AS stems
-FROM products p
-LEFT JOIN categories c ON p.category_id = c.id
-LEFT JOIN product_stems ps ON ps.product_id = p.id
-WHERE 
-    p.deleted_at IS NULL
-    AND (
-        COALESCE(sqlc.narg('search'), '') = '' 
-        OR LOWER(p.name) LIKE sqlc.narg('search')
-        OR LOWER(p.description) LIKE sqlc.narg('search')
-    )
-    AND (
-        sqlc.narg('price_from')::float IS NULL 
-        OR p.price >= sqlc.narg('price_from')
-    )
-    AND (
-        sqlc.narg('is_message_card')::boolean IS NULL 
-        OR p.is_message_card = sqlc.narg('is_message_card')
-    )
-    AND (
-        sqlc.narg('is_flowers')::boolean IS NULL 
-        OR p.is_flowers = sqlc.narg('is_flowers')
-    )
-    AND (
-        sqlc.narg('is_add_on')::boolean IS NULL 
-        OR p.is_add_on = sqlc.narg('is_add_on')
-    )
-    AND (
-        sqlc.narg('price_to')::float IS NULL 
-        OR p.price <= sqlc.narg('price_to')
-    )
-    AND (
-        sqlc.narg('category_ids')::int[] IS NULL 
-        OR p.category_id = ANY(sqlc.narg('category_ids')::int[])
-    )
-GROUP BY p.id, c.id, c.name, c.description
-ORDER BY p.created_at DESC
-LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-
 -- name: ListCountProducts :one
-SELECT COUNT(DISTINCT p.id) AS total_products
-FROM products p
-WHERE 
-    p.deleted_at IS NULL
-    AND (
-        COALESCE(sqlc.narg('search'), '') = '' 
-        OR LOWER(p.name) LIKE sqlc.narg('search')
-        OR LOWER(p.description) LIKE sqlc.narg('search')
-    )
-    AND (
-        sqlc.narg('price_from')::float IS NULL 
-        OR p.price >= sqlc.narg('price_from')
-    )
-    AND (
-        sqlc.narg('is_message_card')::boolean IS NULL 
-        OR p.is_message_card = sqlc.narg('is_message_card')
-    )
-    AND (
-        sqlc.narg('is_flowers')::boolean IS NULL 
-        OR p.is_flowers = sqlc.narg('is_flowers')
-    )
-    AND (
-        sqlc.narg('is_add_on')::boolean IS NULL 
-        OR p.is_add_on = sqlc.narg('is_add_on')
-    )
-    AND (
-        sqlc.narg('price_to')::float IS NULL 
-        OR p.price <= sqlc.narg('price_to')
-    )
-    AND (
-        sqlc.narg('category_ids')::int[] IS NULL 
-        OR p.category_id = ANY(sqlc.narg('category_ids')::int[])
-    );
+WITH filtered AS (
+    SELECT
+        p.id,
+        product_price(p, NULL, p.price, now()) AS price,
+        p.category_id,
+        p.is_flowers,
+        p.is_add_on,
+        p.is_message_card,
+        (
+            product_availability(p, NULL, p.stock_quantity) > 0
+            OR EXISTS (
+                SELECT 1 FROM product_variants pv
+                WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND product_availability(p, pv.id, pv.stock_quantity) > 0
+            )
+        ) AS in_stock
+    FROM products p
+    LEFT JOIN product_search_documents psd ON psd.product_id = p.id
+    WHERE 
+        p.deleted_at IS NULL
+        AND (
+            COALESCE(sqlc.narg('search')::text, '') = ''
+            OR psd.document @@ websearch_to_tsquery('english', sqlc.narg('search')::text)
+            OR p.name % sqlc.narg('search')::text
+            OR sqlc.narg('search')::text <% p.name
+        )
+        AND (
+            sqlc.narg('price_from')::float IS NULL 
+            OR product_price(p, NULL, p.price, now()) >= sqlc.narg('price_from')
+        )
+        AND (
+            sqlc.narg('is_message_card')::boolean IS NULL 
+            OR p.is_message_card = sqlc.narg('is_message_card')
+        )
+        AND (
+            sqlc.narg('is_flowers')::boolean IS NULL 
+            OR p.is_flowers = sqlc.narg('is_flowers')
+        )
+        AND (
+            sqlc.narg('is_add_on')::boolean IS NULL 
+            OR p.is_add_on = sqlc.narg('is_add_on')
+        )
+        AND (
+            sqlc.narg('price_to')::float IS NULL 
+            OR product_price(p, NULL, p.price, now()) <= sqlc.narg('price_to')
+        )
+        AND (
+            sqlc.narg('category_ids')::int[] IS NULL 
+            OR p.category_id = ANY(category_subtree_ids(sqlc.narg('category_ids')::bigint[]))
+        )
+        AND (
+            sqlc.narg('in_stock')::boolean IS NULL
+            OR (
+                product_availability(p, NULL, p.stock_quantity) > 0
+                OR EXISTS (
+                    SELECT 1 FROM product_variants pv
+                    WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND product_availability(p, pv.id, pv.stock_quantity) > 0
+                )
+            ) = sqlc.narg('in_stock')
+        )
+        AND (
+            sqlc.narg('tag_ids')::bigint[] IS NULL
+            OR (
+                SELECT COUNT(*) FROM product_tags pt
+                WHERE pt.product_id = p.id AND pt.tag_id = ANY(sqlc.narg('tag_ids')::bigint[])
+            ) = cardinality(sqlc.narg('tag_ids')::bigint[])
+        )
+)
+SELECT
+    (SELECT COUNT(*) FROM filtered) AS total_products,
+    CASE WHEN sqlc.arg('with_facets')::boolean THEN json_build_object(
+        'categories', (
+            SELECT COALESCE(json_agg(json_build_object(
+                'id', c.id,
+                'name', c.name,
+                'count', f.count
+            ) ORDER BY f.count DESC, c.name), '[]')
+            FROM (SELECT category_id, COUNT(*) AS count FROM filtered GROUP BY category_id) f
+            JOIN categories c ON c.id = f.category_id
+        ),
+        'tags', (
+            SELECT COALESCE(json_agg(json_build_object(
+                'id', t.id,
+                'name', t.name,
+                'slug', t.slug,
+                'group', g.name,
+                'count', f.count
+            ) ORDER BY g.position, g.name, f.count DESC, t.name), '[]')
+            FROM (
+                SELECT pt.tag_id, COUNT(*) AS count
+                FROM filtered
+                JOIN product_tags pt ON pt.product_id = filtered.id
+                GROUP BY pt.tag_id
+            ) f
+            JOIN tags t ON t.id = f.tag_id AND t.deleted_at IS NULL
+            JOIN tag_groups g ON g.id = t.tag_group_id
+        ),
+        'price_buckets', (
+            SELECT COALESCE(json_agg(json_build_object(
+                'bucket', b.bucket,
+                'count', b.count
+            ) ORDER BY b.bucket), '[]')
+            FROM (
+                SELECT width_bucket(price::float8, sqlc.arg('price_bounds')::float8[]) AS bucket, COUNT(*) AS count
+                FROM filtered
+                GROUP BY 1
+            ) b
+        ),
+        'types', (
+            SELECT json_build_object(
+                'flowers', COUNT(*) FILTER (WHERE is_flowers),
+                'add_ons', COUNT(*) FILTER (WHERE is_add_on),
+                'message_cards', COUNT(*) FILTER (WHERE is_message_card)
+            )
+            FROM filtered
+        ),
+        'stock', (
+            SELECT json_build_object(
+                'in_stock', COUNT(*) FILTER (WHERE in_stock),
+                'out_of_stock', COUNT(*) FILTER (WHERE NOT in_stock)
+            )
+            FROM filtered
+        )
+    ) END AS facets;
 
 -- name: DeleteProduct :exec
 UPDATE products
 SET deleted_at = now()
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: RestoreProduct :execrows
+-- only products whose category is still live can be restored
+UPDATE products p
+SET deleted_at = NULL
+WHERE p.id = $1
+    AND p.deleted_at IS NOT NULL
+    AND EXISTS (SELECT 1 FROM categories c WHERE c.id = p.category_id AND c.deleted_at IS NULL);
\ No newline at end of file
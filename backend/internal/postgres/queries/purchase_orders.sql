@@ -0,0 +1,151 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (supplier_id, currency, expected_at, notes, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: CreatePurchaseOrderItem :exec
+INSERT INTO purchase_order_items (purchase_order_id, product_id, variant_id, quantity, unit_cost)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeletePurchaseOrderItems :exec
+DELETE FROM purchase_order_items
+WHERE purchase_order_id = $1;
+
+-- name: GetPurchaseOrderByID :one
+SELECT 
+    po.*,
+    s.name AS supplier_name,
+    COALESCE((
+        SELECT SUM(poi.quantity * poi.unit_cost)
+        FROM purchase_order_items poi
+        WHERE poi.purchase_order_id = po.id
+    ), 0)::decimal AS total_cost
+FROM purchase_orders po
+JOIN suppliers s ON s.id = po.supplier_id
+WHERE po.id = $1;
+
+-- name: GetPurchaseOrderForUpdate :one
+-- locks the purchase order until the transaction changing it commits
+SELECT * FROM purchase_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT 
+    po.*,
+    s.name AS supplier_name,
+    COALESCE((
+        SELECT SUM(poi.quantity * poi.unit_cost)
+        FROM purchase_order_items poi
+        WHERE poi.purchase_order_id = po.id
+    ), 0)::decimal AS total_cost
+FROM purchase_orders po
+JOIN suppliers s ON s.id = po.supplier_id
+WHERE 
+    (sqlc.narg('supplier_id')::bigint IS NULL OR po.supplier_id = sqlc.narg('supplier_id'))
+    AND (sqlc.narg('status')::text IS NULL OR po.status = sqlc.narg('status'))
+ORDER BY po.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListPurchaseOrdersCount :one
+SELECT COUNT(*) AS total_purchase_orders
+FROM purchase_orders po
+WHERE 
+    (sqlc.narg('supplier_id')::bigint IS NULL OR po.supplier_id = sqlc.narg('supplier_id'))
+    AND (sqlc.narg('status')::text IS NULL OR po.status = sqlc.narg('status'));
+
+-- name: ListPurchaseOrderItems :many
+SELECT 
+    poi.*,
+    p.name AS product_name,
+    pv.sku AS variant_sku
+FROM purchase_order_items poi
+JOIN products p ON p.id = poi.product_id
+LEFT JOIN product_variants pv ON pv.id = poi.variant_id
+WHERE poi.purchase_order_id = $1
+ORDER BY poi.id;
+
+-- name: UpdatePurchaseOrder :one
+UPDATE purchase_orders
+SET supplier_id = coalesce(sqlc.narg('supplier_id'), supplier_id),
+    currency = coalesce(sqlc.narg('currency'), currency),
+    status = coalesce(sqlc.narg('status'), status),
+    expected_at = coalesce(sqlc.narg('expected_at'), expected_at),
+    notes = coalesce(sqlc.narg('notes'), notes),
+    ordered_at = coalesce(sqlc.narg('ordered_at'), ordered_at),
+    received_at = coalesce(sqlc.narg('received_at'), received_at),
+    cancelled_at = coalesce(sqlc.narg('cancelled_at'), cancelled_at)
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: ReceivePurchaseOrderItem :one
+UPDATE purchase_order_items
+SET received_quantity = received_quantity + sqlc.arg('quantity')
+WHERE id = sqlc.arg('id') AND purchase_order_id = sqlc.arg('purchase_order_id')
+RETURNING *;
+
+-- name: GetMarginReport :many
+-- revenue, net of tax, of the paid orders placed between from and to against
+-- the average cost of the stock received in the currency sold in. Variants
+-- without costs of their own are costed at their product's, and products with
+-- a recipe at what their components cost. Items without a cost in the
+-- currency they were sold in have a NULL cost.
+WITH costs AS (
+    SELECT 
+        poi.product_id,
+        poi.variant_id,
+        po.currency,
+        SUM(poi.received_quantity * poi.unit_cost) / SUM(poi.received_quantity) AS unit_cost
+    FROM purchase_order_items poi
+    JOIN purchase_orders po ON po.id = poi.purchase_order_id
+    WHERE poi.received_quantity > 0
+    GROUP BY poi.product_id, poi.variant_id, po.currency
+), sales AS (
+    SELECT 
+        oi.product_id,
+        oi.variant_id,
+        o.currency,
+        SUM(oi.quantity)::bigint AS quantity,
+        SUM(oi.net_amount) AS revenue
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.payment_status = TRUE
+        AND o.deleted_at IS NULL
+        AND o.created_at >= sqlc.arg('from')
+        AND o.created_at < sqlc.arg('to')
+    GROUP BY oi.product_id, oi.variant_id, o.currency
+), recipe_costs AS (
+    SELECT 
+        s.product_id,
+        s.variant_id,
+        s.currency,
+        CASE WHEN bool_and(COALESCE(cvc.unit_cost, cpc.unit_cost) IS NOT NULL)
+            THEN SUM(rc.quantity * COALESCE(cvc.unit_cost, cpc.unit_cost))
+        END AS unit_cost
+    FROM sales s
+    JOIN recipe_components rc ON rc.product_id = s.product_id
+        AND rc.variant_id IS NOT DISTINCT FROM recipe_variant_id(s.product_id, s.variant_id)
+    LEFT JOIN costs cvc ON cvc.product_id = rc.component_product_id AND cvc.variant_id = rc.component_variant_id AND cvc.currency = s.currency
+    LEFT JOIN costs cpc ON cpc.product_id = rc.component_product_id AND cpc.variant_id IS NULL AND cpc.currency = s.currency
+    GROUP BY s.product_id, s.variant_id, s.currency
+)
+SELECT 
+    s.product_id,
+    p.name AS product_name,
+    s.variant_id,
+    pv.sku AS variant_sku,
+    s.currency,
+    s.quantity,
+    s.revenue::decimal AS revenue,
+    ROUND(c.unit_cost, 2)::decimal AS unit_cost,
+    ROUND(s.quantity * c.unit_cost, 2)::decimal AS cost
+FROM sales s
+JOIN products p ON p.id = s.product_id
+LEFT JOIN product_variants pv ON pv.id = s.variant_id
+LEFT JOIN recipe_costs r ON r.product_id = s.product_id AND r.variant_id IS NOT DISTINCT FROM s.variant_id AND r.currency = s.currency
+LEFT JOIN costs vc ON vc.product_id = s.product_id AND vc.variant_id = s.variant_id AND vc.currency = s.currency
+LEFT JOIN costs pc ON pc.product_id = s.product_id AND pc.variant_id IS NULL AND pc.currency = s.currency
+CROSS JOIN LATERAL (
+    SELECT CASE WHEN r.product_id IS NOT NULL THEN r.unit_cost ELSE COALESCE(vc.unit_cost, pc.unit_cost) END AS unit_cost
+) c
+ORDER BY s.currency, s.revenue DESC, s.product_id, s.variant_id NULLS FIRST;
@@ -0,0 +1,75 @@
+-- name: CreateRecipeComponent :exec
+INSERT INTO recipe_components (product_id, variant_id, component_product_id, component_variant_id, quantity)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteRecipeComponents :exec
+DELETE FROM recipe_components
+WHERE product_id = sqlc.arg('product_id') AND variant_id IS NOT DISTINCT FROM sqlc.narg('variant_id');
+
+-- name: ListRecipeComponents :many
+SELECT 
+    rc.*,
+    cp.name AS component_product_name,
+    cv.sku AS component_variant_sku
+FROM recipe_components rc
+JOIN products cp ON cp.id = rc.component_product_id
+LEFT JOIN product_variants cv ON cv.id = rc.component_variant_id
+WHERE rc.product_id = sqlc.arg('product_id') AND rc.variant_id IS NOT DISTINCT FROM sqlc.narg('variant_id')
+ORDER BY rc.id;
+
+-- name: ListItemRecipeComponents :many
+-- the components one of a product or variant is made from, by the recipe of
+-- the variant or else of its product, with their stock
+SELECT 
+    rc.component_product_id,
+    rc.component_variant_id,
+    rc.quantity,
+    cp.stock_quantity AS product_stock_quantity,
+    cv.stock_quantity AS variant_stock_quantity
+FROM recipe_components rc
+JOIN products cp ON cp.id = rc.component_product_id
+LEFT JOIN product_variants cv ON cv.id = rc.component_variant_id
+WHERE rc.product_id = sqlc.arg('product_id')
+    AND rc.variant_id IS NOT DISTINCT FROM recipe_variant_id(sqlc.arg('product_id'), sqlc.narg('variant_id'))
+ORDER BY rc.id;
+
+-- name: LockRecipeProducts :many
+-- locks the products, in id order so that concurrent recipe changes wait on
+-- each other instead of deadlocking
+SELECT id FROM products
+WHERE id = ANY(sqlc.arg('ids')::bigint[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: ProductHasRecipe :one
+SELECT EXISTS (
+    SELECT 1 FROM recipe_components
+    WHERE product_id = $1
+) AS has_recipe;
+
+-- name: IsRecipeComponent :one
+SELECT EXISTS (
+    SELECT 1 FROM recipe_components
+    WHERE component_product_id = $1
+) AS is_component;
+
+-- name: ListComponentsNeeded :many
+-- the components of the items of the orders delivered between day_start and
+-- day_end, by their recipes
+SELECT 
+    rc.component_product_id,
+    cp.name AS component_product_name,
+    rc.component_variant_id,
+    cv.sku AS component_variant_sku,
+    SUM(oi.quantity * rc.quantity)::bigint AS quantity
+FROM orders o
+JOIN order_items oi ON oi.order_id = o.id
+JOIN recipe_components rc ON rc.product_id = oi.product_id
+    AND rc.variant_id IS NOT DISTINCT FROM recipe_variant_id(oi.product_id, oi.variant_id)
+JOIN products cp ON cp.id = rc.component_product_id
+LEFT JOIN product_variants cv ON cv.id = rc.component_variant_id
+WHERE o.deleted_at IS NULL
+    AND o.delivery_date >= sqlc.arg('day_start')
+    AND o.delivery_date < sqlc.arg('day_end')
+GROUP BY rc.component_product_id, cp.name, rc.component_variant_id, cv.sku
+ORDER BY cp.name, rc.component_variant_id NULLS FIRST;
@@ -0,0 +1,86 @@
+-- name: CreateStockBatch :one
+INSERT INTO stock_batches (product_id, variant_id, supplier, received_at, expires_at, quantity, remaining)
+VALUES (sqlc.arg('product_id'), sqlc.narg('variant_id'), sqlc.narg('supplier'), sqlc.arg('received_at'), sqlc.arg('expires_at'), sqlc.arg('quantity'), sqlc.arg('quantity'))
+RETURNING *;
+
+-- name: GetStockBatchByID :one
+SELECT * FROM stock_batches WHERE id = $1;
+
+-- name: ListStockBatches :many
+SELECT * FROM stock_batches
+WHERE 
+    (sqlc.narg('product_id')::bigint IS NULL OR product_id = sqlc.narg('product_id'))
+    AND (sqlc.narg('variant_id')::bigint IS NULL OR variant_id = sqlc.narg('variant_id'))
+    AND (NOT sqlc.arg('live')::boolean OR remaining > 0)
+    AND (sqlc.narg('expires_before')::timestamptz IS NULL OR expires_at < sqlc.narg('expires_before'))
+ORDER BY expires_at, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListStockBatchesCount :one
+SELECT COUNT(*) AS total_stock_batches
+FROM stock_batches
+WHERE 
+    (sqlc.narg('product_id')::bigint IS NULL OR product_id = sqlc.narg('product_id'))
+    AND (sqlc.narg('variant_id')::bigint IS NULL OR variant_id = sqlc.narg('variant_id'))
+    AND (NOT sqlc.arg('live')::boolean OR remaining > 0)
+    AND (sqlc.narg('expires_before')::timestamptz IS NULL OR expires_at < sqlc.narg('expires_before'));
+
+-- name: ListAllocatableStockBatches :many
+-- unexpired batches of a product, or of one of its variants, first expiring
+-- first, locked until the order allocating from them commits
+SELECT * FROM stock_batches
+WHERE 
+    product_id = sqlc.arg('product_id')
+    AND variant_id IS NOT DISTINCT FROM sqlc.narg('variant_id')
+    AND remaining > 0
+    AND expires_at > sqlc.arg('at')
+ORDER BY expires_at, id
+FOR UPDATE;
+
+-- name: AllocateStockBatch :exec
+UPDATE stock_batches
+SET remaining = remaining - sqlc.arg('quantity')
+WHERE id = sqlc.arg('id');
+
+-- name: ReleaseStockBatch :exec
+-- puts stock an order allocated from a batch back into it
+UPDATE stock_batches
+SET remaining = remaining + sqlc.arg('quantity')
+WHERE id = sqlc.arg('id');
+
+-- name: WriteOffExpiredStockBatches :many
+UPDATE stock_batches
+SET written_off = written_off + remaining, remaining = 0, written_off_at = sqlc.arg('at')
+WHERE remaining > 0 AND expires_at <= sqlc.arg('at')
+RETURNING *;
+
+-- name: AddProductStock :one
+-- stock never goes below 0, the quantity actually added is returned
+UPDATE products p
+SET stock_quantity = GREATEST(p.stock_quantity + sqlc.arg('quantity')::bigint, 0)
+FROM (SELECT id, stock_quantity FROM products WHERE id = sqlc.arg('id') FOR UPDATE) old
+WHERE p.id = old.id
+RETURNING (p.stock_quantity - old.stock_quantity)::bigint AS added;
+
+-- name: AddProductVariantStock :one
+UPDATE product_variants pv
+SET stock_quantity = GREATEST(pv.stock_quantity + sqlc.arg('quantity')::bigint, 0)
+FROM (SELECT id, stock_quantity FROM product_variants WHERE id = sqlc.arg('id') AND stock_quantity IS NOT NULL FOR UPDATE) old
+WHERE pv.id = old.id
+RETURNING (pv.stock_quantity - old.stock_quantity)::bigint AS added;
+
+-- name: GetWastageReport :many
+-- stock written off, by expired batches or by hand
+SELECT 
+    sm.product_id,
+    p.name AS product_name,
+    sm.variant_id,
+    pv.sku AS variant_sku,
+    COUNT(DISTINCT sm.stock_batch_id) AS batches,
+    (-SUM(sm.quantity))::bigint AS written_off
+FROM stock_movements sm
+JOIN products p ON p.id = sm.product_id
+LEFT JOIN product_variants pv ON pv.id = sm.variant_id
+WHERE sm.kind = 'wastage' AND sm.created_at >= sqlc.arg('from') AND sm.created_at < sqlc.arg('to')
+GROUP BY sm.product_id, p.name, sm.variant_id, pv.sku
+ORDER BY written_off DESC, sm.product_id, sm.variant_id;
@@ -0,0 +1,62 @@
+-- name: CreateStockMovement :one
+INSERT INTO stock_movements (product_id, variant_id, kind, quantity, reason, user_id, order_id, stock_batch_id)
+VALUES (sqlc.arg('product_id'), sqlc.narg('variant_id'), sqlc.arg('kind'), sqlc.arg('quantity'), sqlc.arg('reason'), sqlc.narg('user_id'), sqlc.narg('order_id'), sqlc.narg('stock_batch_id'))
+RETURNING *;
+
+-- name: ListStockMovements :many
+SELECT * FROM stock_movements
+WHERE 
+    (sqlc.narg('product_id')::bigint IS NULL OR product_id = sqlc.narg('product_id'))
+    AND (sqlc.narg('variant_id')::bigint IS NULL OR variant_id = sqlc.narg('variant_id'))
+    AND (sqlc.narg('kind')::text IS NULL OR kind = sqlc.narg('kind'))
+    AND (sqlc.narg('order_id')::bigint IS NULL OR order_id = sqlc.narg('order_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListStockMovementsCount :one
+SELECT COUNT(*) AS total_stock_movements
+FROM stock_movements
+WHERE 
+    (sqlc.narg('product_id')::bigint IS NULL OR product_id = sqlc.narg('product_id'))
+    AND (sqlc.narg('variant_id')::bigint IS NULL OR variant_id = sqlc.narg('variant_id'))
+    AND (sqlc.narg('kind')::text IS NULL OR kind = sqlc.narg('kind'))
+    AND (sqlc.narg('order_id')::bigint IS NULL OR order_id = sqlc.narg('order_id'));
+
+-- name: GetStockLedgerBalance :one
+SELECT COALESCE(SUM(quantity), 0)::bigint AS balance
+FROM stock_movements
+WHERE product_id = sqlc.arg('product_id') AND variant_id IS NOT DISTINCT FROM sqlc.narg('variant_id');
+
+-- name: ListOrderStockReservations :many
+-- stock an order has reserved and not released yet, by the batch it came from
+SELECT product_id, variant_id, stock_batch_id, (-SUM(quantity))::bigint AS quantity
+FROM stock_movements
+WHERE order_id = $1 AND kind IN ('reservation', 'release')
+GROUP BY product_id, variant_id, stock_batch_id
+HAVING SUM(quantity) < 0
+ORDER BY product_id, variant_id, stock_batch_id;
+
+-- name: ListStockDiscrepancies :many
+-- products and variants with stock of their own whose stock_quantity is not
+-- the sum of their stock movements
+WITH ledger AS (
+    SELECT product_id, variant_id, SUM(quantity) AS quantity
+    FROM stock_movements
+    GROUP BY product_id, variant_id
+), stock AS (
+    SELECT p.id AS product_id, NULL::bigint AS variant_id, p.stock_quantity
+    FROM products p
+    UNION ALL
+    SELECT pv.product_id, pv.id AS variant_id, pv.stock_quantity
+    FROM product_variants pv
+    WHERE pv.stock_quantity IS NOT NULL
+)
+SELECT 
+    s.product_id,
+    s.variant_id,
+    s.stock_quantity::bigint AS stock_quantity,
+    COALESCE(l.quantity, 0)::bigint AS ledger_quantity
+FROM stock s
+LEFT JOIN ledger l ON l.product_id = s.product_id AND l.variant_id IS NOT DISTINCT FROM s.variant_id
+WHERE s.stock_quantity <> COALESCE(l.quantity, 0)
+ORDER BY s.product_id, s.variant_id;
@@ -1,6 +1,6 @@
 -- name: CreateSubscription :one
-INSERT INTO subscriptions (name, description, product_ids, add_ons, price, stem_ids, by_admin, parent_order_id)
-VALUES (sqlc.arg('name'), sqlc.arg('description'), sqlc.arg('product_ids'), sqlc.arg('add_ons'), sqlc.arg('price'), sqlc.arg('stem_ids'), sqlc.arg('by_admin'), sqlc.narg('parent_order_id'))
+INSERT INTO subscriptions (name, description, product_ids, add_ons, price, currency, variant_ids, by_admin, parent_order_id)
+VALUES (sqlc.arg('name'), sqlc.arg('description'), sqlc.arg('product_ids'), sqlc.arg('add_ons'), sqlc.arg('price'), sqlc.arg('currency'), sqlc.arg('variant_ids'), sqlc.arg('by_admin'), sqlc.narg('parent_order_id'))
 RETURNING id;
 
 -- name: SubscriptionExists :one
@@ -29,7 +29,7 @@ UPDATE subscriptions
 SET name = coalesce(sqlc.narg('name'), name),
     description = coalesce(sqlc.narg('description'), description),
     product_ids = coalesce(sqlc.narg('product_ids'), product_ids),
-    stem_ids = coalesce(sqlc.narg('stem_ids'), stem_ids), 
+    variant_ids = coalesce(sqlc.narg('variant_ids'), variant_ids), 
     add_ons = coalesce(sqlc.narg('add_ons'), add_ons),
     price = coalesce(sqlc.narg('price'), price)
 WHERE id = sqlc.arg('id')
@@ -40,52 +40,6 @@ UPDATE subscriptions
 SET deleted_at = now()
 WHERE id = $1;
 
--- name: ListSubscriptions :many
-SELECT 
-  s.*,
-  COALESCE(p.products, '[]') AS products_data,
-  COALESCE(a.add_ons, '[]') AS add_ons_data
-FROM subscriptions s
-LEFT JOIN LATERAL (
-  SELECT json_agg(json_build_object(
-    'id', p.id,
-    'name', p.name,
-    'price', p.price
-  )) AS products
-  FROM products p
-  WHERE p.id = ANY(s.product_ids)
-) p ON true
-LEFT JOIN LATERAL (
-  SELECT json_agg(json_build_object(
-    'id', p.id,
-    'name', p.name,
-    'price', p.price
-  )) AS add_ons
-  FROM products p
-  WHERE p.id = ANY(s.add_ons)
-) a ON true
-WHERE 
-    s.deleted_at IS NULL
-    AND (
-        COALESCE(sqlc.narg('search'), '') = '' 
-        OR LOWER(s.name) LIKE sqlc.narg('search')
-        OR LOWER(s.description) LIKE sqlc.narg('search')
-    )
-    AND (
-        sqlc.narg('by_admin')::boolean IS NULL 
-        OR s.by_admin = sqlc.narg('by_admin')
-    )
-    AND (
-        sqlc.narg('price_from')::float IS NULL 
-        OR s.price >= sqlc.narg('price_from')
-    )
-    AND (
-        sqlc.narg('price_to')::float IS NULL 
-        OR s.price <= sqlc.narg('price_to')
-    )
-ORDER BY s.created_at DESC
-LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-
 -- name: ListSubscriptionsCount :one
 SELECT COUNT(*) AS total_subscriptions
 FROM subscriptions
@@ -0,0 +1,47 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (name, contact_name, email, phone_number, address, notes)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetSupplierByID :one
+SELECT * FROM suppliers
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+WHERE 
+    deleted_at IS NULL
+    AND (
+        COALESCE(sqlc.narg('search'), '') = '' 
+        OR LOWER(name) LIKE sqlc.narg('search')
+        OR LOWER(contact_name) LIKE sqlc.narg('search')
+    )
+ORDER BY name
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListSuppliersCount :one
+SELECT COUNT(*) AS total_suppliers
+FROM suppliers
+WHERE 
+    deleted_at IS NULL
+    AND (
+        COALESCE(sqlc.narg('search'), '') = '' 
+        OR LOWER(name) LIKE sqlc.narg('search')
+        OR LOWER(contact_name) LIKE sqlc.narg('search')
+    );
+
+-- name: UpdateSupplier :one
+UPDATE suppliers
+SET name = coalesce(sqlc.narg('name'), name),
+    contact_name = coalesce(sqlc.narg('contact_name'), contact_name),
+    email = coalesce(sqlc.narg('email'), email),
+    phone_number = coalesce(sqlc.narg('phone_number'), phone_number),
+    address = coalesce(sqlc.narg('address'), address),
+    notes = coalesce(sqlc.narg('notes'), notes)
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteSupplier :execrows
+UPDATE suppliers
+SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,112 @@
+-- name: CreateTagGroup :one
+INSERT INTO tag_groups (name, slug, position)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetTagGroupByID :one
+SELECT * FROM tag_groups WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: TagGroupExists :one
+SELECT EXISTS(SELECT 1 FROM tag_groups WHERE id = $1 AND deleted_at IS NULL) AS exists;
+
+-- name: ListTagGroups :many
+SELECT * FROM tag_groups
+WHERE deleted_at IS NULL
+ORDER BY position, name;
+
+-- name: UpdateTagGroup :one
+UPDATE tag_groups
+SET name = coalesce(sqlc.narg('name'), name),
+    slug = coalesce(sqlc.narg('slug'), slug),
+    position = coalesce(sqlc.narg('position'), position)
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteTagGroup :exec
+WITH deleted_tags AS (
+    UPDATE tags
+    SET deleted_at = now()
+    WHERE tag_group_id = $1 AND deleted_at IS NULL
+)
+UPDATE tag_groups
+SET deleted_at = now()
+WHERE id = $1;
+
+-- name: CreateTag :one
+INSERT INTO tags (tag_group_id, name, slug, description, image_url, position)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTagWithProductCount :one
+SELECT 
+    t.*,
+    g.name AS group_name,
+    g.slug AS group_slug,
+    COUNT(p.id) AS products_count
+FROM tags t
+JOIN tag_groups g ON g.id = t.tag_group_id
+LEFT JOIN product_tags pt ON pt.tag_id = t.id
+LEFT JOIN products p 
+    ON p.id = pt.product_id 
+   AND p.deleted_at IS NULL
+WHERE t.deleted_at IS NULL
+    AND (sqlc.narg('id')::bigint IS NULL OR t.id = sqlc.narg('id'))
+    AND (sqlc.narg('slug')::text IS NULL OR t.slug = sqlc.narg('slug'))
+GROUP BY t.id, g.id;
+
+-- name: ListTagsWithProductCount :many
+SELECT 
+    t.*,
+    g.name AS group_name,
+    g.slug AS group_slug,
+    COUNT(p.id) AS products_count
+FROM tags t
+JOIN tag_groups g ON g.id = t.tag_group_id
+LEFT JOIN product_tags pt ON pt.tag_id = t.id
+LEFT JOIN products p 
+    ON p.id = pt.product_id 
+   AND p.deleted_at IS NULL
+WHERE t.deleted_at IS NULL
+    AND (sqlc.narg('tag_group_id')::bigint IS NULL OR t.tag_group_id = sqlc.narg('tag_group_id'))
+GROUP BY t.id, g.id
+ORDER BY g.position, g.name, t.position, t.name;
+
+-- name: UpdateTag :one
+UPDATE tags
+SET tag_group_id = coalesce(sqlc.narg('tag_group_id'), tag_group_id),
+    name = coalesce(sqlc.narg('name'), name),
+    slug = coalesce(sqlc.narg('slug'), slug),
+    description = coalesce(sqlc.narg('description'), description),
+    image_url = coalesce(sqlc.narg('image_url'), image_url),
+    position = coalesce(sqlc.narg('position'), position)
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteTag :exec
+UPDATE tags
+SET deleted_at = now()
+WHERE id = $1;
+
+-- name: CountLiveTags :one
+SELECT COUNT(*) AS live_tags
+FROM tags
+WHERE id = ANY(sqlc.arg('ids')::bigint[]) AND deleted_at IS NULL;
+
+-- name: DeleteProductTags :exec
+DELETE FROM product_tags WHERE product_id = $1;
+
+-- name: AddProductTags :exec
+INSERT INTO product_tags (product_id, tag_id)
+SELECT sqlc.arg('product_id')::bigint, unnest(sqlc.arg('tag_ids')::bigint[])
+ON CONFLICT DO NOTHING;
+
+-- name: ListTagsByProductID :many
+SELECT 
+    t.*,
+    g.name AS group_name,
+    g.slug AS group_slug
+FROM product_tags pt
+JOIN tags t ON t.id = pt.tag_id AND t.deleted_at IS NULL
+JOIN tag_groups g ON g.id = t.tag_group_id
+WHERE pt.product_id = $1
+ORDER BY g.position, g.name, t.position, t.name;
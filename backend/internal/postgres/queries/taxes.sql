@@ -0,0 +1,52 @@
+-- name: CreateTaxClass :one
+INSERT INTO tax_classes (name, description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetTaxClassByID :one
+SELECT * FROM tax_classes WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: TaxClassExists :one
+SELECT EXISTS(SELECT 1 FROM tax_classes WHERE id = $1 AND deleted_at IS NULL) AS exists;
+
+-- name: ListTaxClasses :many
+SELECT * FROM tax_classes
+WHERE deleted_at IS NULL
+ORDER BY name;
+
+-- name: UpdateTaxClass :one
+UPDATE tax_classes
+SET name = coalesce(sqlc.narg('name'), name),
+    description = coalesce(sqlc.narg('description'), description)
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteTaxClass :exec
+UPDATE tax_classes
+SET deleted_at = now()
+WHERE id = $1;
+
+-- name: CreateTaxRate :one
+INSERT INTO tax_rates (tax_class_id, rate_bps, effective_from)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListTaxRatesByClassID :many
+SELECT * FROM tax_rates
+WHERE tax_class_id = $1
+ORDER BY effective_from DESC;
+
+-- name: DeleteTaxRate :exec
+DELETE FROM tax_rates WHERE id = $1;
+
+-- name: GetProductTaxRate :one
+-- the product's own tax class wins over its category's
+SELECT tr.rate_bps
+FROM products p
+LEFT JOIN categories c ON c.id = p.category_id
+JOIN tax_classes tc ON tc.id = COALESCE(p.tax_class_id, c.tax_class_id) AND tc.deleted_at IS NULL
+JOIN tax_rates tr ON tr.tax_class_id = tc.id
+WHERE p.id = sqlc.arg('product_id')
+    AND tr.effective_from <= sqlc.arg('at')
+ORDER BY tr.effective_from DESC
+LIMIT 1;
@@ -24,26 +24,6 @@ SET name = coalesce(sqlc.narg('name'), name),
 WHERE id = sqlc.arg('id')
 RETURNING *;
 
--- name: ListUsers :many
-SELECT * FROM users
-WHERE 
-    (
-        COALESCE(sqlc.narg('search'), '') = '' 
-        OR LOWER(name) LIKE sqlc.narg('search')
-        OR LOWER(email) LIKE sqlc.narg('search')
-        OR LOWER(phone_number) LIKE sqlc.narg('search')
-    )
-    AND (
-        sqlc.narg('is_active')::boolean IS NULL 
-        OR is_active = sqlc.narg('is_active')
-    )
-    AND (
-        sqlc.narg('is_admin')::boolean IS NULL 
-        OR is_admin = sqlc.narg('is_admin')
-    )
-ORDER BY created_at DESC
-LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
-
 -- name: ListUsersCount :one
 SELECT COUNT(*) AS total_users
 FROM users
@@ -61,4 +41,9 @@ WHERE
     AND (
         sqlc.narg('is_admin')::boolean IS NULL 
         OR is_admin = sqlc.narg('is_admin')
-    );
\ No newline at end of file
+    );
+
+-- name: ListActiveAdmins :many
+SELECT * FROM users
+WHERE is_admin = TRUE AND is_active = TRUE
+ORDER BY id;
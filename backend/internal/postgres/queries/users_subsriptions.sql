@@ -7,7 +7,7 @@ RETURNING id;
 SELECT EXISTS(SELECT 1 FROM user_subscriptions WHERE id = $1) AS exists;
 
 -- name: ActiveSubscriptions :one
-SELECT COALESCE(COUNT(*), 0) AS active_subscriptions
+SELECT COUNT(*) AS active_subscriptions
 FROM user_subscriptions
 WHERE status = true
   AND deleted_at IS NULL;
@@ -37,6 +37,7 @@ LEFT JOIN LATERAL (
         'id', s.id,
         'name', s.name,
         'price', s.price,
+        'currency', s.currency,
         'description', s.description,
         'product_ids', s.product_ids,
         'add_ons', s.add_ons,
@@ -63,6 +64,7 @@ LEFT JOIN LATERAL (
         'id', s.id,
         'name', s.name,
         'price', s.price,
+        'currency', s.currency,
         'description', s.description,
         'product_ids', s.product_ids,
         'add_ons', s.add_ons,
@@ -113,6 +115,7 @@ LEFT JOIN LATERAL (
         'id', s.id,
         'name', s.name,
         'price', s.price,
+        'currency', s.currency,
         'description', s.description,
         'product_ids', s.product_ids,
         'add_ons', s.add_ons,
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.RecipeRepository = (*RecipeRepository)(nil)
+
+type RecipeRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewRecipeRepository(db *Store) *RecipeRepository {
+	return &RecipeRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+func (rr *RecipeRepository) GetRecipe(ctx context.Context, productID uint32, variantID *uint32) (*repository.Recipe, error) {
+	recipeVariantID, err := recipeVariantID(ctx, rr.queries, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return getRecipe(ctx, rr.queries, productID, recipeVariantID)
+}
+
+func (rr *RecipeRepository) SetRecipe(ctx context.Context, recipe *repository.Recipe) (*repository.Recipe, error) {
+	var newRecipe *repository.Recipe
+	err := rr.db.ExecTx(ctx, func(q *generated.Queries) error {
+		// with the product and its components locked, a concurrent change can't
+		// make one of them a component and a product with a recipe at once
+		productIDs := []int64{int64(recipe.ProductID)}
+		for _, component := range recipe.Components {
+			productIDs = append(productIDs, int64(component.ProductID))
+		}
+		if _, err := q.LockRecipeProducts(ctx, productIDs); err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error locking recipe products: %s", err.Error())
+		}
+
+		recipeVariantID, err := recipeVariantID(ctx, q, recipe.ProductID, recipe.VariantID)
+		if err != nil {
+			return err
+		}
+
+		if len(recipe.Components) > 0 {
+			isComponent, err := q.IsRecipeComponent(ctx, int64(recipe.ProductID))
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error checking recipes of product with id %d: %s", recipe.ProductID, err.Error())
+			}
+			if isComponent {
+				return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d is a component of other products and cannot have a recipe", recipe.ProductID)
+			}
+		}
+
+		err = q.DeleteRecipeComponents(ctx, generated.DeleteRecipeComponentsParams{
+			ProductID: int64(recipe.ProductID),
+			VariantID: recipeVariantID,
+		})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting recipe components: %s", err.Error())
+		}
+
+		for _, component := range recipe.Components {
+			if component.Quantity <= 0 {
+				return pkg.Errorf(pkg.INVALID_ERROR, "quantity of component product with id %d must be greater than 0", component.ProductID)
+			}
+			if component.ProductID == recipe.ProductID {
+				return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d cannot be a component of itself", recipe.ProductID)
+			}
+
+			componentVariantID, err := stockVariantID(ctx, q, component.ProductID, component.VariantID)
+			if err != nil {
+				return err
+			}
+
+			hasRecipe, err := q.ProductHasRecipe(ctx, int64(component.ProductID))
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error checking recipes of product with id %d: %s", component.ProductID, err.Error())
+			}
+			if hasRecipe {
+				return pkg.Errorf(pkg.INVALID_ERROR, "product with id %d has a recipe and cannot be a component", component.ProductID)
+			}
+
+			err = q.CreateRecipeComponent(ctx, generated.CreateRecipeComponentParams{
+				ProductID:          int64(recipe.ProductID),
+				VariantID:          recipeVariantID,
+				ComponentProductID: int64(component.ProductID),
+				ComponentVariantID: componentVariantID,
+				Quantity:           component.Quantity,
+			})
+			if err != nil {
+				if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+					return pkg.Errorf(pkg.INVALID_ERROR, "component product with id %d is on the recipe more than once", component.ProductID)
+				}
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating recipe component: %s", err.Error())
+			}
+		}
+
+		newRecipe, err = getRecipe(ctx, q, recipe.ProductID, recipeVariantID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newRecipe, nil
+}
+
+func (rr *RecipeRepository) ListComponentsNeeded(ctx context.Context, date time.Time) ([]repository.RecipeComponent, error) {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	rows, err := rr.queries.ListComponentsNeeded(ctx, generated.ListComponentsNeededParams{
+		DayStart: dayStart,
+		DayEnd:   dayStart.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing components needed: %s", err.Error())
+	}
+
+	components := make([]repository.RecipeComponent, len(rows))
+	for i, row := range rows {
+		components[i] = recipeComponentToRepo(row.ComponentProductID, row.ComponentProductName, row.ComponentVariantID, row.ComponentVariantSku, row.Quantity)
+	}
+
+	return components, nil
+}
+
+// recipeVariantID checks a product, and the variant of it whose recipe is
+// meant when variantID is set.
+func recipeVariantID(ctx context.Context, q *generated.Queries, productID uint32, variantID *uint32) (pgtype.Int8, error) {
+	exists, err := q.ProductExists(ctx, int64(productID))
+	if err != nil {
+		return pgtype.Int8{}, pkg.Errorf(pkg.INTERNAL_ERROR, "error checking product with id %d: %s", productID, err.Error())
+	}
+	if !exists {
+		return pgtype.Int8{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with id %d not found", productID)
+	}
+
+	if variantID == nil {
+		return pgtype.Int8{Valid: false}, nil
+	}
+
+	variant, err := q.GetProductVariantByID(ctx, int64(*variantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pgtype.Int8{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "variant with id %d not found", *variantID)
+		}
+		return pgtype.Int8{}, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching variant by id: %s", err.Error())
+	}
+	if variant.ProductID != int64(productID) {
+		return pgtype.Int8{}, pkg.Errorf(pkg.INVALID_ERROR, "variant with id %d is not a variant of product with id %d", *variantID, productID)
+	}
+
+	return pgtype.Int8{Valid: true, Int64: variant.ID}, nil
+}
+
+func getRecipe(ctx context.Context, q *generated.Queries, productID uint32, variantID pgtype.Int8) (*repository.Recipe, error) {
+	rows, err := q.ListRecipeComponents(ctx, generated.ListRecipeComponentsParams{
+		ProductID: int64(productID),
+		VariantID: variantID,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing recipe components: %s", err.Error())
+	}
+
+	recipe := &repository.Recipe{
+		ProductID:  productID,
+		VariantID:  nil,
+		Components: make([]repository.RecipeComponent, len(rows)),
+	}
+	if variantID.Valid {
+		id := uint32(variantID.Int64)
+		recipe.VariantID = &id
+	}
+
+	for i, row := range rows {
+		recipe.Components[i] = recipeComponentToRepo(row.ComponentProductID, row.ComponentProductName, row.ComponentVariantID, row.ComponentVariantSku, row.Quantity)
+	}
+
+	return recipe, nil
+}
+
+func recipeComponentToRepo(productID int64, productName string, variantID pgtype.Int8, variantSku pgtype.Text, quantity int64) repository.RecipeComponent {
+	component := repository.RecipeComponent{
+		ProductID:   uint32(productID),
+		ProductName: productName,
+		VariantID:   nil,
+		VariantSku:  nil,
+		Quantity:    quantity,
+	}
+
+	if variantID.Valid {
+		id := uint32(variantID.Int64)
+		component.VariantID = &id
+	}
+	if variantSku.Valid {
+		component.VariantSku = &variantSku.String
+	}
+
+	return component
+}
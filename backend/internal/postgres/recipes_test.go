@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+func TestSetRecipeRejectsNestedRecipes(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+	recipes := NewRecipeRepository(store)
+
+	bouquet := uint32(createTestProduct(t, store, 0).ID)
+	rose := uint32(createTestProduct(t, store, 10).ID)
+	hamper := uint32(createTestProduct(t, store, 0).ID)
+
+	if _, err := recipes.SetRecipe(ctx, &repository.Recipe{
+		ProductID:  bouquet,
+		Components: []repository.RecipeComponent{{ProductID: rose, Quantity: 3}},
+	}); err != nil {
+		t.Fatalf("SetRecipe: %v", err)
+	}
+
+	// a product with a recipe can't be a component
+	if _, err := recipes.SetRecipe(ctx, &repository.Recipe{
+		ProductID:  hamper,
+		Components: []repository.RecipeComponent{{ProductID: bouquet, Quantity: 1}},
+	}); err == nil {
+		t.Errorf("SetRecipe made a product with a recipe a component")
+	}
+
+	// a component can't have a recipe
+	if _, err := recipes.SetRecipe(ctx, &repository.Recipe{
+		ProductID:  rose,
+		Components: []repository.RecipeComponent{{ProductID: hamper, Quantity: 1}},
+	}); err == nil {
+		t.Errorf("SetRecipe gave a component a recipe")
+	}
+
+	if _, err := recipes.GetRecipe(ctx, 0, nil); pkg.ErrorCode(err) != pkg.NOT_FOUND_ERROR {
+		t.Errorf("GetRecipe of a missing product error = %v, want a %s error", err, pkg.NOT_FOUND_ERROR)
+	}
+}
+
+func TestSetRecipeConcurrentlyNestsNoRecipes(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+	recipes := NewRecipeRepository(store)
+
+	first := uint32(createTestProduct(t, store, 10).ID)
+	second := uint32(createTestProduct(t, store, 10).ID)
+
+	// each product is made the other's component at once, only one can win
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, ids := range [][2]uint32{{first, second}, {second, first}} {
+		wg.Add(1)
+		go func(i int, productID, componentID uint32) {
+			defer wg.Done()
+			_, errs[i] = recipes.SetRecipe(ctx, &repository.Recipe{
+				ProductID:  productID,
+				Components: []repository.RecipeComponent{{ProductID: componentID, Quantity: 1}},
+			})
+		}(i, ids[0], ids[1])
+	}
+	wg.Wait()
+
+	if (errs[0] == nil) == (errs[1] == nil) {
+		t.Errorf("SetRecipe errors = %v and %v, want exactly one to fail", errs[0], errs[1])
+	}
+}
@@ -0,0 +1,258 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.StockBatchRepository = (*StockBatchRepository)(nil)
+
+type StockBatchRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewStockBatchRepository(db *Store) *StockBatchRepository {
+	return &StockBatchRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+func (sbr *StockBatchRepository) CreateStockBatch(ctx context.Context, batch *repository.StockBatch, userID uint32) (*repository.StockBatch, error) {
+	if batch.Quantity <= 0 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "quantity must be greater than 0")
+	}
+	if batch.ReceivedAt.IsZero() {
+		batch.ReceivedAt = time.Now()
+	}
+	variantID, err := stockVariantID(ctx, sbr.queries, batch.ProductID, batch.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var newBatch *generated.StockBatch
+	err = sbr.db.ExecTx(ctx, func(q *generated.Queries) error {
+		var err error
+		newBatch, err = receiveProductStock(ctx, q, stockReceipt{
+			ProductID:  int64(batch.ProductID),
+			VariantID:  variantID,
+			Quantity:   batch.Quantity,
+			Supplier:   textFromPtr(batch.Supplier),
+			ReceivedAt: batch.ReceivedAt,
+			ExpiresAt:  &batch.ExpiresAt,
+			Reason:     "stock batch received",
+			UserID:     userID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return generatedStockBatchToRepo(*newBatch), nil
+}
+
+func (sbr *StockBatchRepository) GetStockBatchByID(ctx context.Context, id int64) (*repository.StockBatch, error) {
+	batch, err := sbr.queries.GetStockBatchByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "stock batch with id %d not found", id)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching stock batch by id: %s", err.Error())
+	}
+
+	return generatedStockBatchToRepo(batch), nil
+}
+
+func (sbr *StockBatchRepository) ListStockBatches(ctx context.Context, filter *repository.StockBatchFilter) ([]*repository.StockBatch, *pkg.Pagination, error) {
+	countParams := generated.ListStockBatchesCountParams{
+		ProductID:     pgtype.Int8{Valid: false},
+		VariantID:     pgtype.Int8{Valid: false},
+		Live:          filter.Live,
+		ExpiresBefore: pgtype.Timestamptz{Valid: false},
+	}
+
+	if filter.ProductID != nil {
+		countParams.ProductID = pgtype.Int8{Valid: true, Int64: int64(*filter.ProductID)}
+	}
+	if filter.VariantID != nil {
+		countParams.VariantID = pgtype.Int8{Valid: true, Int64: int64(*filter.VariantID)}
+	}
+	if filter.ExpiresBefore != nil {
+		countParams.ExpiresBefore = pgtype.Timestamptz{Valid: true, Time: *filter.ExpiresBefore}
+	}
+
+	generatedBatches, err := sbr.queries.ListStockBatches(ctx, generated.ListStockBatchesParams{
+		ProductID:     countParams.ProductID,
+		VariantID:     countParams.VariantID,
+		Live:          countParams.Live,
+		ExpiresBefore: countParams.ExpiresBefore,
+		Offset:        pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
+		Limit:         int32(filter.Pagination.PageSize),
+	})
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing stock batches: %s", err.Error())
+	}
+
+	totalCount, err := sbr.queries.ListStockBatchesCount(ctx, countParams)
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting stock batches: %s", err.Error())
+	}
+
+	batches := make([]*repository.StockBatch, len(generatedBatches))
+	for i, batch := range generatedBatches {
+		batches[i] = generatedStockBatchToRepo(batch)
+	}
+
+	return batches, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+}
+
+func (sbr *StockBatchRepository) WriteOffExpiredStock(ctx context.Context, at time.Time) ([]*repository.StockBatch, error) {
+	var writtenOff []generated.StockBatch
+	err := sbr.db.ExecTx(ctx, func(q *generated.Queries) error {
+		var err error
+		writtenOff, err = q.WriteOffExpiredStockBatches(ctx, pgtype.Timestamptz{Valid: true, Time: at})
+		if err != nil {
+			return pkg.Errorf(pkg.INTERNAL_ERROR, "error writing off expired stock batches: %s", err.Error())
+		}
+
+		for _, batch := range writtenOff {
+			err := moveStock(ctx, q, generated.CreateStockMovementParams{
+				ProductID:    batch.ProductID,
+				VariantID:    batch.VariantID,
+				Kind:         repository.StockMovementWastage,
+				Quantity:     -batch.WrittenOff,
+				Reason:       "stock batch expired",
+				UserID:       pgtype.Int8{Valid: false},
+				OrderID:      pgtype.Int8{Valid: false},
+				StockBatchID: pgtype.Int8{Valid: true, Int64: batch.ID},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]*repository.StockBatch, len(writtenOff))
+	for i, batch := range writtenOff {
+		batches[i] = generatedStockBatchToRepo(batch)
+	}
+
+	return batches, nil
+}
+
+func (sbr *StockBatchRepository) GetWastageReport(ctx context.Context, from, to time.Time) (*repository.WastageReport, error) {
+	if !to.After(from) {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "to must be after from")
+	}
+
+	rows, err := sbr.queries.GetWastageReport(ctx, generated.GetWastageReportParams{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error getting wastage report: %s", err.Error())
+	}
+
+	report := &repository.WastageReport{
+		From:  from,
+		To:    to,
+		Items: make([]repository.WastageReportItem, len(rows)),
+	}
+
+	for i, row := range rows {
+		item := repository.WastageReportItem{
+			ProductID:   uint32(row.ProductID),
+			ProductName: row.ProductName,
+			VariantID:   nil,
+			VariantSku:  nil,
+			Batches:     row.Batches,
+			WrittenOff:  row.WrittenOff,
+		}
+		if row.VariantID.Valid {
+			variantID := uint32(row.VariantID.Int64)
+			item.VariantID = &variantID
+		}
+		if row.VariantSku.Valid {
+			item.VariantSku = &row.VariantSku.String
+		}
+
+		report.Items[i] = item
+		report.TotalWrittenOff += row.WrittenOff
+	}
+
+	return report, nil
+}
+
+// allocateStockBatches takes an ordered quantity out of the unexpired batches of
+// a product or variant, first expiring first, and returns what was taken from
+// each. What the batches can't cover comes from stock that isn't in a batch,
+// already checked by the caller.
+func allocateStockBatches(ctx context.Context, q *generated.Queries, productID int64, variantID pgtype.Int8, quantity int64) ([]generated.AllocateStockBatchParams, error) {
+	batches, err := q.ListAllocatableStockBatches(ctx, generated.ListAllocatableStockBatchesParams{
+		ProductID: productID,
+		VariantID: variantID,
+		At:        time.Now(),
+	})
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing stock batches of product with id %d: %s", productID, err.Error())
+	}
+
+	allocations := make([]generated.AllocateStockBatchParams, 0, len(batches))
+	for _, batch := range batches {
+		if quantity == 0 {
+			break
+		}
+
+		allocation := generated.AllocateStockBatchParams{Quantity: min(batch.Remaining, quantity), ID: batch.ID}
+		if err := q.AllocateStockBatch(ctx, allocation); err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error allocating stock batch with id %d: %s", batch.ID, err.Error())
+		}
+		allocations = append(allocations, allocation)
+		quantity -= allocation.Quantity
+	}
+
+	return allocations, nil
+}
+
+func generatedStockBatchToRepo(generatedBatch generated.StockBatch) *repository.StockBatch {
+	batch := &repository.StockBatch{
+		ID:           uint32(generatedBatch.ID),
+		ProductID:    uint32(generatedBatch.ProductID),
+		VariantID:    nil,
+		Supplier:     nil,
+		ReceivedAt:   generatedBatch.ReceivedAt,
+		ExpiresAt:    generatedBatch.ExpiresAt,
+		Quantity:     generatedBatch.Quantity,
+		Remaining:    generatedBatch.Remaining,
+		WrittenOff:   generatedBatch.WrittenOff,
+		WrittenOffAt: nil,
+		CreatedAt:    generatedBatch.CreatedAt,
+	}
+
+	if generatedBatch.VariantID.Valid {
+		variantID := uint32(generatedBatch.VariantID.Int64)
+		batch.VariantID = &variantID
+	}
+	if generatedBatch.Supplier.Valid {
+		batch.Supplier = &generatedBatch.Supplier.String
+	}
+	if generatedBatch.WrittenOffAt.Valid {
+		batch.WrittenOffAt = &generatedBatch.WrittenOffAt.Time
+	}
+
+	return batch
+}
@@ -0,0 +1,328 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.StockMovementRepository = (*StockMovementRepository)(nil)
+
+type StockMovementRepository struct {
+	queries *generated.Queries
+	db      *Store
+}
+
+func NewStockMovementRepository(db *Store) *StockMovementRepository {
+	return &StockMovementRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
+}
+
+func (smr *StockMovementRepository) AdjustStock(ctx context.Context, adjustment *repository.StockAdjustment) ([]*repository.StockMovement, error) {
+	switch adjustment.Kind {
+	case repository.StockMovementAdjustment:
+	case repository.StockMovementReturn:
+		if adjustment.Quantity < 0 {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "a return must have a positive quantity")
+		}
+	case repository.StockMovementWastage:
+		if adjustment.Quantity > 0 {
+			return nil, pkg.Errorf(pkg.INVALID_ERROR, "wastage must have a negative quantity")
+		}
+	default:
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "stock can only be adjusted with an adjustment, a return or wastage, not %s", adjustment.Kind)
+	}
+	if adjustment.Quantity == 0 {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "quantity cannot be 0")
+	}
+
+	reason := strings.TrimSpace(adjustment.Reason)
+	if reason == "" {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "a reason is required")
+	}
+
+	variantID, err := stockVariantID(ctx, smr.queries, adjustment.ProductID, adjustment.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustmentMovement := generated.CreateStockMovementParams{
+		ProductID:    int64(adjustment.ProductID),
+		VariantID:    variantID,
+		Kind:         adjustment.Kind,
+		Quantity:     adjustment.Quantity,
+		Reason:       reason,
+		UserID:       optionalID(adjustment.UserID),
+		OrderID:      pgtype.Int8{Valid: false},
+		StockBatchID: pgtype.Int8{Valid: false},
+	}
+
+	var movements []generated.StockMovement
+	err = smr.db.ExecTx(ctx, func(q *generated.Queries) error {
+		params := []generated.CreateStockMovementParams{adjustmentMovement}
+
+		if adjustment.Quantity < 0 {
+			// stock taken out leaves its batches first expiring first, as orders do,
+			// so the batches don't hold more than is in stock
+			taken, takenQuantity, err := takeStock(ctx, q, adjustmentMovement)
+			if err != nil {
+				return err
+			}
+			if takenQuantity != -adjustment.Quantity {
+				return pkg.Errorf(pkg.INVALID_ERROR, "cannot take %d out of stock, only %d in stock", -adjustment.Quantity, takenQuantity)
+			}
+			params = taken
+		} else {
+			added, err := addStock(ctx, q, adjustmentMovement.ProductID, variantID, adjustment.Quantity)
+			if err != nil {
+				return err
+			}
+			if added != adjustment.Quantity {
+				return pkg.Errorf(pkg.INVALID_ERROR, "cannot add %d to stock, the variant has no stock of its own", adjustment.Quantity)
+			}
+		}
+
+		for _, param := range params {
+			movement, err := q.CreateStockMovement(ctx, param)
+			if err != nil {
+				return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating stock movement: %s", err.Error())
+			}
+			movements = append(movements, movement)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*repository.StockMovement, len(movements))
+	for i, movement := range movements {
+		result[i] = generatedStockMovementToRepo(movement)
+	}
+
+	return result, nil
+}
+
+func (smr *StockMovementRepository) ListStockMovements(ctx context.Context, filter *repository.StockMovementFilter) ([]*repository.StockMovement, *pkg.Pagination, error) {
+	countParams := generated.ListStockMovementsCountParams{
+		ProductID: pgtype.Int8{Valid: false},
+		VariantID: pgtype.Int8{Valid: false},
+		Kind:      textFromPtr(filter.Kind),
+		OrderID:   pgtype.Int8{Valid: false},
+	}
+
+	if filter.ProductID != nil {
+		countParams.ProductID = pgtype.Int8{Valid: true, Int64: int64(*filter.ProductID)}
+	}
+	if filter.VariantID != nil {
+		countParams.VariantID = pgtype.Int8{Valid: true, Int64: int64(*filter.VariantID)}
+	}
+	if filter.OrderID != nil {
+		countParams.OrderID = pgtype.Int8{Valid: true, Int64: int64(*filter.OrderID)}
+	}
+
+	generatedMovements, err := smr.queries.ListStockMovements(ctx, generated.ListStockMovementsParams{
+		ProductID: countParams.ProductID,
+		VariantID: countParams.VariantID,
+		Kind:      countParams.Kind,
+		OrderID:   countParams.OrderID,
+		Offset:    pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
+		Limit:     int32(filter.Pagination.PageSize),
+	})
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing stock movements: %s", err.Error())
+	}
+
+	totalCount, err := smr.queries.ListStockMovementsCount(ctx, countParams)
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting stock movements: %s", err.Error())
+	}
+
+	movements := make([]*repository.StockMovement, len(generatedMovements))
+	for i, movement := range generatedMovements {
+		movements[i] = generatedStockMovementToRepo(movement)
+	}
+
+	return movements, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+}
+
+func (smr *StockMovementRepository) ListStockDiscrepancies(ctx context.Context) ([]repository.StockDiscrepancy, error) {
+	rows, err := smr.queries.ListStockDiscrepancies(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing stock discrepancies: %s", err.Error())
+	}
+
+	discrepancies := make([]repository.StockDiscrepancy, len(rows))
+	for i, row := range rows {
+		discrepancies[i] = repository.StockDiscrepancy{
+			ProductID:      uint32(row.ProductID),
+			VariantID:      nil,
+			StockQuantity:  row.StockQuantity,
+			LedgerQuantity: row.LedgerQuantity,
+		}
+		if row.VariantID.Valid {
+			variantID := uint32(row.VariantID.Int64)
+			discrepancies[i].VariantID = &variantID
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// stockVariantID checks that the product exists and that the variant, when
+// set, is one of its variants with stock of its own.
+func stockVariantID(ctx context.Context, q *generated.Queries, productID uint32, variantID *uint32) (pgtype.Int8, error) {
+	if exists, _ := q.ProductExists(ctx, int64(productID)); !exists {
+		return pgtype.Int8{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with id %d not found", productID)
+	}
+
+	if variantID == nil {
+		return pgtype.Int8{Valid: false}, nil
+	}
+
+	variant, err := q.GetProductVariantByID(ctx, int64(*variantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pgtype.Int8{}, pkg.Errorf(pkg.NOT_FOUND_ERROR, "variant with id %d not found", *variantID)
+		}
+		return pgtype.Int8{}, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching variant by id: %s", err.Error())
+	}
+	if variant.ProductID != int64(productID) {
+		return pgtype.Int8{}, pkg.Errorf(pkg.INVALID_ERROR, "variant with id %d is not a variant of product with id %d", *variantID, productID)
+	}
+	// a variant drawing on its product's stock has its stock moved through the product
+	if !variant.StockQuantity.Valid {
+		return pgtype.Int8{}, pkg.Errorf(pkg.INVALID_ERROR, "variant with id %d has no stock of its own, use product with id %d", *variantID, productID)
+	}
+
+	return pgtype.Int8{Valid: true, Int64: variant.ID}, nil
+}
+
+// addStock adds quantity, negative to take it out, to the stock of a variant
+// or, when variantID is not set, of a product. Stock never goes below 0, the
+// quantity actually added is returned.
+func addStock(ctx context.Context, q *generated.Queries, productID int64, variantID pgtype.Int8, quantity int64) (int64, error) {
+	if variantID.Valid {
+		added, err := q.AddProductVariantStock(ctx, generated.AddProductVariantStockParams{Quantity: quantity, ID: variantID.Int64})
+		if err != nil {
+			// the variant has no stock of its own anymore
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, nil
+			}
+			return 0, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update variant with id %d quantity: %s", variantID.Int64, err.Error())
+		}
+
+		return added, nil
+	}
+
+	added, err := q.AddProductStock(ctx, generated.AddProductStockParams{Quantity: quantity, ID: productID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, pkg.Errorf(pkg.NOT_FOUND_ERROR, "product with id %d not found", productID)
+		}
+		return 0, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to update product with id %d quantity: %s", productID, err.Error())
+	}
+
+	return added, nil
+}
+
+// moveStock applies a movement to the stock of its product or variant and
+// records it with the quantity actually applied.
+func moveStock(ctx context.Context, q *generated.Queries, movement generated.CreateStockMovementParams) error {
+	added, err := addStock(ctx, q, movement.ProductID, movement.VariantID, movement.Quantity)
+	if err != nil {
+		return err
+	}
+	movement.Quantity = added
+
+	return recordStockMovement(ctx, q, movement)
+}
+
+// recordStockMovement records a change already made to stock_quantity, there
+// is nothing to record when the quantity is 0.
+func recordStockMovement(ctx context.Context, q *generated.Queries, movement generated.CreateStockMovementParams) error {
+	if movement.Quantity == 0 {
+		return nil
+	}
+
+	if _, err := q.CreateStockMovement(ctx, movement); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error creating stock movement: %s", err.Error())
+	}
+
+	return nil
+}
+
+// recordStockSet records the adjustment that brings the ledger of a product or
+// variant in line with a stock_quantity set directly. quantity is nil when a
+// variant no longer has stock of its own.
+func recordStockSet(ctx context.Context, q *generated.Queries, productID int64, variantID pgtype.Int8, quantity *int64, reason string, userID uint32) error {
+	balance, err := q.GetStockLedgerBalance(ctx, generated.GetStockLedgerBalanceParams{
+		ProductID: productID,
+		VariantID: variantID,
+	})
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error getting stock ledger balance: %s", err.Error())
+	}
+
+	var target int64
+	if quantity != nil {
+		target = *quantity
+	}
+
+	return recordStockMovement(ctx, q, generated.CreateStockMovementParams{
+		ProductID:    productID,
+		VariantID:    variantID,
+		Kind:         repository.StockMovementAdjustment,
+		Quantity:     target - balance,
+		Reason:       reason,
+		UserID:       optionalID(userID),
+		OrderID:      pgtype.Int8{Valid: false},
+		StockBatchID: pgtype.Int8{Valid: false},
+	})
+}
+
+// optionalID is NULL for an unset, zero, id.
+func optionalID(id uint32) pgtype.Int8 {
+	return pgtype.Int8{Valid: id != 0, Int64: int64(id)}
+}
+
+func generatedStockMovementToRepo(generatedMovement generated.StockMovement) *repository.StockMovement {
+	movement := &repository.StockMovement{
+		ID:           uint32(generatedMovement.ID),
+		ProductID:    uint32(generatedMovement.ProductID),
+		VariantID:    nil,
+		Kind:         generatedMovement.Kind,
+		Quantity:     generatedMovement.Quantity,
+		Reason:       generatedMovement.Reason,
+		UserID:       nil,
+		OrderID:      nil,
+		StockBatchID: nil,
+		CreatedAt:    generatedMovement.CreatedAt,
+	}
+
+	for _, field := range []struct {
+		value  pgtype.Int8
+		target **uint32
+	}{
+		{generatedMovement.VariantID, &movement.VariantID},
+		{generatedMovement.UserID, &movement.UserID},
+		{generatedMovement.OrderID, &movement.OrderID},
+		{generatedMovement.StockBatchID, &movement.StockBatchID},
+	} {
+		if field.value.Valid {
+			id := uint32(field.value.Int64)
+			*field.target = &id
+		}
+	}
+
+	return movement
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+)
+
+func TestAdjustStockTakesBatchesExpiringFirst(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+	q := generated.New(store.pool)
+
+	product := createTestProduct(t, store, 0)
+	productID := uint32(product.ID)
+
+	batches := NewStockBatchRepository(store)
+	later, err := batches.CreateStockBatch(ctx, &repository.StockBatch{
+		ProductID:  productID,
+		ReceivedAt: time.Now(),
+		ExpiresAt:  time.Now().AddDate(0, 0, 2),
+		Quantity:   3,
+	}, 0)
+	if err != nil {
+		t.Fatalf("CreateStockBatch: %v", err)
+	}
+	sooner, err := batches.CreateStockBatch(ctx, &repository.StockBatch{
+		ProductID:  productID,
+		ReceivedAt: time.Now(),
+		ExpiresAt:  time.Now().AddDate(0, 0, 1),
+		Quantity:   2,
+	}, 0)
+	if err != nil {
+		t.Fatalf("CreateStockBatch: %v", err)
+	}
+
+	movements, err := NewStockMovementRepository(store).AdjustStock(ctx, &repository.StockAdjustment{
+		ProductID: productID,
+		Kind:      repository.StockMovementWastage,
+		Quantity:  -4,
+		Reason:    "dropped",
+	})
+	if err != nil {
+		t.Fatalf("AdjustStock: %v", err)
+	}
+
+	want := []struct {
+		batchID  uint32
+		quantity int64
+	}{{sooner.ID, -2}, {later.ID, -2}}
+	if len(movements) != len(want) {
+		t.Fatalf("AdjustStock recorded %d movements, want %d", len(movements), len(want))
+	}
+	for i, movement := range movements {
+		if movement.StockBatchID == nil || *movement.StockBatchID != want[i].batchID || movement.Quantity != want[i].quantity {
+			t.Errorf("movement %d = batch %v quantity %d, want batch %d quantity %d", i, movement.StockBatchID, movement.Quantity, want[i].batchID, want[i].quantity)
+		}
+	}
+
+	for id, remaining := range map[uint32]int64{sooner.ID: 0, later.ID: 1} {
+		batch, err := q.GetStockBatchByID(ctx, int64(id))
+		if err != nil {
+			t.Fatalf("GetStockBatchByID: %v", err)
+		}
+		if batch.Remaining != remaining {
+			t.Errorf("batch %d has %d remaining, want %d", id, batch.Remaining, remaining)
+		}
+	}
+
+	// more than is in stock is refused and nothing is taken
+	if _, err := NewStockMovementRepository(store).AdjustStock(ctx, &repository.StockAdjustment{
+		ProductID: productID,
+		Kind:      repository.StockMovementAdjustment,
+		Quantity:  -2,
+		Reason:    "recount",
+	}); err == nil {
+		t.Errorf("AdjustStock took 2 out of a stock of 1")
+	}
+
+	stocked, err := q.GetProductByID(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("GetProductByID: %v", err)
+	}
+	if stocked.StockQuantity != 1 {
+		t.Errorf("stock quantity = %d, want 1", stocked.StockQuantity)
+	}
+}
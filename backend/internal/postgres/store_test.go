@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// testStore connects to the database at TEST_DATABASE_URL and migrates it,
+// the test is skipped when it is not set. Rows are left behind, so tests create
+// the ones they use and look no further than them.
+func testStore(t *testing.T) *Store {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	store := NewStore(pkg.Config{DATABASE_URL: url, MIGRATION_PATH: "file://migrations"})
+	if err := store.OpenDB(context.Background()); err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	t.Cleanup(store.CloseDB)
+
+	return store
+}
+
+// createTestProduct creates a product in a category of its own.
+func createTestProduct(t *testing.T, store *Store, stock int64) generated.Product {
+	t.Helper()
+
+	ctx := context.Background()
+	q := generated.New(store.pool)
+	name := fmt.Sprintf("%s %d", t.Name(), time.Now().UnixNano())
+
+	category, err := q.CreateCategory(ctx, generated.CreateCategoryParams{
+		Name:        name,
+		Description: "test category",
+		ImageUrl:    []string{},
+	})
+	if err != nil {
+		t.Fatalf("creating test category: %v", err)
+	}
+
+	product, err := q.CreateProduct(ctx, generated.CreateProductParams{
+		Name:          name,
+		Description:   "test product",
+		Price:         pkg.NewMoney(1000, pkg.KES).Numeric(),
+		CategoryID:    category.ID,
+		ImageUrl:      []string{},
+		StockQuantity: stock,
+		Currency:      string(pkg.KES),
+		TaxClassID:    pgtype.Int8{Valid: false},
+	})
+	if err != nil {
+		t.Fatalf("creating test product: %v", err)
+	}
+
+	return product
+}
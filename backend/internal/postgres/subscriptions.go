@@ -15,21 +15,34 @@ import (
 
 var _ repository.SubscriptionRepository = (*SubscriptionRepository)(nil)
 
+var defaultSubscriptionSort = pkg.Sort{{Name: "created_at", Desc: true}}
+
+var subscriptionKeyset = keyset{id: "s.id", fields: map[string][]sortColumn{
+	"created_at": {{"s.created_at", "timestamptz"}},
+	"price":      {{"s.currency", "text"}, {"s.price", "numeric"}},
+}}
+
 type SubscriptionRepository struct {
 	queries *generated.Queries
+	db      *Store
 }
 
-func NewSubscriptionRepository(queries *generated.Queries) *SubscriptionRepository {
-	return &SubscriptionRepository{queries: queries}
+func NewSubscriptionRepository(db *Store) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
 }
 
 func (sr *SubscriptionRepository) CreateSubscription(ctx context.Context, subscription *repository.Subscription) (*repository.Subscription, error) {
 	params := generated.CreateSubscriptionParams{
 		Name:        subscription.Name,
 		Description: subscription.Description,
-		Price:       pkg.Float64ToPgTypeNumeric(subscription.Price),
+		Price:       subscription.Price.Numeric(),
+		Currency:    string(subscription.Price.Currency()),
 		ProductIds:  make([]int32, 0, len(subscription.ProductIds)),
 		AddOns:      make([]int32, 0, len(subscription.AddOns)),
+		VariantIds:  make([]int32, 0, len(subscription.VariantIds)),
 	}
 
 	for _, productId := range subscription.ProductIds {
@@ -46,6 +59,10 @@ func (sr *SubscriptionRepository) CreateSubscription(ctx context.Context, subscr
 		params.AddOns = append(params.AddOns, int32(addOnId))
 	}
 
+	for _, variantId := range subscription.VariantIds {
+		params.VariantIds = append(params.VariantIds, int32(variantId))
+	}
+
 	subscriptionId, err := sr.queries.CreateSubscription(ctx, params)
 	if err != nil {
 		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
@@ -73,7 +90,9 @@ func (sr *SubscriptionRepository) GetSubscriptionByID(ctx context.Context, id in
 		Description: generatedSubscription.Description,
 		ProductIds:  generatedSubscription.ProductIds,
 		AddOns:      generatedSubscription.AddOns,
+		VariantIds:  generatedSubscription.VariantIds,
 		Price:       generatedSubscription.Price,
+		Currency:    generatedSubscription.Currency,
 		DeletedAt:   generatedSubscription.DeletedAt,
 		CreatedAt:   generatedSubscription.CreatedAt,
 	}, generatedSubscription.ProductsData, generatedSubscription.AddOnsData)
@@ -85,6 +104,7 @@ func (sr *SubscriptionRepository) UpdateSubscription(ctx context.Context, subscr
 		Name:        pgtype.Text{Valid: false},
 		Description: pgtype.Text{Valid: false},
 		ProductIds:  nil,
+		VariantIds:  nil,
 		AddOns:      nil,
 		Price:       pgtype.Numeric{Valid: false},
 	}
@@ -110,6 +130,13 @@ func (sr *SubscriptionRepository) UpdateSubscription(ctx context.Context, subscr
 		}
 	}
 
+	if subscription.VariantIds != nil {
+		params.VariantIds = make([]int32, len(*subscription.VariantIds))
+		for idx, variantId := range *subscription.VariantIds {
+			params.VariantIds[idx] = int32(variantId)
+		}
+	}
+
 	if subscription.AddOns != nil {
 		params.AddOns = make([]int32, len(*subscription.AddOns))
 		for idx, addOnId := range *subscription.AddOns {
@@ -118,7 +145,7 @@ func (sr *SubscriptionRepository) UpdateSubscription(ctx context.Context, subscr
 	}
 
 	if subscription.Price != nil {
-		params.Price = pkg.Float64ToPgTypeNumeric(*subscription.Price)
+		params.Price = subscription.Price.Numeric()
 	}
 
 	subscriptionId, err := sr.queries.UpdateSubscription(ctx, params)
@@ -133,12 +160,9 @@ func (sr *SubscriptionRepository) UpdateSubscription(ctx context.Context, subscr
 }
 
 func (sr *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter *repository.SubscriptionFilter) ([]*repository.Subscription, *pkg.Pagination, error) {
-	paramsListSubscriptions := generated.ListSubscriptionsParams{
-		Limit:     int32(filter.Pagination.PageSize),
-		Offset:    pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
-		Search:    pgtype.Text{Valid: false},
-		PriceFrom: pgtype.Float8{Valid: false},
-		PriceTo:   pgtype.Float8{Valid: false},
+	page, err := newListPage(filter.Pagination, defaultSubscriptionSort, subscriptionKeyset)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	paramsCountSubscriptions := generated.ListSubscriptionsCountParams{
@@ -149,10 +173,6 @@ func (sr *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter
 
 	if filter.Search != nil {
 		search := strings.ToLower(*filter.Search)
-		paramsListSubscriptions.Search = pgtype.Text{
-			Valid:  true,
-			String: "%" + search + "%",
-		}
 		paramsCountSubscriptions.Search = pgtype.Text{
 			Valid:  true,
 			String: "%" + search + "%",
@@ -160,15 +180,6 @@ func (sr *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter
 	}
 
 	if filter.PriceFrom != nil && filter.PriceTo != nil {
-		paramsListSubscriptions.PriceFrom = pgtype.Float8{
-			Valid:   true,
-			Float64: *filter.PriceFrom,
-		}
-		paramsListSubscriptions.PriceTo = pgtype.Float8{
-			Valid:   true,
-			Float64: *filter.PriceTo,
-		}
-
 		paramsCountSubscriptions.PriceFrom = pgtype.Float8{
 			Valid:   true,
 			Float64: *filter.PriceFrom,
@@ -179,7 +190,13 @@ func (sr *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter
 		}
 	}
 
-	generatedSubscriptions, err := sr.queries.ListSubscriptions(ctx, paramsListSubscriptions)
+	// the list takes the filters of the count in the same order
+	generatedSubscriptions, err := listRows[subscriptionListRow](ctx, sr.db.pool, page, subscriptionList,
+		paramsCountSubscriptions.Search,
+		paramsCountSubscriptions.ByAdmin,
+		paramsCountSubscriptions.PriceFrom,
+		paramsCountSubscriptions.PriceTo,
+	)
 	if err != nil {
 		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing subscriptions: %s", err.Error())
 	}
@@ -189,6 +206,10 @@ func (sr *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter
 		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting users: %s", err.Error())
 	}
 
+	generatedSubscriptions, pagination := pageRows(generatedSubscriptions, filter.Pagination, totalCount, func(row subscriptionListRow) []string {
+		return row.SortKeys
+	})
+
 	subscriptionList := make([]*repository.Subscription, len(generatedSubscriptions))
 	for idx, generatedSubscription := range generatedSubscriptions {
 		subscriptionList[idx], err = generatedSubToRepoSub(generated.Subscription{
@@ -197,7 +218,9 @@ func (sr *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter
 			Description: generatedSubscription.Description,
 			ProductIds:  generatedSubscription.ProductIds,
 			AddOns:      generatedSubscription.AddOns,
+			VariantIds:  generatedSubscription.VariantIds,
 			Price:       generatedSubscription.Price,
+			Currency:    generatedSubscription.Currency,
 			DeletedAt:   generatedSubscription.DeletedAt,
 			CreatedAt:   generatedSubscription.CreatedAt,
 		}, generatedSubscription.ProductsData, generatedSubscription.AddOnsData)
@@ -206,7 +229,7 @@ func (sr *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter
 		}
 	}
 
-	return subscriptionList, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+	return subscriptionList, pagination, nil
 }
 
 func (sr *SubscriptionRepository) DeleteSubscription(ctx context.Context, id int64) error {
@@ -221,13 +244,21 @@ func (sr *SubscriptionRepository) DeleteSubscription(ctx context.Context, id int
 }
 
 func generatedSubToRepoSub(genSub generated.Subscription, productsData, addOnsData []byte) (*repository.Subscription, error) {
+	currency := pkg.Currency(genSub.Currency)
+	price, err := pkg.NumericToMoney(genSub.Price, currency)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "invalid price for subscription %d: %s", genSub.ID, err.Error())
+	}
+
 	subscription := &repository.Subscription{
 		ID:           uint32(genSub.ID),
 		Name:         genSub.Name,
 		Description:  genSub.Description,
-		Price:        pkg.PgTypeNumericToFloat64(genSub.Price),
+		Price:        price,
+		Currency:     currency,
 		CreatedAt:    genSub.CreatedAt,
 		ProductIds:   make([]uint32, len(genSub.ProductIds)),
+		VariantIds:   make([]uint32, len(genSub.VariantIds)),
 		AddOns:       make([]uint32, len(genSub.AddOns)),
 		ProductsData: nil,
 		AddOnsData:   nil,
@@ -241,6 +272,10 @@ func generatedSubToRepoSub(genSub generated.Subscription, productsData, addOnsDa
 		subscription.ProductIds[idx] = uint32(productId)
 	}
 
+	for idx, variantId := range genSub.VariantIds {
+		subscription.VariantIds[idx] = uint32(variantId)
+	}
+
 	for idx, addOn := range genSub.AddOns {
 		subscription.AddOns[idx] = uint32(addOn)
 	}
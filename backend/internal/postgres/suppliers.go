@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.SupplierRepository = (*SupplierRepository)(nil)
+
+type SupplierRepository struct {
+	queries *generated.Queries
+}
+
+func NewSupplierRepository(queries *generated.Queries) *SupplierRepository {
+	return &SupplierRepository{queries: queries}
+}
+
+func (sr *SupplierRepository) CreateSupplier(ctx context.Context, supplier *repository.Supplier) (*repository.Supplier, error) {
+	generatedSupplier, err := sr.queries.CreateSupplier(ctx, generated.CreateSupplierParams{
+		Name:        supplier.Name,
+		ContactName: textFromPtr(supplier.ContactName),
+		Email:       textFromPtr(supplier.Email),
+		PhoneNumber: textFromPtr(supplier.PhoneNumber),
+		Address:     textFromPtr(supplier.Address),
+		Notes:       textFromPtr(supplier.Notes),
+	})
+	if err != nil {
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "supplier %s already exists", supplier.Name)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating supplier: %s", err.Error())
+	}
+
+	return generatedSupplierToRepo(generatedSupplier), nil
+}
+
+func (sr *SupplierRepository) GetSupplierByID(ctx context.Context, id int64) (*repository.Supplier, error) {
+	generatedSupplier, err := sr.queries.GetSupplierByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "supplier with id %d not found", id)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching supplier by id: %s", err.Error())
+	}
+
+	return generatedSupplierToRepo(generatedSupplier), nil
+}
+
+func (sr *SupplierRepository) UpdateSupplier(ctx context.Context, supplier *repository.UpdateSupplier) (*repository.Supplier, error) {
+	generatedSupplier, err := sr.queries.UpdateSupplier(ctx, generated.UpdateSupplierParams{
+		Name:        textFromPtr(supplier.Name),
+		ContactName: textFromPtr(supplier.ContactName),
+		Email:       textFromPtr(supplier.Email),
+		PhoneNumber: textFromPtr(supplier.PhoneNumber),
+		Address:     textFromPtr(supplier.Address),
+		Notes:       textFromPtr(supplier.Notes),
+		ID:          int64(supplier.ID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "supplier with id %d not found", supplier.ID)
+		}
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "supplier %s already exists", *supplier.Name)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error updating supplier: %s", err.Error())
+	}
+
+	return generatedSupplierToRepo(generatedSupplier), nil
+}
+
+func (sr *SupplierRepository) ListSuppliers(ctx context.Context, filter *repository.SupplierFilter) ([]*repository.Supplier, *pkg.Pagination, error) {
+	search := pgtype.Text{Valid: false}
+	if filter.Search != nil {
+		search = pgtype.Text{Valid: true, String: "%" + strings.ToLower(*filter.Search) + "%"}
+	}
+
+	generatedSuppliers, err := sr.queries.ListSuppliers(ctx, generated.ListSuppliersParams{
+		Search: search,
+		Offset: pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
+		Limit:  int32(filter.Pagination.PageSize),
+	})
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing suppliers: %s", err.Error())
+	}
+
+	totalCount, err := sr.queries.ListSuppliersCount(ctx, search)
+	if err != nil {
+		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting suppliers: %s", err.Error())
+	}
+
+	suppliers := make([]*repository.Supplier, len(generatedSuppliers))
+	for i, supplier := range generatedSuppliers {
+		suppliers[i] = generatedSupplierToRepo(supplier)
+	}
+
+	return suppliers, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+}
+
+func (sr *SupplierRepository) DeleteSupplier(ctx context.Context, id int64) error {
+	deleted, err := sr.queries.DeleteSupplier(ctx, id)
+	if err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting supplier by id: %s", err.Error())
+	}
+	if deleted == 0 {
+		return pkg.Errorf(pkg.NOT_FOUND_ERROR, "supplier with id %d not found", id)
+	}
+
+	return nil
+}
+
+func generatedSupplierToRepo(generatedSupplier generated.Supplier) *repository.Supplier {
+	supplier := &repository.Supplier{
+		ID:          uint32(generatedSupplier.ID),
+		Name:        generatedSupplier.Name,
+		ContactName: nil,
+		Email:       nil,
+		PhoneNumber: nil,
+		Address:     nil,
+		Notes:       nil,
+		DeletedAt:   nil,
+		CreatedAt:   generatedSupplier.CreatedAt,
+	}
+
+	for _, field := range []struct {
+		value  pgtype.Text
+		target **string
+	}{
+		{generatedSupplier.ContactName, &supplier.ContactName},
+		{generatedSupplier.Email, &supplier.Email},
+		{generatedSupplier.PhoneNumber, &supplier.PhoneNumber},
+		{generatedSupplier.Address, &supplier.Address},
+		{generatedSupplier.Notes, &supplier.Notes},
+	} {
+		if field.value.Valid {
+			value := field.value.String
+			*field.target = &value
+		}
+	}
+	if generatedSupplier.DeletedAt.Valid {
+		supplier.DeletedAt = &generatedSupplier.DeletedAt.Time
+	}
+
+	return supplier
+}
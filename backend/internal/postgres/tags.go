@@ -0,0 +1,328 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.TagRepository = (*TagRepository)(nil)
+
+type TagRepository struct {
+	queries *generated.Queries
+}
+
+func NewTagRepository(queries *generated.Queries) *TagRepository {
+	return &TagRepository{queries: queries}
+}
+
+func (tr *TagRepository) CreateTagGroup(ctx context.Context, tagGroup *repository.TagGroup) (*repository.TagGroup, error) {
+	if tagGroup.Slug == "" {
+		tagGroup.Slug = pkg.Slugify(tagGroup.Name)
+	}
+
+	generatedTagGroup, err := tr.queries.CreateTagGroup(ctx, generated.CreateTagGroupParams{
+		Name:     tagGroup.Name,
+		Slug:     tagGroup.Slug,
+		Position: tagGroup.Position,
+	})
+	if err != nil {
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "tag group with slug %s already exists", tagGroup.Slug)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating tag group: %s", err.Error())
+	}
+
+	return generatedTagGroupToRepo(generatedTagGroup), nil
+}
+
+func (tr *TagRepository) GetTagGroupByID(ctx context.Context, id int64) (*repository.TagGroup, error) {
+	generatedTagGroup, err := tr.queries.GetTagGroupByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tag group with ID %d not found", id)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching tag group by id: %s", err.Error())
+	}
+
+	return generatedTagGroupToRepo(generatedTagGroup), nil
+}
+
+func (tr *TagRepository) UpdateTagGroup(ctx context.Context, tagGroup *repository.UpdateTagGroup) (*repository.TagGroup, error) {
+	params := generated.UpdateTagGroupParams{
+		ID:       int64(tagGroup.ID),
+		Name:     pgtype.Text{Valid: false},
+		Slug:     pgtype.Text{Valid: false},
+		Position: pgtype.Int4{Valid: false},
+	}
+
+	if tagGroup.Name != nil {
+		params.Name = pgtype.Text{
+			Valid:  true,
+			String: *tagGroup.Name,
+		}
+	}
+	if tagGroup.Slug != nil {
+		params.Slug = pgtype.Text{
+			Valid:  true,
+			String: pkg.Slugify(*tagGroup.Slug),
+		}
+	}
+	if tagGroup.Position != nil {
+		params.Position = pgtype.Int4{
+			Valid: true,
+			Int32: *tagGroup.Position,
+		}
+	}
+
+	generatedTagGroup, err := tr.queries.UpdateTagGroup(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tag group with ID %d not found", tagGroup.ID)
+		}
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "tag group with slug %s already exists", params.Slug.String)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error updating tag group: %s", err.Error())
+	}
+
+	return generatedTagGroupToRepo(generatedTagGroup), nil
+}
+
+func (tr *TagRepository) ListTagGroups(ctx context.Context) ([]*repository.TagGroup, error) {
+	generatedTagGroups, err := tr.queries.ListTagGroups(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing tag groups: %s", err.Error())
+	}
+
+	tagGroups := make([]*repository.TagGroup, len(generatedTagGroups))
+	for i, tagGroup := range generatedTagGroups {
+		tagGroups[i] = generatedTagGroupToRepo(tagGroup)
+	}
+
+	return tagGroups, nil
+}
+
+// DeleteTagGroup soft deletes the group together with its tags.
+func (tr *TagRepository) DeleteTagGroup(ctx context.Context, id int64) error {
+	if err := tr.queries.DeleteTagGroup(ctx, id); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting tag group by id: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (tr *TagRepository) CreateTag(ctx context.Context, tag *repository.Tag) (*repository.Tag, error) {
+	if exists, _ := tr.queries.TagGroupExists(ctx, int64(tag.TagGroupID)); !exists {
+		return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tag group with id %d not found", tag.TagGroupID)
+	}
+
+	if tag.Slug == "" {
+		tag.Slug = pkg.Slugify(tag.Name)
+	}
+	if tag.ImageUrl == nil {
+		tag.ImageUrl = []string{}
+	}
+
+	generatedTag, err := tr.queries.CreateTag(ctx, generated.CreateTagParams{
+		TagGroupID:  int64(tag.TagGroupID),
+		Name:        tag.Name,
+		Slug:        tag.Slug,
+		Description: tag.Description,
+		ImageUrl:    tag.ImageUrl,
+		Position:    tag.Position,
+	})
+	if err != nil {
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "tag with slug %s already exists", tag.Slug)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating tag: %s", err.Error())
+	}
+
+	return tr.GetTagByID(ctx, generatedTag.ID)
+}
+
+func (tr *TagRepository) GetTagByID(ctx context.Context, id int64) (*repository.Tag, error) {
+	return tr.getTag(ctx, generated.GetTagWithProductCountParams{
+		ID:   pgtype.Int8{Int64: id, Valid: true},
+		Slug: pgtype.Text{Valid: false},
+	}, "ID", id)
+}
+
+func (tr *TagRepository) GetTagBySlug(ctx context.Context, slug string) (*repository.Tag, error) {
+	return tr.getTag(ctx, generated.GetTagWithProductCountParams{
+		ID:   pgtype.Int8{Valid: false},
+		Slug: pgtype.Text{String: slug, Valid: true},
+	}, "slug", slug)
+}
+
+func (tr *TagRepository) getTag(ctx context.Context, params generated.GetTagWithProductCountParams, field string, value any) (*repository.Tag, error) {
+	row, err := tr.queries.GetTagWithProductCount(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tag with %s %v not found", field, value)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching tag by %s: %s", field, err.Error())
+	}
+
+	tag := generatedTagToRepo(generated.Tag{
+		ID:          row.ID,
+		TagGroupID:  row.TagGroupID,
+		Name:        row.Name,
+		Slug:        row.Slug,
+		Description: row.Description,
+		ImageUrl:    row.ImageUrl,
+		Position:    row.Position,
+		DeletedAt:   row.DeletedAt,
+		CreatedAt:   row.CreatedAt,
+	})
+	tag.GroupName = row.GroupName
+	tag.GroupSlug = row.GroupSlug
+	tag.ProductCount = &row.ProductsCount
+
+	return tag, nil
+}
+
+func (tr *TagRepository) UpdateTag(ctx context.Context, tag *repository.UpdateTag) (*repository.Tag, error) {
+	params := generated.UpdateTagParams{
+		ID:          int64(tag.ID),
+		TagGroupID:  pgtype.Int8{Valid: false},
+		Name:        pgtype.Text{Valid: false},
+		Slug:        pgtype.Text{Valid: false},
+		Description: pgtype.Text{Valid: false},
+		ImageUrl:    nil,
+		Position:    pgtype.Int4{Valid: false},
+	}
+
+	if tag.TagGroupID != nil {
+		if exists, _ := tr.queries.TagGroupExists(ctx, int64(*tag.TagGroupID)); !exists {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tag group with id %d not found", *tag.TagGroupID)
+		}
+
+		params.TagGroupID = pgtype.Int8{
+			Valid: true,
+			Int64: int64(*tag.TagGroupID),
+		}
+	}
+	if tag.Name != nil {
+		params.Name = pgtype.Text{
+			Valid:  true,
+			String: *tag.Name,
+		}
+	}
+	if tag.Slug != nil {
+		params.Slug = pgtype.Text{
+			Valid:  true,
+			String: pkg.Slugify(*tag.Slug),
+		}
+	}
+	if tag.Description != nil {
+		params.Description = pgtype.Text{
+			Valid:  true,
+			String: *tag.Description,
+		}
+	}
+	if tag.ImageUrl != nil {
+		params.ImageUrl = *tag.ImageUrl
+	}
+	if tag.Position != nil {
+		params.Position = pgtype.Int4{
+			Valid: true,
+			Int32: *tag.Position,
+		}
+	}
+
+	if _, err := tr.queries.UpdateTag(ctx, params); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tag with ID %d not found", tag.ID)
+		}
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "tag with slug %s already exists", params.Slug.String)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error updating tag: %s", err.Error())
+	}
+
+	return tr.GetTagByID(ctx, int64(tag.ID))
+}
+
+func (tr *TagRepository) ListTags(ctx context.Context, tagGroupID *uint32) ([]*repository.Tag, error) {
+	groupID := pgtype.Int8{Valid: false}
+	if tagGroupID != nil {
+		groupID = pgtype.Int8{Int64: int64(*tagGroupID), Valid: true}
+	}
+
+	rows, err := tr.queries.ListTagsWithProductCount(ctx, groupID)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing tags: %s", err.Error())
+	}
+
+	tags := make([]*repository.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = generatedTagToRepo(generated.Tag{
+			ID:          row.ID,
+			TagGroupID:  row.TagGroupID,
+			Name:        row.Name,
+			Slug:        row.Slug,
+			Description: row.Description,
+			ImageUrl:    row.ImageUrl,
+			Position:    row.Position,
+			DeletedAt:   row.DeletedAt,
+			CreatedAt:   row.CreatedAt,
+		})
+		tags[i].GroupName = row.GroupName
+		tags[i].GroupSlug = row.GroupSlug
+		tags[i].ProductCount = &row.ProductsCount
+	}
+
+	return tags, nil
+}
+
+func (tr *TagRepository) DeleteTag(ctx context.Context, id int64) error {
+	if err := tr.queries.DeleteTag(ctx, id); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting tag by id: %s", err.Error())
+	}
+
+	return nil
+}
+
+func generatedTagGroupToRepo(tagGroup generated.TagGroup) *repository.TagGroup {
+	rslt := &repository.TagGroup{
+		ID:        uint32(tagGroup.ID),
+		Name:      tagGroup.Name,
+		Slug:      tagGroup.Slug,
+		Position:  tagGroup.Position,
+		DeletedAt: nil,
+		CreatedAt: tagGroup.CreatedAt,
+	}
+
+	if tagGroup.DeletedAt.Valid {
+		rslt.DeletedAt = &tagGroup.DeletedAt.Time
+	}
+
+	return rslt
+}
+
+func generatedTagToRepo(tag generated.Tag) *repository.Tag {
+	rslt := &repository.Tag{
+		ID:          uint32(tag.ID),
+		TagGroupID:  uint32(tag.TagGroupID),
+		Name:        tag.Name,
+		Slug:        tag.Slug,
+		Description: tag.Description,
+		ImageUrl:    tag.ImageUrl,
+		Position:    tag.Position,
+		DeletedAt:   nil,
+		CreatedAt:   tag.CreatedAt,
+	}
+
+	if tag.DeletedAt.Valid {
+		rslt.DeletedAt = &tag.DeletedAt.Time
+	}
+
+	return rslt
+}
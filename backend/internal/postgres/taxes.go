@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/flexGURU/flower-haven/backend/internal/postgres/generated"
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ repository.TaxRepository = (*TaxRepository)(nil)
+
+type TaxRepository struct {
+	queries *generated.Queries
+}
+
+func NewTaxRepository(queries *generated.Queries) *TaxRepository {
+	return &TaxRepository{queries: queries}
+}
+
+func (tr *TaxRepository) CreateTaxClass(ctx context.Context, taxClass *repository.TaxClass) (*repository.TaxClass, error) {
+	generatedTaxClass, err := tr.queries.CreateTaxClass(ctx, generated.CreateTaxClassParams{
+		Name:        taxClass.Name,
+		Description: taxClass.Description,
+	})
+	if err != nil {
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "tax class with name %s already exists", taxClass.Name)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating tax class: %s", err.Error())
+	}
+
+	return generatedTaxClassToRepo(generatedTaxClass, nil), nil
+}
+
+func (tr *TaxRepository) GetTaxClassByID(ctx context.Context, id int64) (*repository.TaxClass, error) {
+	generatedTaxClass, err := tr.queries.GetTaxClassByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tax class with ID %d not found", id)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error fetching tax class by id: %s", err.Error())
+	}
+
+	rates, err := tr.queries.ListTaxRatesByClassID(ctx, id)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing tax rates: %s", err.Error())
+	}
+
+	return generatedTaxClassToRepo(generatedTaxClass, rates), nil
+}
+
+func (tr *TaxRepository) UpdateTaxClass(ctx context.Context, taxClass *repository.UpdateTaxClass) (*repository.TaxClass, error) {
+	params := generated.UpdateTaxClassParams{
+		ID:          int64(taxClass.ID),
+		Name:        pgtype.Text{Valid: false},
+		Description: pgtype.Text{Valid: false},
+	}
+
+	if taxClass.Name != nil {
+		params.Name = pgtype.Text{
+			Valid:  true,
+			String: *taxClass.Name,
+		}
+	}
+	if taxClass.Description != nil {
+		params.Description = pgtype.Text{
+			Valid:  true,
+			String: *taxClass.Description,
+		}
+	}
+
+	if _, err := tr.queries.UpdateTaxClass(ctx, params); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tax class with ID %d not found", taxClass.ID)
+		}
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "tax class with name %s already exists", *taxClass.Name)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error updating tax class: %s", err.Error())
+	}
+
+	return tr.GetTaxClassByID(ctx, int64(taxClass.ID))
+}
+
+func (tr *TaxRepository) ListTaxClasses(ctx context.Context) ([]*repository.TaxClass, error) {
+	generatedTaxClasses, err := tr.queries.ListTaxClasses(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing tax classes: %s", err.Error())
+	}
+
+	taxClasses := make([]*repository.TaxClass, len(generatedTaxClasses))
+	for i, taxClass := range generatedTaxClasses {
+		rates, err := tr.queries.ListTaxRatesByClassID(ctx, taxClass.ID)
+		if err != nil {
+			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing tax rates: %s", err.Error())
+		}
+
+		taxClasses[i] = generatedTaxClassToRepo(taxClass, rates)
+	}
+
+	return taxClasses, nil
+}
+
+func (tr *TaxRepository) DeleteTaxClass(ctx context.Context, id int64) error {
+	if err := tr.queries.DeleteTaxClass(ctx, id); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting tax class by id: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (tr *TaxRepository) CreateTaxRate(ctx context.Context, taxRate *repository.TaxRate) (*repository.TaxRate, error) {
+	if taxRate.RateBps > pkg.MaxTaxRateBps {
+		return nil, pkg.Errorf(pkg.INVALID_ERROR, "tax rate cannot be more than %d bps", pkg.MaxTaxRateBps)
+	}
+
+	if exists, _ := tr.queries.TaxClassExists(ctx, int64(taxRate.TaxClassID)); !exists {
+		return nil, pkg.Errorf(pkg.NOT_FOUND_ERROR, "tax class with id %d not found", taxRate.TaxClassID)
+	}
+
+	generatedTaxRate, err := tr.queries.CreateTaxRate(ctx, generated.CreateTaxRateParams{
+		TaxClassID:    int64(taxRate.TaxClassID),
+		RateBps:       int32(taxRate.RateBps),
+		EffectiveFrom: taxRate.EffectiveFrom,
+	})
+	if err != nil {
+		if pkg.PgxErrorCode(err) == pkg.UNIQUE_VIOLATION {
+			return nil, pkg.Errorf(pkg.ALREADY_EXISTS_ERROR, "tax class %d already has a rate effective from %s", taxRate.TaxClassID, taxRate.EffectiveFrom)
+		}
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error creating tax rate: %s", err.Error())
+	}
+
+	return generatedTaxRateToRepo(generatedTaxRate), nil
+}
+
+func (tr *TaxRepository) DeleteTaxRate(ctx context.Context, id int64) error {
+	if err := tr.queries.DeleteTaxRate(ctx, id); err != nil {
+		return pkg.Errorf(pkg.INTERNAL_ERROR, "error deleting tax rate by id: %s", err.Error())
+	}
+
+	return nil
+}
+
+func generatedTaxClassToRepo(taxClass generated.TaxClass, rates []generated.TaxRate) *repository.TaxClass {
+	rslt := &repository.TaxClass{
+		ID:          uint32(taxClass.ID),
+		Name:        taxClass.Name,
+		Description: taxClass.Description,
+		Rates:       make([]repository.TaxRate, len(rates)),
+		DeletedAt:   nil,
+		CreatedAt:   taxClass.CreatedAt,
+	}
+
+	if taxClass.DeletedAt.Valid {
+		rslt.DeletedAt = &taxClass.DeletedAt.Time
+	}
+
+	for i, rate := range rates {
+		rslt.Rates[i] = *generatedTaxRateToRepo(rate)
+	}
+
+	return rslt
+}
+
+func generatedTaxRateToRepo(taxRate generated.TaxRate) *repository.TaxRate {
+	return &repository.TaxRate{
+		ID:            uint32(taxRate.ID),
+		TaxClassID:    uint32(taxRate.TaxClassID),
+		RateBps:       uint32(taxRate.RateBps),
+		EffectiveFrom: taxRate.EffectiveFrom,
+		CreatedAt:     taxRate.CreatedAt,
+	}
+}
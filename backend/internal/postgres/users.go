@@ -14,12 +14,22 @@ import (
 
 var _ repository.UserRepository = (*UserRepository)(nil)
 
+var defaultUserSort = pkg.Sort{{Name: "created_at", Desc: true}}
+
+var userKeyset = keyset{id: "users.id", fields: map[string][]sortColumn{
+	"created_at": {{"users.created_at", "timestamptz"}},
+}}
+
 type UserRepository struct {
 	queries *generated.Queries
+	db      *Store
 }
 
-func NewUserRepository(queries *generated.Queries) *UserRepository {
-	return &UserRepository{queries: queries}
+func NewUserRepository(db *Store) *UserRepository {
+	return &UserRepository{
+		db:      db,
+		queries: generated.New(db.pool),
+	}
 }
 
 func (ur *UserRepository) CreateUser(ctx context.Context, user *repository.User) (*repository.User, error) {
@@ -253,12 +263,9 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *repository.Updat
 }
 
 func (ur *UserRepository) ListUsers(ctx context.Context, filter *repository.UserFilter) ([]*repository.User, *pkg.Pagination, error) {
-	paramListUsers := generated.ListUsersParams{
-		Limit:    int32(filter.Pagination.PageSize),
-		Offset:   pkg.Offset(filter.Pagination.Page, filter.Pagination.PageSize),
-		Search:   pgtype.Text{Valid: false},
-		IsActive: pgtype.Bool{Valid: false},
-		IsAdmin:  pgtype.Bool{Valid: false},
+	page, err := newListPage(filter.Pagination, defaultUserSort, userKeyset)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	paramCountUsers := generated.ListUsersCountParams{
@@ -269,10 +276,6 @@ func (ur *UserRepository) ListUsers(ctx context.Context, filter *repository.User
 
 	if filter.Search != nil {
 		search := strings.ToLower(*filter.Search)
-		paramListUsers.Search = pgtype.Text{
-			Valid:  true,
-			String: "%" + search + "%",
-		}
 		paramCountUsers.Search = pgtype.Text{
 			Valid:  true,
 			String: "%" + search + "%",
@@ -280,10 +283,6 @@ func (ur *UserRepository) ListUsers(ctx context.Context, filter *repository.User
 	}
 
 	if filter.IsAdmin != nil {
-		paramListUsers.IsAdmin = pgtype.Bool{
-			Valid: true,
-			Bool:  *filter.IsAdmin,
-		}
 		paramCountUsers.IsAdmin = pgtype.Bool{
 			Valid: true,
 			Bool:  *filter.IsAdmin,
@@ -291,26 +290,34 @@ func (ur *UserRepository) ListUsers(ctx context.Context, filter *repository.User
 	}
 
 	if filter.IsActive != nil {
-		paramListUsers.IsActive = pgtype.Bool{
-			Valid: true,
-			Bool:  *filter.IsActive,
-		}
 		paramCountUsers.IsActive = pgtype.Bool{
 			Valid: true,
 			Bool:  *filter.IsActive,
 		}
 	}
 
-	generatedUsers, err := ur.queries.ListUsers(ctx, paramListUsers)
+	// the list takes the filters of the count in the same order
+	generatedUsers, err := listRows[userListRow](ctx, ur.db.pool, page, userList,
+		paramCountUsers.Search,
+		paramCountUsers.IsActive,
+		paramCountUsers.IsAdmin,
+	)
 	if err != nil {
 		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing users: %s", err.Error())
 	}
 
-	totalCount, err := ur.queries.ListUsersCount(ctx, paramCountUsers)
-	if err != nil {
-		return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting users: %s", err.Error())
+	var totalCount int64
+	if !filter.Pagination.SkipTotal {
+		totalCount, err = ur.queries.ListUsersCount(ctx, paramCountUsers)
+		if err != nil {
+			return nil, nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error counting users: %s", err.Error())
+		}
 	}
 
+	generatedUsers, pagination := pageRows(generatedUsers, filter.Pagination, totalCount, func(row userListRow) []string {
+		return row.SortKeys
+	})
+
 	userList := make([]*repository.User, len(generatedUsers))
 	for idx, generatedUser := range generatedUsers {
 		user := &repository.User{
@@ -332,5 +339,33 @@ func (ur *UserRepository) ListUsers(ctx context.Context, filter *repository.User
 		userList[idx] = user
 	}
 
-	return userList, pkg.CalculatePagination(uint32(totalCount), filter.Pagination.PageSize, filter.Pagination.Page), nil
+	return userList, pagination, nil
+}
+
+func (ur *UserRepository) ListActiveAdmins(ctx context.Context) ([]*repository.User, error) {
+	generatedUsers, err := ur.queries.ListActiveAdmins(ctx)
+	if err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "error listing active admins: %s", err.Error())
+	}
+
+	users := make([]*repository.User, len(generatedUsers))
+	for idx, generatedUser := range generatedUsers {
+		users[idx] = &repository.User{
+			ID:          uint32(generatedUser.ID),
+			Name:        generatedUser.Name,
+			Email:       generatedUser.Email,
+			Address:     nil,
+			PhoneNumber: generatedUser.PhoneNumber,
+			IsAdmin:     generatedUser.IsAdmin,
+			IsActive:    generatedUser.IsActive,
+			CreatedAt:   generatedUser.CreatedAt,
+		}
+
+		if generatedUser.Address.Valid {
+			address := generatedUser.Address.String
+			users[idx].Address = &address
+		}
+	}
+
+	return users, nil
 }
@@ -72,6 +72,7 @@ func (usr *UserSubscriptionRepository) GetUserSubscriptionByID(ctx context.Conte
 		EndDate:        userSubscription.EndDate,
 		DeletedAt:      userSubscription.DeletedAt,
 		CreatedAt:      userSubscription.CreatedAt,
+		CancelledAt:    userSubscription.CancelledAt,
 	}, userSubscription.UserData, userSubscription.SubscriptionData, userSubscription.PaymentData)
 }
 
@@ -102,6 +103,7 @@ func (usr *UserSubscriptionRepository) GetUsersSubscriptionsByUserID(ctx context
 			EndDate:        userSub.EndDate,
 			DeletedAt:      userSub.DeletedAt,
 			CreatedAt:      userSub.CreatedAt,
+			CancelledAt:    userSub.CancelledAt,
 		}, nil, userSub.SubscriptionData, nil)
 		if err != nil {
 			return nil, nil, err
@@ -197,6 +199,7 @@ func (usr *UserSubscriptionRepository) ListUserSubscriptions(ctx context.Context
 			EndDate:        userSub.EndDate,
 			DeletedAt:      userSub.DeletedAt,
 			CreatedAt:      userSub.CreatedAt,
+			CancelledAt:    userSub.CancelledAt,
 		}, userSub.UserData, userSub.SubscriptionData, nil)
 		if err != nil {
 			return nil, nil, err
@@ -228,6 +231,7 @@ func generatedUserSubToRepoUserSub(genUserSub generated.UserSubscription, userDa
 		EndDate:          genUserSub.EndDate,
 		CreatedAt:        genUserSub.CreatedAt,
 		DeletedAt:        nil,
+		CancelledAt:      nil,
 		UserData:         nil,
 		SubscriptionData: nil,
 		PaymentData:      nil,
@@ -241,6 +245,10 @@ func generatedUserSubToRepoUserSub(genUserSub generated.UserSubscription, userDa
 		userSuscription.DeletedAt = &genUserSub.DeletedAt.Time
 	}
 
+	if genUserSub.CancelledAt.Valid {
+		userSuscription.CancelledAt = &genUserSub.CancelledAt.Time
+	}
+
 	if userData != nil {
 		var uData repository.User
 		if err := json.Unmarshal(userData, &uData); err != nil {
@@ -254,6 +262,7 @@ func generatedUserSubToRepoUserSub(genUserSub generated.UserSubscription, userDa
 		if err := json.Unmarshal(subData, &sData); err != nil {
 			return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed unmarshalling subscription data to user_subscription: %s", err.Error())
 		}
+		sData.Price = sData.Price.WithCurrency(sData.Currency)
 		userSuscription.SubscriptionData = &sData
 	}
 
@@ -0,0 +1,295 @@
+package receipts
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/go-pdf/fpdf"
+)
+
+var _ services.IReceipt = (*Receipt)(nil)
+
+const (
+	lineHeight = 7.0
+	font       = "Helvetica"
+)
+
+// table column widths in mm, they add up to the A4 printable width
+var columnWidths = []float64{80, 15, 30, 30, 35}
+
+type Receipt struct {
+	BusinessName    string
+	BusinessDetails string
+}
+
+func NewReceipt(businessName string, businessDetails string) services.IReceipt {
+	return &Receipt{
+		BusinessName:    businessName,
+		BusinessDetails: businessDetails,
+	}
+}
+
+func (r *Receipt) OrderReceipt(invoice *repository.Invoice, order *repository.Order) ([]byte, error) {
+	doc := r.newDocument(invoice, order.CreatedAt)
+
+	doc.details([][2]string{
+		{"Order", fmt.Sprintf("#%d", order.ID)},
+		{"Payment reference", stringOrDash(order.PaymentReference)},
+		{"Payment status", paymentStatus(order.PaymentStatus)},
+	})
+
+	doc.heading("Billed to")
+	doc.details([][2]string{
+		{"Name", order.Buyer.Name},
+		{"Phone", order.Buyer.PhoneNumber},
+		{"Email", stringOrDash(order.Buyer.Email)},
+	})
+
+	doc.heading("Deliver to")
+	doc.details([][2]string{
+		{"Name", order.Recipient.Name},
+		{"Phone", order.Recipient.PhoneNumber},
+		{"Address", formatAddress(order.DeliveryAddress)},
+		{"Delivery date", fmt.Sprintf("%s (%s)", order.DeliveryDate.Format("02 Jan 2006"), order.TimeSlot)},
+	})
+
+	doc.tableHeader("Item", "Qty", "Net", "Tax", "Amount")
+	for _, item := range order.OrderItemsData {
+		doc.itemRow(item, "")
+		for _, child := range item.Children {
+			doc.itemRow(child, "  + ")
+		}
+	}
+
+	doc.total("Net", order.NetAmount)
+	doc.total("Tax", order.TaxAmount)
+	doc.total("Total", order.TotalAmount)
+
+	return doc.bytes()
+}
+
+func (r *Receipt) SubscriptionReceipt(invoice *repository.Invoice, payment *repository.Payment, userSubscription *repository.UserSubscription) ([]byte, error) {
+	doc := r.newDocument(invoice, payment.PaidAt)
+
+	doc.details([][2]string{
+		{"Payment", fmt.Sprintf("#%d", payment.ID)},
+		{"Payment method", payment.PaymentMethod},
+		{"Paid on", payment.PaidAt.Format("02 Jan 2006 15:04")},
+	})
+
+	if userSubscription.UserData != nil {
+		doc.heading("Billed to")
+		doc.details([][2]string{
+			{"Name", userSubscription.UserData.Name},
+			{"Email", userSubscription.UserData.Email},
+			{"Phone", userSubscription.UserData.PhoneNumber},
+		})
+	}
+
+	description := fmt.Sprintf("Subscription #%d", userSubscription.ID)
+	if userSubscription.SubscriptionData != nil {
+		description = userSubscription.SubscriptionData.Name
+	}
+	description = fmt.Sprintf("%s, %s from %s to %s", description, userSubscription.Frequency, userSubscription.StartDate.Format("02 Jan 2006"), userSubscription.EndDate.Format("02 Jan 2006"))
+	if payment.Description != nil {
+		description = fmt.Sprintf("%s\n%s", description, *payment.Description)
+	}
+
+	doc.tableHeader("Item", "Qty", "", "", "Amount")
+	doc.tableRow(description, "1", "", "", payment.Amount.String())
+
+	doc.total("Total", payment.Amount)
+
+	return doc.bytes()
+}
+
+// document wraps fpdf with the few layout helpers receipts need. fpdf keeps
+// the first error and ignores later calls, so it is only checked on output.
+type document struct {
+	pdf       *fpdf.Fpdf
+	translate func(string) string
+}
+
+func (r *Receipt) newDocument(invoice *repository.Invoice, issuedAt time.Time) *document {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(invoice.Number, true)
+	pdf.SetAuthor(r.BusinessName, true)
+	pdf.AddPage()
+
+	doc := &document{
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+
+	pdf.SetFont(font, "B", 18)
+	pdf.CellFormat(0, 10, doc.translate(r.BusinessName), "", 1, "L", false, 0, "")
+	if r.BusinessDetails != "" {
+		pdf.SetFont(font, "", 9)
+		pdf.MultiCell(0, 5, doc.translate(r.BusinessDetails), "", "L", false)
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont(font, "B", 14)
+	pdf.CellFormat(0, 8, "RECEIPT", "", 1, "L", false, 0, "")
+	doc.details([][2]string{
+		{"Invoice number", invoice.Number},
+		{"Date", issuedAt.Format("02 Jan 2006")},
+	})
+
+	return doc
+}
+
+func (d *document) heading(text string) {
+	d.pdf.Ln(2)
+	d.pdf.SetFont(font, "B", 11)
+	d.pdf.CellFormat(0, lineHeight, d.translate(text), "", 1, "L", false, 0, "")
+}
+
+func (d *document) details(rows [][2]string) {
+	for _, row := range rows {
+		d.pdf.SetFont(font, "B", 10)
+		d.pdf.CellFormat(45, 6, d.translate(row[0]), "", 0, "L", false, 0, "")
+		d.pdf.SetFont(font, "", 10)
+		d.pdf.MultiCell(0, 6, d.translate(row[1]), "", "L", false)
+	}
+}
+
+func (d *document) tableHeader(columns ...string) {
+	d.pdf.Ln(4)
+	d.pdf.SetFont(font, "B", 10)
+	d.pdf.SetFillColor(230, 230, 230)
+	for i, column := range columns {
+		d.pdf.CellFormat(columnWidths[i], lineHeight, d.translate(column), "1", 0, align(i), true, 0, "")
+	}
+	d.pdf.Ln(-1)
+}
+
+// tableRow wraps the first column and stretches the remaining cells to the
+// height the wrapped text ends up taking.
+func (d *document) tableRow(columns ...string) {
+	d.pdf.SetFont(font, "", 10)
+
+	lines := d.pdf.SplitLines([]byte(d.translate(columns[0])), columnWidths[0]-2)
+	height := float64(len(lines)) * lineHeight
+	if height == 0 {
+		height = lineHeight
+	}
+
+	if _, pageHeight := d.pdf.GetPageSize(); d.pdf.GetY()+height > pageHeight-20 {
+		d.pdf.AddPage()
+	}
+
+	x, y := d.pdf.GetXY()
+	d.pdf.MultiCell(columnWidths[0], lineHeight, d.translate(columns[0]), "1", "L", false)
+	d.pdf.SetXY(x+columnWidths[0], y)
+	for i := 1; i < len(columns); i++ {
+		d.pdf.CellFormat(columnWidths[i], height, d.translate(columns[i]), "1", 0, align(i), false, 0, "")
+	}
+	d.pdf.SetXY(x, y+height)
+}
+
+// itemRow lists an order item, prefix marks the items attached to the one
+// above it.
+func (d *document) itemRow(item repository.OrderItem, prefix string) {
+	d.tableRow(
+		prefix+orderItemDescription(item),
+		fmt.Sprintf("%d", item.Quantity),
+		item.NetAmount.String(),
+		fmt.Sprintf("%s (%s)", item.TaxAmount.String(), bpsToPercent(item.TaxRateBps)),
+		item.Amount.String(),
+	)
+}
+
+func (d *document) total(label string, amount pkg.Money) {
+	labelWidth := columnWidths[0] + columnWidths[1] + columnWidths[2] + columnWidths[3]
+	d.pdf.SetFont(font, "B", 10)
+	d.pdf.CellFormat(labelWidth, lineHeight, d.translate(label), "", 0, "R", false, 0, "")
+	d.pdf.CellFormat(columnWidths[4], lineHeight, d.translate(fmt.Sprintf("%s %s", amount.Currency(), amount)), "", 1, "R", false, 0, "")
+}
+
+func (d *document) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.pdf.Output(&buf); err != nil {
+		return nil, pkg.Errorf(pkg.INTERNAL_ERROR, "failed to render receipt: %s", err.Error())
+	}
+
+	return buf.Bytes(), nil
+}
+
+func orderItemDescription(item repository.OrderItem) string {
+	description := fmt.Sprintf("Product #%d", item.ProductID)
+	product := item.CurrentProductDetails
+	if product != nil {
+		description = product.Name
+		if len(product.Variants) > 0 {
+			description = fmt.Sprintf("%s, %s", description, product.Variants[0].Label())
+		}
+		switch {
+		case product.IsMessageCard:
+			description = fmt.Sprintf("%s (message card)", description)
+		case product.IsAddOn:
+			description = fmt.Sprintf("%s (add-on)", description)
+		}
+	}
+
+	if item.PaymentMethod == "subscription" {
+		description = fmt.Sprintf("%s\nSubscription, %s", description, item.Frequency)
+	}
+
+	return description
+}
+
+// formatAddress joins the parts of a delivery address into a single line,
+// most specific first.
+func formatAddress(address repository.Address) string {
+	parts := make([]string, 0, 4)
+	for _, part := range []*string{address.Building, address.Street, &address.Area, address.Landmark} {
+		if part != nil && strings.TrimSpace(*part) != "" {
+			parts = append(parts, strings.TrimSpace(*part))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "-"
+	}
+
+	formatted := strings.Join(parts, ", ")
+	if address.Latitude != nil && address.Longitude != nil {
+		formatted = fmt.Sprintf("%s (%.6f, %.6f)", formatted, *address.Latitude, *address.Longitude)
+	}
+
+	return formatted
+}
+
+func bpsToPercent(bps uint32) string {
+	return fmt.Sprintf("%d.%02d%%", bps/100, bps%100)
+}
+
+func paymentStatus(paid bool) string {
+	if paid {
+		return "Paid"
+	}
+
+	return "Pending"
+}
+
+func stringOrDash(s *string) string {
+	if s == nil || *s == "" {
+		return "-"
+	}
+
+	return *s
+}
+
+func align(column int) string {
+	if column == 0 {
+		return "L"
+	}
+
+	return "R"
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// AnalyticsFilter is the range, From inclusive and To exclusive, analytics are
+// computed over. Granularity is only used by revenue and Limit by the top
+// products and categories.
+type AnalyticsFilter struct {
+	From        time.Time
+	To          time.Time
+	Granularity string
+	Limit       uint32
+}
+
+// Dashboard is the all time overview shown on the admin home page. Amounts in
+// different currencies cannot be summed so revenue is per currency.
+type Dashboard struct {
+	TotalRevenue        map[pkg.Currency]pkg.Money `json:"total_revenue"`
+	TotalProducts       int64                      `json:"total_products"`
+	TotalOrders         int64                      `json:"total_orders"`
+	ActiveSubscriptions int64                      `json:"active_subscriptions"`
+	RecentOrders        []*Order                   `json:"recent_orders"`
+	Categories          []*Category                `json:"categories"`
+	LowStock            []LowStockItem             `json:"low_stock"`
+}
+
+// RevenuePoint is the payments received in a currency during the day, week or
+// month starting at Period.
+type RevenuePoint struct {
+	Period   time.Time    `json:"period"`
+	Currency pkg.Currency `json:"currency"`
+	Payments int64        `json:"payments"`
+	Revenue  pkg.Money    `json:"revenue"`
+}
+
+type OrderStatusCount struct {
+	Status string `json:"status"`
+	Orders int64  `json:"orders"`
+}
+
+// AverageOrderValue is worked out over the paid orders in a currency.
+type AverageOrderValue struct {
+	Currency pkg.Currency `json:"currency"`
+	Orders   int64        `json:"orders"`
+	Revenue  pkg.Money    `json:"revenue"`
+	Average  pkg.Money    `json:"average"`
+}
+
+type TopProduct struct {
+	ProductID   uint32       `json:"product_id"`
+	ProductName string       `json:"product_name"`
+	Currency    pkg.Currency `json:"currency"`
+	Quantity    int64        `json:"quantity"`
+	Revenue     pkg.Money    `json:"revenue"`
+}
+
+type TopCategory struct {
+	CategoryID   uint32       `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Currency     pkg.Currency `json:"currency"`
+	Quantity     int64        `json:"quantity"`
+	Revenue      pkg.Money    `json:"revenue"`
+}
+
+// SubscriptionMetrics counts the subscriptions running at the start and end
+// of a range, those started (New) in it and those cancelled or run out
+// (Churned) in it. ChurnRate is Churned over ActiveAtStart, 0 when none were
+// running. MRR is the monthly value, per currency, of the subscriptions
+// running at the end of the range.
+type SubscriptionMetrics struct {
+	ActiveAtStart int64                      `json:"active_at_start"`
+	ActiveAtEnd   int64                      `json:"active_at_end"`
+	New           int64                      `json:"new"`
+	Churned       int64                      `json:"churned"`
+	ChurnRate     float64                    `json:"churn_rate"`
+	MRR           map[pkg.Currency]pkg.Money `json:"mrr"`
+}
+
+// CustomerMetrics counts the customers, told apart by phone number, who
+// ordered in a range. New customers had not ordered before it.
+type CustomerMetrics struct {
+	Customers int64 `json:"customers"`
+	New       int64 `json:"new"`
+	Returning int64 `json:"returning"`
+}
+
+type AnalyticsRepository interface {
+	GetDashboard(ctx context.Context) (*Dashboard, error)
+	// GetRevenue reports the payments received per period and currency,
+	// periods without payments are left out.
+	GetRevenue(ctx context.Context, filter *AnalyticsFilter) ([]RevenuePoint, error)
+	GetOrdersByStatus(ctx context.Context, filter *AnalyticsFilter) ([]OrderStatusCount, error)
+	GetAverageOrderValues(ctx context.Context, filter *AnalyticsFilter) ([]AverageOrderValue, error)
+	GetTopProducts(ctx context.Context, filter *AnalyticsFilter) ([]TopProduct, error)
+	GetTopCategories(ctx context.Context, filter *AnalyticsFilter) ([]TopCategory, error)
+	GetSubscriptionMetrics(ctx context.Context, filter *AnalyticsFilter) (*SubscriptionMetrics, error)
+	GetCustomerMetrics(ctx context.Context, filter *AnalyticsFilter) (*CustomerMetrics, error)
+}
@@ -8,12 +8,27 @@ import (
 )
 
 type Category struct {
-	ID          uint32     `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	ImageUrl    []string   `json:"image_url"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
+	ID          uint32   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	ImageUrl    []string `json:"image_url"`
+	TaxClassID  *uint32  `json:"tax_class_id,omitempty"`
+	ParentID    *uint32  `json:"parent_id,omitempty"`
+	// ProductCount is the number of live products in the category, in the
+	// category tree it includes the products of sub-categories.
+	ProductCount int64      `json:"product_count"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// set in the category tree
+	Children []*Category `json:"children,omitempty"`
+}
+
+// CategoryBreadcrumb is one step on the path from a top level category down
+// to a product's category.
+type CategoryBreadcrumb struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
 }
 
 type UpdateCategory struct {
@@ -21,6 +36,10 @@ type UpdateCategory struct {
 	Name        *string   `json:"name"`
 	Description *string   `json:"description"`
 	ImageUrl    *[]string `json:"image_url"`
+	TaxClassID  *uint32   `json:"tax_class_id"`
+	// ParentID moves the category under another one, 0 moves it to the top
+	// level.
+	ParentID *uint32 `json:"parent_id"`
 }
 
 type CategoryFilter struct {
@@ -34,4 +53,7 @@ type CategoryRepository interface {
 	UpdateCategory(ctx context.Context, category *UpdateCategory) (*Category, error)
 	ListCategories(ctx context.Context, filter *CategoryFilter) ([]*Category, *pkg.Pagination, error)
 	DeleteCategory(ctx context.Context, id int64) error
+
+	GetCategoryTree(ctx context.Context) ([]*Category, error)
+	RecountProductCounts(ctx context.Context) (int64, error)
 }
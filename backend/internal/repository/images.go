@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ImageOwnerProduct  = "product"
+	ImageOwnerCategory = "category"
+)
+
+// Image is a file uploaded to the blob store for a product or a category,
+// together with its thumbnail. URL is the entry in the owner's image_url.
+type Image struct {
+	ID           uint32    `json:"id"`
+	OwnerType    string    `json:"owner_type"`
+	OwnerID      uint32    `json:"owner_id"`
+	Key          string    `json:"key"`
+	URL          string    `json:"url"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int32     `json:"width"`
+	Height       int32     `json:"height"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ImageRepository interface {
+	// AddImages records uploaded images and appends their URLs to the image_url
+	// of their owner.
+	AddImages(ctx context.Context, ownerType string, ownerID uint32, images []*Image) ([]*Image, error)
+	ListImages(ctx context.Context, ownerType string, ownerID uint32) ([]*Image, error)
+	// ListOrphanImages lists the images their owner no longer uses, of every
+	// owner when ownerType is empty.
+	ListOrphanImages(ctx context.Context, ownerType string, ownerID uint32) ([]*Image, error)
+	DeleteImage(ctx context.Context, id int64) error
+}
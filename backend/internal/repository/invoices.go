@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Invoice numbers a receipt for either an order or a subscription payment.
+// Once issued, the same number is returned every time the receipt is rendered.
+type Invoice struct {
+	ID        uint32    `json:"id"`
+	Number    string    `json:"number"`
+	OrderID   *uint32   `json:"order_id,omitempty"`
+	PaymentID *uint32   `json:"payment_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InvoiceRepository interface {
+	GetOrCreateOrderInvoice(ctx context.Context, orderID int64) (*Invoice, error)
+	GetOrCreatePaymentInvoice(ctx context.Context, paymentID int64) (*Invoice, error)
+}
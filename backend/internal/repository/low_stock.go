@@ -0,0 +1,22 @@
+package repository
+
+import "context"
+
+// LowStockItem is a product, or a variant with stock of its own, whose stock
+// is at or below its reorder threshold.
+type LowStockItem struct {
+	ProductID        uint32  `json:"product_id"`
+	ProductName      string  `json:"product_name"`
+	VariantID        *uint32 `json:"variant_id,omitempty"`
+	VariantSku       *string `json:"variant_sku,omitempty"`
+	StockQuantity    int64   `json:"stock_quantity"`
+	ReorderThreshold int64   `json:"reorder_threshold"`
+}
+
+type LowStockRepository interface {
+	// ListLowStock lists the stock that is low, the least in stock first.
+	ListLowStock(ctx context.Context) ([]LowStockItem, error)
+	// CheckLowStock returns the stock that has run low since the last check.
+	// Stock is returned once until it is back above its threshold.
+	CheckLowStock(ctx context.Context) ([]LowStockItem, error)
+}
@@ -8,56 +8,133 @@ import (
 )
 
 type Order struct {
-	ID              uint32      `json:"id"`
-	UserName        string      `json:"user_name"`
-	UserPhoneNumber string      `json:"user_phone_number"`
-	TotalAmount     float64     `json:"total_amount"`
-	PaymentStatus   bool        `json:"payment_status"`
-	Status          string      `json:"status"`
-	DeliveryDate    time.Time   `json:"delivery_date"`
-	TimeSlot        string      `json:"time_slot"`
-	ByAdmin         bool        `json:"by_admin"`
-	ShippingAddress *string     `json:"shipping_address,omitempty"`
-	DeletedAt       *time.Time  `json:"deleted_at,omitempty"`
-	CreatedAt       time.Time   `json:"created_at"`
-	OrderItemsData  []OrderItem `json:"order_item_data,omitempty"`
+	ID               uint32       `json:"id"`
+	Buyer            Contact      `json:"buyer"`
+	Recipient        Contact      `json:"recipient"`
+	TotalAmount      pkg.Money    `json:"total_amount"`
+	NetAmount        pkg.Money    `json:"net_amount"`
+	TaxAmount        pkg.Money    `json:"tax_amount"`
+	Currency         pkg.Currency `json:"currency"`
+	PaymentStatus    bool         `json:"payment_status"`
+	Status           string       `json:"status"`
+	DeliveryDate     time.Time    `json:"delivery_date"`
+	TimeSlot         string       `json:"time_slot"`
+	ByAdmin          bool         `json:"by_admin"`
+	DeliveryAddress  Address      `json:"delivery_address"`
+	PaymentReference *string      `json:"payment_reference,omitempty"`
+	DeletedAt        *time.Time   `json:"deleted_at,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+	OrderItemsData   []OrderItem  `json:"order_item_data,omitempty"`
 }
 
 type UpdateOrder struct {
-	ID              uint32  `json:"id"`
-	UserName        *string `json:"user_name"`
-	UserPhoneNumber *string `json:"user_phone_number"`
-	PaymentStatus   *bool   `json:"payment_status"`
-	Status          *string `json:"status"`
-	ShippingAddress *string `json:"shipping_address,omitempty"`
+	ID              uint32         `json:"id"`
+	Buyer           *UpdateContact `json:"buyer"`
+	Recipient       *UpdateContact `json:"recipient"`
+	PaymentStatus   *bool          `json:"payment_status"`
+	Status          *string        `json:"status"`
+	DeliveryAddress *UpdateAddress `json:"delivery_address"`
+	// UpdatedBy is the user making the update, recorded with the stock sold
+	// when the order is paid.
+	UpdatedBy uint32 `json:"-"`
 }
 
+// Contact is the buyer who pays for an order or the recipient it is delivered
+// to. Only the buyer's email is kept.
+type Contact struct {
+	Name        string  `json:"name"`
+	PhoneNumber string  `json:"phone_number"`
+	Email       *string `json:"email,omitempty"`
+}
+
+type UpdateContact struct {
+	Name        *string `json:"name"`
+	PhoneNumber *string `json:"phone_number"`
+	Email       *string `json:"email"`
+}
+
+// Address is where an order is delivered. Latitude and Longitude are either
+// both set or both nil.
+type Address struct {
+	Area      string   `json:"area"`
+	Street    *string  `json:"street,omitempty"`
+	Building  *string  `json:"building,omitempty"`
+	Landmark  *string  `json:"landmark,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type UpdateAddress struct {
+	Area      *string  `json:"area"`
+	Street    *string  `json:"street"`
+	Building  *string  `json:"building"`
+	Landmark  *string  `json:"landmark"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+var OrderSortFields = []string{"created_at", "delivery_date", "total_amount"}
+
 type OrderFilter struct {
 	Pagination    *pkg.Pagination
 	Search        *string
 	PaymentStatus *bool
 	Status        *string
+	// StartDate and EndDate bound when the order was placed, StartDate
+	// inclusive and EndDate exclusive.
+	StartDate *time.Time
+	EndDate   *time.Time
 }
 
 type OrderItem struct {
-	ID                    uint32   `json:"id"`
-	OrderID               uint32   `json:"order_id"`
-	ProductID             uint32   `json:"product_id"`
-	StemID                uint32   `json:"stem_id,omitempty"`
-	PaymentMethod         string   `json:"payment_method"`
-	Frequency             string   `json:"frequency"`
-	Quantity              int32    `json:"quantity"`
-	Amount                float64  `json:"amount"`
-	OrderData             *Order   `json:"order_data,omitempty"`
-	CurrentProductDetails *Product `json:"current_product_details,omitempty"`
+	ID                    uint32       `json:"id"`
+	OrderID               uint32       `json:"order_id"`
+	ProductID             uint32       `json:"product_id"`
+	VariantID             uint32       `json:"variant_id,omitempty"`
+	PaymentMethod         string       `json:"payment_method"`
+	Frequency             string       `json:"frequency"`
+	Quantity              int32        `json:"quantity"`
+	Amount                pkg.Money    `json:"amount"`
+	NetAmount             pkg.Money    `json:"net_amount"`
+	TaxAmount             pkg.Money    `json:"tax_amount"`
+	TaxRateBps            uint32       `json:"tax_rate_bps"`
+	MessageCard           *MessageCard `json:"message_card,omitempty"`
+	ParentItemID          uint32       `json:"parent_item_id,omitempty"`
+	Children              []OrderItem  `json:"children,omitempty"` // add-ons and message cards
+	OrderData             *Order       `json:"order_data,omitempty"`
+	CurrentProductDetails *Product     `json:"current_product_details,omitempty"`
+}
+
+// MessageCard is the text printed on the card of an order item that references
+// a message card product. The sender's name is not kept for anonymous cards.
+type MessageCard struct {
+	Message       string `json:"message"`
+	SenderName    string `json:"sender_name,omitempty"`
+	RecipientName string `json:"recipient_name,omitempty"`
+	Anonymous     bool   `json:"anonymous"`
+}
+
+// OrderQuote is the priced breakdown of a prospective order. Prices are tax
+// inclusive, so TotalAmount is the gross and equals NetAmount + TaxAmount.
+type OrderQuote struct {
+	Currency    pkg.Currency `json:"currency"`
+	NetAmount   pkg.Money    `json:"net_amount"`
+	TaxAmount   pkg.Money    `json:"tax_amount"`
+	TotalAmount pkg.Money    `json:"total_amount"`
+	Items       []OrderItem  `json:"items"`
 }
 
 type OrderRepository interface {
+	QuoteOrder(ctx context.Context, currency pkg.Currency, orderItems []OrderItem) (*OrderQuote, error)
+	// CreateOrder fails when the items do not add up to order.TotalAmount, the
+	// amount the buyer paid.
 	CreateOrder(ctx context.Context, order *Order, orderItems []OrderItem) (*Order, error)
 	GetOrderByID(ctx context.Context, id int64) (*Order, error)
 	UpdateOrder(ctx context.Context, order *UpdateOrder) (*Order, error)
 	ListOrders(ctx context.Context, filter *OrderFilter) ([]*Order, *pkg.Pagination, error)
-	DeleteOrder(ctx context.Context, id int64) error
+	// DeleteOrder puts the stock reserved by an unpaid order back into stock.
+	DeleteOrder(ctx context.Context, id int64, userID uint32) error
+	ListOrdersByDeliveryDate(ctx context.Context, date time.Time) ([]*Order, error)
 
 	// Order Items
 	GetOrderItemsByProductID(ctx context.Context, productID int64, filter *OrderFilter) ([]*OrderItem, *pkg.Pagination, error)
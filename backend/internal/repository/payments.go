@@ -8,24 +8,27 @@ import (
 )
 
 type Payment struct {
-	ID                 uint32    `json:"id"`
-	Description        *string   `json:"description,omitempty"`
-	OrderID            *uint32   `json:"order_id,omitempty"`
-	UserSubscriptionID *uint32   `json:"user_subscription_id,omitempty"`
-	PaymentMethod      string    `json:"payment_method"`
-	Amount             float64   `json:"amount"`
-	PaidAt             time.Time `json:"paid_at"`
-	CreatedAt          time.Time `json:"created_at"`
+	ID                 uint32       `json:"id"`
+	Description        *string      `json:"description,omitempty"`
+	OrderID            *uint32      `json:"order_id,omitempty"`
+	UserSubscriptionID *uint32      `json:"user_subscription_id,omitempty"`
+	PaymentMethod      string       `json:"payment_method"`
+	Amount             pkg.Money    `json:"amount"`
+	Currency           pkg.Currency `json:"currency"`
+	PaidAt             time.Time    `json:"paid_at"`
+	CreatedAt          time.Time    `json:"created_at"`
 }
 
 type UpdatePayment struct {
 	ID            uint32     `json:"id"`
 	Description   *string    `json:"description,omitempty"`
 	PaymentMethod *string    `json:"payment_method"`
-	Amount        *float64   `json:"amount"`
+	Amount        *pkg.Money `json:"amount"`
 	PaidAt        *time.Time `json:"paid_at"`
 }
 
+var PaymentSortFields = []string{"paid_at", "created_at", "amount"}
+
 type PaymentFilter struct {
 	Pagination    *pkg.Pagination
 	PaymentMethod *string
@@ -8,13 +8,14 @@ import (
 )
 
 type PaystackPayment struct {
-	ID        int64     `json:"id"`
-	Email     string    `json:"email"`
-	Amount    string    `json:"amount"`
-	Reference string    `json:"reference"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        int64        `json:"id"`
+	Email     string       `json:"email"`
+	Amount    string       `json:"amount"`
+	Currency  pkg.Currency `json:"currency"`
+	Reference string       `json:"reference"`
+	Status    string       `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 }
 
 type PaystackEvent struct {
@@ -25,11 +26,12 @@ type PaystackEvent struct {
 }
 
 type PaystackRepository interface {
-	CreatePayment(ctx context.Context, email string, amount int64, reference string) error
+	CreatePayment(ctx context.Context, email string, amount pkg.Money, reference string) error
 	GetPaymentByReference(ctx context.Context, reference string) (PaystackPayment, error)
 	UpdatePaymentStatus(ctx context.Context, reference string, status string) error
 	ListPaystackPayments(ctx context.Context, status string, pagination *pkg.Pagination) ([]PaystackPayment, *pkg.Pagination, error)
 
 	LogPaystackEvent(ctx context.Context, event string, payload []byte) error
 	ListPaystackEvents(ctx context.Context, event string, pagination *pkg.Pagination) ([]PaystackEvent, *pkg.Pagination, error)
+	GetPaystackEvent(ctx context.Context, id int64) (PaystackEvent, error)
 }
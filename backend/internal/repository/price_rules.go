@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+const (
+	PriceRuleAbsolute   = "absolute"
+	PriceRulePercentage = "percentage"
+)
+
+// PriceRule overrides product and variant prices from StartsAt until EndsAt,
+// either with an absolute Price or with an uplift of UpliftBps basis points
+// (20000 triples the price, -1000 takes 10% off). A rule is scoped to one of a
+// product, optionally narrowed to one of its variants, a category with its
+// sub-categories, or a tag. Absolute rules can only be scoped to a product.
+//
+// When several rules are in force the most specific scope wins (variant,
+// product, tag, category), then the highest priority, then the newest rule.
+// Rules don't stack.
+type PriceRule struct {
+	ID         uint32     `json:"id"`
+	Name       string     `json:"name"`
+	Kind       string     `json:"kind"`
+	Price      *pkg.Money `json:"price,omitempty"`
+	UpliftBps  *int32     `json:"uplift_bps,omitempty"`
+	ProductID  *uint32    `json:"product_id,omitempty"`
+	VariantID  *uint32    `json:"variant_id,omitempty"`
+	CategoryID *uint32    `json:"category_id,omitempty"`
+	TagID      *uint32    `json:"tag_id,omitempty"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"`
+	Priority   int32      `json:"priority"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type PriceRuleFilter struct {
+	ProductID  *uint32
+	CategoryID *uint32
+	TagID      *uint32
+	// ActiveAt matches the rules in force at the time.
+	ActiveAt       *time.Time
+	IncludeDeleted bool
+}
+
+// PriceChange is a change to the base price of a product or, when VariantID is
+// set, of one of its variants. OldPrice is nil for the price a product or
+// variant was created with.
+type PriceChange struct {
+	ID        uint32     `json:"id"`
+	ProductID uint32     `json:"product_id"`
+	VariantID *uint32    `json:"variant_id,omitempty"`
+	OldPrice  *pkg.Money `json:"old_price"`
+	NewPrice  pkg.Money  `json:"new_price"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+// PriceHistory is the audit trail of a product's prices: the changes to its
+// base prices and every rule that has applied or will apply to it.
+type PriceHistory struct {
+	Changes []PriceChange `json:"changes"`
+	Rules   []*PriceRule  `json:"rules"`
+}
+
+type PriceRuleRepository interface {
+	CreatePriceRule(ctx context.Context, priceRule *PriceRule) (*PriceRule, error)
+	GetPriceRuleByID(ctx context.Context, id int64) (*PriceRule, error)
+	ListPriceRules(ctx context.Context, filter *PriceRuleFilter) ([]*PriceRule, error)
+	// EndPriceRule ends a rule that has started and deletes one that has not,
+	// rules are never edited so the price history stays intact.
+	EndPriceRule(ctx context.Context, id int64) error
+	GetPriceHistory(ctx context.Context, productID int64) (*PriceHistory, error)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+const (
+	// ImportModeAtomic imports all the products in one transaction, or none
+	// of them when any row has an error.
+	ImportModeAtomic = "atomic"
+	// ImportModePerRow imports each product in a transaction of its own,
+	// skipping the products whose rows have errors.
+	ImportModePerRow = "per_row"
+)
+
+// ProductImportRow is a line of a product import. Rows with a ProductSKU are
+// variants of the product on the row with that SKU, the others are products
+// upserted by their SKU. Fields left empty are nil, keeping the value of an
+// existing product.
+type ProductImportRow struct {
+	Line             int
+	SKU              string
+	ProductSKU       string
+	Name             string
+	Description      *string
+	Category         string // category name, ignoring case
+	Price            *pkg.Money
+	Currency         pkg.Currency
+	IsFlowers        *bool
+	IsAddOn          *bool
+	IsMessageCard    *bool
+	StockQuantity    *int64
+	ReorderThreshold *int64
+	ImageUrl         []string
+	// Options are the option values of a variant, e.g. Stems 12.
+	Options []VariantOption
+
+	// Errors are the problems found reading the row.
+	Errors []string
+}
+
+type ProductImportOptions struct {
+	Mode   string
+	DryRun bool
+	// UserID is the user importing, recorded with the stock set.
+	UserID uint32
+}
+
+type ProductImportError struct {
+	Line    int    `json:"line"`
+	SKU     string `json:"sku"`
+	Message string `json:"message"`
+}
+
+// ImportedProduct is a product created or updated by an import. ID is 0 for
+// products a dry run would create.
+type ImportedProduct struct {
+	ID  uint32 `json:"id,omitempty"`
+	SKU string `json:"sku"`
+}
+
+// ProductImportResult lists what an import did or, on a dry run, would do.
+type ProductImportResult struct {
+	Mode    string               `json:"mode"`
+	DryRun  bool                 `json:"dry_run"`
+	Created []ImportedProduct    `json:"created"`
+	Updated []ImportedProduct    `json:"updated"`
+	Errors  []ProductImportError `json:"errors"`
+}
+
+type ProductImportRepository interface {
+	ImportProducts(ctx context.Context, rows []ProductImportRow, options ProductImportOptions) (*ProductImportResult, error)
+}
@@ -2,79 +2,207 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/flexGURU/flower-haven/backend/pkg"
 )
 
 type Product struct {
-	ID            uint32     `json:"id"`
-	Name          string     `json:"name"`
-	Description   string     `json:"description"`
-	Price         float64    `json:"price"`
-	CategoryID    uint32     `json:"category_id"`
-	HasStems      bool       `json:"has_stems"`
-	IsMessageCard bool       `json:"is_message_card"`
-	IsFlowers     bool       `json:"is_flowers"`
-	IsAddOn       bool       `json:"is_add_on"`
-	ImageUrl      []string   `json:"image_url"`
-	StockQuantity int64      `json:"stock_quantity"`
-	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
-	CategoryData  *Category  `json:"category_data,omitempty"`
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
+	// SKU is the product's own, variants have theirs.
+	SKU           *string      `json:"sku,omitempty"`
+	Description   string       `json:"description"`
+	Price         pkg.Money    `json:"price"`
+	BasePrice     *pkg.Money   `json:"base_price,omitempty"` // set while a price rule changes the price
+	Currency      pkg.Currency `json:"currency"`
+	CategoryID    uint32       `json:"category_id"`
+	TaxClassID    *uint32      `json:"tax_class_id,omitempty"`
+	HasVariants   bool         `json:"has_variants"`
+	IsMessageCard bool         `json:"is_message_card"`
+	IsFlowers     bool         `json:"is_flowers"`
+	IsAddOn       bool         `json:"is_add_on"`
+	ImageUrl      []string     `json:"image_url"`
+	StockQuantity int64        `json:"stock_quantity"`
+	// AvailableQuantity is how many can be ordered, what the stock of its
+	// components makes when the product has a recipe.
+	AvailableQuantity int64 `json:"available_quantity"`
+	// ReorderThreshold is the stock_quantity at or below which stock is low.
+	ReorderThreshold *int64     `json:"reorder_threshold,omitempty"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	CategoryData     *Category  `json:"category_data,omitempty"`
+	// Breadcrumbs lead from the top level category to the product's own,
+	// set by GetProductByID.
+	Breadcrumbs []CategoryBreadcrumb `json:"breadcrumbs,omitempty"`
 
 	// extended fields
-	Stems []ProductStem `json:"stems,omitempty"`
+	Options  []ProductOption  `json:"options,omitempty"`
+	Variants []ProductVariant `json:"variants,omitempty"`
+	TagIDs   []uint32         `json:"tag_ids,omitempty"`
+	Tags     []Tag            `json:"tags,omitempty"`
+
+	// set when listing with a search term
+	SearchRank float64           `json:"search_rank,omitempty"`
+	Highlight  *ProductHighlight `json:"highlight,omitempty"`
+
+	// CreatedBy is the user creating the product, recorded with its stock.
+	CreatedBy uint32 `json:"-"`
+}
+
+// ProductHighlight holds the name and a description excerpt with the matched
+// search terms wrapped in <mark> tags.
+type ProductHighlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
 
 type UpdateProduct struct {
-	ID            uint32    `json:"id"`
-	Name          *string   `json:"name"`
-	Description   *string   `json:"description"`
-	HasStems      *bool     `json:"has_stems"`
-	IsMessageCard *bool     `json:"is_message_card"`
-	IsFlowers     *bool     `json:"is_flowers"`
-	IsAddOn       *bool     `json:"is_add_on"`
-	Price         *float64  `json:"price"`
-	CategoryID    *uint32   `json:"category_id"`
-	ImageURL      *[]string `json:"image_url"`
-	StockQuantity *int64    `json:"stock_quantity"`
+	ID               uint32     `json:"id"`
+	Name             *string    `json:"name"`
+	SKU              *string    `json:"sku"`
+	Description      *string    `json:"description"`
+	HasVariants      *bool      `json:"has_variants"`
+	IsMessageCard    *bool      `json:"is_message_card"`
+	IsFlowers        *bool      `json:"is_flowers"`
+	IsAddOn          *bool      `json:"is_add_on"`
+	Price            *pkg.Money `json:"price"`
+	Currency         *string    `json:"currency"`
+	CategoryID       *uint32    `json:"category_id"`
+	TaxClassID       *uint32    `json:"tax_class_id"`
+	ImageURL         *[]string  `json:"image_url"`
+	StockQuantity    *int64     `json:"stock_quantity"`
+	ReorderThreshold *int64     `json:"reorder_threshold"`
 
-	// extended fields
-	Stems []UpdateProductStem `json:"stems,omitempty"`
+	// extended fields, variants and tags replace the existing ones when set
+	Options  []ProductOption  `json:"options,omitempty"`
+	Variants []ProductVariant `json:"variants,omitempty"`
+	TagIDs   *[]uint32        `json:"tag_ids,omitempty"`
+
+	// UpdatedBy is the user making the update, recorded with stock changes.
+	UpdatedBy uint32 `json:"-"`
 }
 
-type ProductStem struct {
-	ID        uint32  `json:"id"`
-	ProductID uint32  `json:"product_id"`
-	StemCount uint32  `json:"stem_count"`
-	Price     float64 `json:"price"`
+// ProductOption is a dimension a product varies in, such as size, colour,
+// wrapping or stem count, with its values in display order.
+type ProductOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
 }
 
-type UpdateProductStem struct {
-	StemCount *uint32  `json:"stem_count"`
-	Price     *float64 `json:"price"`
+// ProductVariant is a sellable version of a product with one value of each of
+// the product's options.
+type ProductVariant struct {
+	ID                uint32          `json:"id"`
+	ProductID         uint32          `json:"product_id"`
+	SKU               string          `json:"sku"`
+	Price             pkg.Money       `json:"price"`
+	BasePrice         *pkg.Money      `json:"base_price,omitempty"`        // set while a price rule changes the price
+	StockQuantity     *int64          `json:"stock_quantity"`              // nil when the variant draws on the product's stock
+	AvailableQuantity *int64          `json:"available_quantity"`          // nil when StockQuantity is and there is no recipe
+	ReorderThreshold  *int64          `json:"reorder_threshold,omitempty"` // only applies to stock of its own
+	ImageUrl          []string        `json:"image_url"`
+	Options           []VariantOption `json:"options"`
 }
 
+type VariantOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Label lists the variant's option values, e.g. "Large, Red".
+func (v ProductVariant) Label() string {
+	values := make([]string, len(v.Options))
+	for i, option := range v.Options {
+		values[i] = option.Value
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// ProductSortFields are the fields products can be sorted by, relevance only
+// ranks results when searching.
+var ProductSortFields = []string{"price", "created_at", "popularity", "relevance"}
+
 type ProductFilter struct {
-	Pagination    *pkg.Pagination
-	Search        *string
-	PriceFrom     *float64
-	PriceTo       *float64
+	Pagination *pkg.Pagination
+	// Search matches the name, description and category name with full-text
+	// search, falling back to trigram similarity on the name for typos.
+	// Results are ordered by relevance unless the pagination sets a sort.
+	Search    *string
+	PriceFrom *float64
+	PriceTo   *float64
+	// CategoryIDs matches products in the categories or any of their
+	// sub-categories.
 	CategoryIDs   *[]int64
 	IsMessageCard *bool
 	IsFlowers     *bool
 	IsAddOn       *bool
+	InStock       *bool
+	// TagIDs matches the products having every one of the tags.
+	TagIDs *[]int64
+	// WithFacets also counts the filtered products per facet.
+	WithFacets bool
+}
+
+// ProductPriceBucketBounds splits product prices into the facet buckets
+// under 1,000, 1,000 to 2,000 and so on up to 10,000 and over.
+var ProductPriceBucketBounds = []float64{1000, 2000, 5000, 10000}
+
+// ProductFacets counts the products matching a filter per category, tag,
+// price range, product type and stock status.
+type ProductFacets struct {
+	Categories   []CategoryFacet    `json:"categories"`
+	Tags         []TagFacet         `json:"tags"`
+	PriceBuckets []PriceBucketFacet `json:"price_buckets"`
+	Types        ProductTypeFacets  `json:"types"`
+	Stock        StockFacets        `json:"stock"`
+}
+
+type CategoryFacet struct {
+	ID    uint32 `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type TagFacet struct {
+	ID    uint32 `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Group string `json:"group"`
+	Count int64  `json:"count"`
+}
+
+// PriceBucketFacet counts the products priced from From up to, but not
+// including, To. To is nil for the last bucket.
+type PriceBucketFacet struct {
+	From  float64  `json:"from"`
+	To    *float64 `json:"to"`
+	Count int64    `json:"count"`
+}
+
+type ProductTypeFacets struct {
+	Flowers      int64 `json:"flowers"`
+	AddOns       int64 `json:"add_ons"`
+	MessageCards int64 `json:"message_cards"`
+}
+
+type StockFacets struct {
+	InStock    int64 `json:"in_stock"`
+	OutOfStock int64 `json:"out_of_stock"`
 }
 
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *Product) (*Product, error)
 	GetProductByID(ctx context.Context, id int64) (*Product, error)
 	UpdateProduct(ctx context.Context, product *UpdateProduct) (*Product, error)
-	ListProducts(ctx context.Context, filter *ProductFilter) ([]*Product, *pkg.Pagination, error)
+	ListProducts(ctx context.Context, filter *ProductFilter) ([]*Product, *pkg.Pagination, *ProductFacets, error)
 	DeleteProduct(ctx context.Context, id int64) error
+	// RestoreProduct undoes DeleteProduct, as long as the product's category
+	// has not been deleted.
+	RestoreProduct(ctx context.Context, id int64) (*Product, error)
 
-	GetDashboardData(ctx context.Context) (interface{}, error)
 	ListAddOns(ctx context.Context) ([]*Product, error)
 	ListMessageCards(ctx context.Context) ([]*Product, error)
 }
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderOrdered           = "ordered"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// PurchaseOrder is stock ordered from a supplier. A draft is placed by moving
+// it to ordered, its items are then received, possibly over several
+// deliveries, until it is received. Only drafts have their items edited and
+// only orders with nothing received yet are cancelled.
+type PurchaseOrder struct {
+	ID           uint32              `json:"id"`
+	SupplierID   uint32              `json:"supplier_id"`
+	SupplierName string              `json:"supplier_name"`
+	Status       string              `json:"status"`
+	Currency     pkg.Currency        `json:"currency"`
+	TotalCost    pkg.Money           `json:"total_cost"`
+	ExpectedAt   *time.Time          `json:"expected_at,omitempty"`
+	Notes        *string             `json:"notes,omitempty"`
+	CreatedBy    *uint32             `json:"created_by,omitempty"`
+	OrderedAt    *time.Time          `json:"ordered_at,omitempty"`
+	ReceivedAt   *time.Time          `json:"received_at,omitempty"`
+	CancelledAt  *time.Time          `json:"cancelled_at,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	Items        []PurchaseOrderItem `json:"items,omitempty"`
+}
+
+// PurchaseOrderItem is a quantity of a product or, when VariantID is set, of
+// one of its variants with stock of its own, at UnitCost a unit.
+type PurchaseOrderItem struct {
+	ID               uint32    `json:"id"`
+	ProductID        uint32    `json:"product_id"`
+	ProductName      string    `json:"product_name"`
+	VariantID        *uint32   `json:"variant_id,omitempty"`
+	VariantSku       *string   `json:"variant_sku,omitempty"`
+	Quantity         int64     `json:"quantity"`
+	ReceivedQuantity int64     `json:"received_quantity"`
+	UnitCost         pkg.Money `json:"unit_cost"`
+}
+
+type UpdatePurchaseOrder struct {
+	ID         uint32        `json:"id"`
+	SupplierID *uint32       `json:"supplier_id"`
+	Currency   *pkg.Currency `json:"currency"`
+	// Status only moves a draft to ordered or cancels an order.
+	Status     *string              `json:"status"`
+	ExpectedAt *time.Time           `json:"expected_at"`
+	Notes      *string              `json:"notes"`
+	Items      *[]PurchaseOrderItem `json:"items"`
+}
+
+type PurchaseOrderFilter struct {
+	Pagination *pkg.Pagination
+	SupplierID *uint32
+	Status     *string
+}
+
+// PurchaseOrderReceipt is a delivery against a purchase order. Items with an
+// ExpiresAt are received as stock batches.
+type PurchaseOrderReceipt struct {
+	PurchaseOrderID uint32
+	Items           []PurchaseOrderReceiptItem
+	UserID          uint32
+}
+
+type PurchaseOrderReceiptItem struct {
+	ItemID    uint32     `json:"item_id"`
+	Quantity  int64      `json:"quantity"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// MarginReport sets the revenue, net of tax, of the orders paid between From
+// and To against the average cost of the stock received through purchase
+// orders in the currency sold in; products with a recipe cost what their
+// components do. Items sold without a known cost are Uncosted, have no Cost or
+// Margin and only count towards the UncostedRevenue of the totals.
+type MarginReport struct {
+	From   time.Time           `json:"from"`
+	To     time.Time           `json:"to"`
+	Totals []MarginReportTotal `json:"totals"`
+	Items  []MarginReportItem  `json:"items"`
+}
+
+type MarginReportTotal struct {
+	Currency pkg.Currency `json:"currency"`
+	Revenue  pkg.Money    `json:"revenue"`
+	Cost     pkg.Money    `json:"cost"`
+	Margin   pkg.Money    `json:"margin"`
+	// UncostedRevenue is the revenue of the items without a known cost, left
+	// out of Revenue.
+	UncostedRevenue pkg.Money `json:"uncosted_revenue"`
+}
+
+type MarginReportItem struct {
+	ProductID   uint32       `json:"product_id"`
+	ProductName string       `json:"product_name"`
+	VariantID   *uint32      `json:"variant_id,omitempty"`
+	VariantSku  *string      `json:"variant_sku,omitempty"`
+	Currency    pkg.Currency `json:"currency"`
+	Quantity    int64        `json:"quantity"`
+	Revenue     pkg.Money    `json:"revenue"`
+	UnitCost    *pkg.Money   `json:"unit_cost,omitempty"`
+	Cost        *pkg.Money   `json:"cost,omitempty"`
+	Margin      *pkg.Money   `json:"margin,omitempty"`
+	Uncosted    bool         `json:"uncosted"`
+}
+
+type PurchaseOrderRepository interface {
+	CreatePurchaseOrder(ctx context.Context, order *PurchaseOrder) (*PurchaseOrder, error)
+	GetPurchaseOrderByID(ctx context.Context, id int64) (*PurchaseOrder, error)
+	UpdatePurchaseOrder(ctx context.Context, order *UpdatePurchaseOrder) (*PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, filter *PurchaseOrderFilter) ([]*PurchaseOrder, *pkg.Pagination, error)
+	// ReceivePurchaseOrder adds a delivery to the stock of the items received
+	// and moves the purchase order on to partially_received or received.
+	ReceivePurchaseOrder(ctx context.Context, receipt *PurchaseOrderReceipt) (*PurchaseOrder, error)
+
+	GetMarginReport(ctx context.Context, from, to time.Time) (*MarginReport, error)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Recipe lists the components one of a product, or when VariantID is set of
+// one of its variants, is made from. Variants without a recipe of their own
+// are made by their product's. Ordering an item with a recipe takes its
+// components out of stock and its availability is what their stock makes.
+type Recipe struct {
+	ProductID  uint32            `json:"product_id"`
+	VariantID  *uint32           `json:"variant_id,omitempty"`
+	Components []RecipeComponent `json:"components"`
+}
+
+// RecipeComponent is a quantity of a product or, when VariantID is set, of one
+// of its variants with stock of its own.
+type RecipeComponent struct {
+	ProductID   uint32  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	VariantID   *uint32 `json:"variant_id,omitempty"`
+	VariantSku  *string `json:"variant_sku,omitempty"`
+	Quantity    int64   `json:"quantity"`
+}
+
+type RecipeRepository interface {
+	GetRecipe(ctx context.Context, productID uint32, variantID *uint32) (*Recipe, error)
+	// SetRecipe replaces the recipe of a product or variant, without
+	// components it removes it. Components cannot have recipes of their own.
+	SetRecipe(ctx context.Context, recipe *Recipe) (*Recipe, error)
+	// ListComponentsNeeded sums the components of the orders delivered on date.
+	ListComponentsNeeded(ctx context.Context, date time.Time) ([]RecipeComponent, error)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+// StockBatch is a delivery of stock for a product or, when VariantID is set,
+// for one of its variants with stock of its own. Orders take from the batches
+// that expire first; what is left at ExpiresAt is written off.
+type StockBatch struct {
+	ID           uint32     `json:"id"`
+	ProductID    uint32     `json:"product_id"`
+	VariantID    *uint32    `json:"variant_id,omitempty"`
+	Supplier     *string    `json:"supplier,omitempty"`
+	ReceivedAt   time.Time  `json:"received_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	Quantity     int64      `json:"quantity"`
+	Remaining    int64      `json:"remaining"`
+	WrittenOff   int64      `json:"written_off"`
+	WrittenOffAt *time.Time `json:"written_off_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type StockBatchFilter struct {
+	Pagination *pkg.Pagination
+	ProductID  *uint32
+	VariantID  *uint32
+	// Live only matches the batches with stock remaining.
+	Live          bool
+	ExpiresBefore *time.Time
+}
+
+// WastageReport sums the stock written off between From and To by product and
+// variant, the largest wastage first, whether batches expired or stock was
+// written off by hand.
+type WastageReport struct {
+	From            time.Time           `json:"from"`
+	To              time.Time           `json:"to"`
+	TotalWrittenOff int64               `json:"total_written_off"`
+	Items           []WastageReportItem `json:"items"`
+}
+
+type WastageReportItem struct {
+	ProductID   uint32  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	VariantID   *uint32 `json:"variant_id,omitempty"`
+	VariantSku  *string `json:"variant_sku,omitempty"`
+	Batches     int64   `json:"batches"`
+	WrittenOff  int64   `json:"written_off"`
+}
+
+type StockBatchRepository interface {
+	// CreateStockBatch records a batch received by the user and adds it to the
+	// stock of its product or variant.
+	CreateStockBatch(ctx context.Context, batch *StockBatch, userID uint32) (*StockBatch, error)
+	GetStockBatchByID(ctx context.Context, id int64) (*StockBatch, error)
+	ListStockBatches(ctx context.Context, filter *StockBatchFilter) ([]*StockBatch, *pkg.Pagination, error)
+	// WriteOffExpiredStock writes off what is left of the batches expired by at
+	// and takes it out of stock, returning the batches written off.
+	WriteOffExpiredStock(ctx context.Context, at time.Time) ([]*StockBatch, error)
+	GetWastageReport(ctx context.Context, from, to time.Time) (*WastageReport, error)
+}
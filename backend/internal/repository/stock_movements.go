@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+const (
+	StockMovementReceipt     = "receipt"
+	StockMovementSale        = "sale"
+	StockMovementReservation = "reservation"
+	StockMovementRelease     = "release"
+	StockMovementAdjustment  = "adjustment"
+	StockMovementWastage     = "wastage"
+	StockMovementReturn      = "return"
+)
+
+// StockMovement is an entry of the append-only stock ledger: a change of
+// Quantity, negative when stock goes out, to the stock of a product or, when
+// VariantID is set, of one of its variants. UserID is the user who made the
+// change, nil for customers placing orders and scheduled jobs.
+//
+// Orders reserve their stock when placed; paying the order releases the
+// reservation and records the sale, deleting an unpaid order releases it back
+// into stock.
+type StockMovement struct {
+	ID           uint32    `json:"id"`
+	ProductID    uint32    `json:"product_id"`
+	VariantID    *uint32   `json:"variant_id,omitempty"`
+	Kind         string    `json:"kind"`
+	Quantity     int64     `json:"quantity"`
+	Reason       string    `json:"reason"`
+	UserID       *uint32   `json:"user_id,omitempty"`
+	OrderID      *uint32   `json:"order_id,omitempty"`
+	StockBatchID *uint32   `json:"stock_batch_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// StockAdjustment is a change to stock made by hand: a correction after a
+// count, a customer return or stock thrown away.
+type StockAdjustment struct {
+	ProductID uint32
+	VariantID *uint32
+	Kind      string
+	Quantity  int64
+	Reason    string
+	UserID    uint32
+}
+
+type StockMovementFilter struct {
+	Pagination *pkg.Pagination
+	ProductID  *uint32
+	VariantID  *uint32
+	Kind       *string
+	OrderID    *uint32
+}
+
+// StockDiscrepancy is a product or variant whose stock_quantity is not the
+// balance of its stock movements.
+type StockDiscrepancy struct {
+	ProductID      uint32  `json:"product_id"`
+	VariantID      *uint32 `json:"variant_id,omitempty"`
+	StockQuantity  int64   `json:"stock_quantity"`
+	LedgerQuantity int64   `json:"ledger_quantity"`
+}
+
+type StockMovementRepository interface {
+	// AdjustStock records an adjustment, split by the batch each part of
+	// stock taken out came from.
+	AdjustStock(ctx context.Context, adjustment *StockAdjustment) ([]*StockMovement, error)
+	ListStockMovements(ctx context.Context, filter *StockMovementFilter) ([]*StockMovement, *pkg.Pagination, error)
+	ListStockDiscrepancies(ctx context.Context) ([]StockDiscrepancy, error)
+}
@@ -8,30 +8,33 @@ import (
 )
 
 type Subscription struct {
-	ID           uint32     `json:"id"`
-	Name         string     `json:"name"`
-	Description  string     `json:"description"`
-	ProductIds   []uint32   `json:"product_ids"`
-	StemIds      []uint32   `json:"stem_ids"`
-	ByAdmin      bool       `json:"by_admin"`
-	ProductsData []Product  `json:"products_data,omitempty"`
-	AddOns       []uint32   `json:"add_ons"`
-	AddOnsData   []Product  `json:"add_ons_data,omitempty"`
-	Price        float64    `json:"price"`
-	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
-	CreatedAt    time.Time  `json:"created_at"`
+	ID           uint32       `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	ProductIds   []uint32     `json:"product_ids"`
+	VariantIds   []uint32     `json:"variant_ids"`
+	ByAdmin      bool         `json:"by_admin"`
+	ProductsData []Product    `json:"products_data,omitempty"`
+	AddOns       []uint32     `json:"add_ons"`
+	AddOnsData   []Product    `json:"add_ons_data,omitempty"`
+	Price        pkg.Money    `json:"price"`
+	Currency     pkg.Currency `json:"currency"`
+	DeletedAt    *time.Time   `json:"deleted_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
 }
 
 type UpdateSubscription struct {
-	ID          uint32    `json:"id"`
-	Name        *string   `json:"name"`
-	Description *string   `json:"description"`
-	ProductIds  *[]uint32 `json:"product_ids"`
-	StemIds     *[]uint32 `json:"stem_ids"`
-	AddOns      *[]uint32 `json:"add_ons"`
-	Price       *float64  `json:"price"`
+	ID          uint32     `json:"id"`
+	Name        *string    `json:"name"`
+	Description *string    `json:"description"`
+	ProductIds  *[]uint32  `json:"product_ids"`
+	VariantIds  *[]uint32  `json:"variant_ids"`
+	AddOns      *[]uint32  `json:"add_ons"`
+	Price       *pkg.Money `json:"price"`
 }
 
+var SubscriptionSortFields = []string{"created_at", "price"}
+
 type SubscriptionFilter struct {
 	Pagination *pkg.Pagination
 	ByAdmin    *bool
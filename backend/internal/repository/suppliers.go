@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/pkg"
+)
+
+type Supplier struct {
+	ID          uint32     `json:"id"`
+	Name        string     `json:"name"`
+	ContactName *string    `json:"contact_name,omitempty"`
+	Email       *string    `json:"email,omitempty"`
+	PhoneNumber *string    `json:"phone_number,omitempty"`
+	Address     *string    `json:"address,omitempty"`
+	Notes       *string    `json:"notes,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type UpdateSupplier struct {
+	ID          uint32  `json:"id"`
+	Name        *string `json:"name"`
+	ContactName *string `json:"contact_name"`
+	Email       *string `json:"email"`
+	PhoneNumber *string `json:"phone_number"`
+	Address     *string `json:"address"`
+	Notes       *string `json:"notes"`
+}
+
+type SupplierFilter struct {
+	Pagination *pkg.Pagination
+	Search     *string
+}
+
+type SupplierRepository interface {
+	CreateSupplier(ctx context.Context, supplier *Supplier) (*Supplier, error)
+	GetSupplierByID(ctx context.Context, id int64) (*Supplier, error)
+	UpdateSupplier(ctx context.Context, supplier *UpdateSupplier) (*Supplier, error)
+	ListSuppliers(ctx context.Context, filter *SupplierFilter) ([]*Supplier, *pkg.Pagination, error)
+	// DeleteSupplier soft deletes a supplier, its purchase orders are kept.
+	DeleteSupplier(ctx context.Context, id int64) error
+}
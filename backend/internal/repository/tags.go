@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TagGroup is a dimension customers shop by across categories, such as
+// occasion, colour or flower type.
+type TagGroup struct {
+	ID        uint32     `json:"id"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	Position  int32      `json:"position"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type UpdateTagGroup struct {
+	ID       uint32  `json:"id"`
+	Name     *string `json:"name"`
+	Slug     *string `json:"slug"`
+	Position *int32  `json:"position"`
+}
+
+type Tag struct {
+	ID           uint32     `json:"id"`
+	TagGroupID   uint32     `json:"tag_group_id"`
+	GroupName    string     `json:"group_name,omitempty"`
+	GroupSlug    string     `json:"group_slug,omitempty"`
+	Name         string     `json:"name"`
+	Slug         string     `json:"slug"`
+	Description  string     `json:"description"`
+	ImageUrl     []string   `json:"image_url"`
+	Position     int32      `json:"position"`
+	ProductCount *int64     `json:"product_count,omitempty"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type UpdateTag struct {
+	ID          uint32    `json:"id"`
+	TagGroupID  *uint32   `json:"tag_group_id"`
+	Name        *string   `json:"name"`
+	Slug        *string   `json:"slug"`
+	Description *string   `json:"description"`
+	ImageUrl    *[]string `json:"image_url"`
+	Position    *int32    `json:"position"`
+}
+
+type TagRepository interface {
+	CreateTagGroup(ctx context.Context, tagGroup *TagGroup) (*TagGroup, error)
+	GetTagGroupByID(ctx context.Context, id int64) (*TagGroup, error)
+	UpdateTagGroup(ctx context.Context, tagGroup *UpdateTagGroup) (*TagGroup, error)
+	ListTagGroups(ctx context.Context) ([]*TagGroup, error)
+	DeleteTagGroup(ctx context.Context, id int64) error
+
+	CreateTag(ctx context.Context, tag *Tag) (*Tag, error)
+	GetTagByID(ctx context.Context, id int64) (*Tag, error)
+	GetTagBySlug(ctx context.Context, slug string) (*Tag, error)
+	UpdateTag(ctx context.Context, tag *UpdateTag) (*Tag, error)
+	ListTags(ctx context.Context, tagGroupID *uint32) ([]*Tag, error)
+	DeleteTag(ctx context.Context, id int64) error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type TaxClass struct {
+	ID          uint32     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Rates       []TaxRate  `json:"rates,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type UpdateTaxClass struct {
+	ID          uint32  `json:"id"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// TaxRate is the rate of a tax class from EffectiveFrom until the next rate of
+// the same class takes effect. RateBps is in basis points (1600 = 16%).
+type TaxRate struct {
+	ID            uint32    `json:"id"`
+	TaxClassID    uint32    `json:"tax_class_id"`
+	RateBps       uint32    `json:"rate_bps"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TaxRepository interface {
+	CreateTaxClass(ctx context.Context, taxClass *TaxClass) (*TaxClass, error)
+	GetTaxClassByID(ctx context.Context, id int64) (*TaxClass, error)
+	UpdateTaxClass(ctx context.Context, taxClass *UpdateTaxClass) (*TaxClass, error)
+	ListTaxClasses(ctx context.Context) ([]*TaxClass, error)
+	DeleteTaxClass(ctx context.Context, id int64) error
+
+	CreateTaxRate(ctx context.Context, taxRate *TaxRate) (*TaxRate, error)
+	DeleteTaxRate(ctx context.Context, id int64) error
+}
@@ -31,6 +31,8 @@ type UpdateUser struct {
 	RefreshToken *string `json:"refresh_token"`
 }
 
+var UserSortFields = []string{"created_at"}
+
 type UserFilter struct {
 	Pagination *pkg.Pagination
 	Search     *string
@@ -45,4 +47,6 @@ type UserRepository interface {
 	GetUserInternal(ctx context.Context, id int64, email string) (*User, error)
 	UpdateUser(ctx context.Context, user *UpdateUser) (*User, error)
 	ListUsers(ctx context.Context, filter *UserFilter) ([]*User, *pkg.Pagination, error)
+	// ListActiveAdmins lists the staff to notify about the running of the shop.
+	ListActiveAdmins(ctx context.Context) ([]*User, error)
 }
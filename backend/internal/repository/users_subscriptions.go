@@ -16,6 +16,7 @@ type UserSubscription struct {
 	Status         bool       `json:"status"`
 	StartDate      time.Time  `json:"start_date"`
 	EndDate        time.Time  `json:"end_date"`
+	CancelledAt    *time.Time `json:"cancelled_at,omitempty"`
 	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
 	CreatedAt      time.Time  `json:"created_at"`
 
@@ -1 +1,127 @@
 package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/repository"
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+	"github.com/flexGURU/flower-haven/backend/pkg"
+	"github.com/robfig/cron/v3"
+)
+
+// jobTimeout bounds a single run of a job.
+const jobTimeout = 5 * time.Minute
+
+// Scheduler runs the periodic jobs of the backend on cron schedules.
+type Scheduler struct {
+	cron         *cron.Cron
+	stockBatches repository.StockBatchRepository
+	lowStock     repository.LowStockRepository
+	users        repository.UserRepository
+	notifier     services.INotifier
+}
+
+func NewScheduler(config pkg.Config, stockBatches repository.StockBatchRepository, lowStock repository.LowStockRepository, users repository.UserRepository, notifier services.INotifier) (*Scheduler, error) {
+	s := &Scheduler{
+		// a run still going when the next one is due is skipped
+		cron:         cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger))),
+		stockBatches: stockBatches,
+		lowStock:     lowStock,
+		users:        users,
+		notifier:     notifier,
+	}
+
+	if _, err := s.cron.AddFunc(config.STOCK_WRITE_OFF_CRON, s.writeOffExpiredStock); err != nil {
+		return nil, fmt.Errorf("invalid STOCK_WRITE_OFF_CRON %q: %w", config.STOCK_WRITE_OFF_CRON, err)
+	}
+
+	if _, err := s.cron.AddFunc(config.LOW_STOCK_CHECK_CRON, s.checkLowStock); err != nil {
+		return nil, fmt.Errorf("invalid LOW_STOCK_CHECK_CRON %q: %w", config.LOW_STOCK_CHECK_CRON, err)
+	}
+
+	return s, nil
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops scheduling jobs and waits for the running ones until ctx is done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	select {
+	case <-s.cron.Stop().Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) writeOffExpiredStock() {
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	batches, err := s.stockBatches.WriteOffExpiredStock(ctx, time.Now())
+	if err != nil {
+		log.Printf("error writing off expired stock: %v", err)
+		return
+	}
+
+	if len(batches) > 0 {
+		var quantity int64
+		for _, batch := range batches {
+			quantity += batch.WrittenOff
+		}
+		log.Printf("wrote off %d expired stock from %d batches", quantity, len(batches))
+	}
+}
+
+// checkLowStock notifies the active admins of the stock that has run low
+// since the last check.
+func (s *Scheduler) checkLowStock() {
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	items, err := s.lowStock.CheckLowStock(ctx)
+	if err != nil {
+		log.Printf("error checking low stock: %v", err)
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	admins, err := s.users.ListActiveAdmins(ctx)
+	if err != nil {
+		log.Printf("error listing admins to notify of low stock: %v", err)
+		return
+	}
+
+	lines := make([]string, len(items))
+	for i, item := range items {
+		name := item.ProductName
+		if item.VariantSku != nil {
+			name = fmt.Sprintf("%s (%s)", name, *item.VariantSku)
+		}
+		lines[i] = fmt.Sprintf("- %s: %d in stock, reorder at %d", name, item.StockQuantity, item.ReorderThreshold)
+	}
+
+	notification := services.Notification{
+		Subject: fmt.Sprintf("%d products are low on stock", len(items)),
+		Message: "The following stock is at or below its reorder threshold:\n" + strings.Join(lines, "\n"),
+	}
+	if len(items) == 1 {
+		notification.Subject = "1 product is low on stock"
+	}
+
+	for _, admin := range admins {
+		notification.Email = admin.Email
+		notification.PhoneNumber = admin.PhoneNumber
+		if err := s.notifier.Send(ctx, notification); err != nil {
+			log.Printf("failed to send low stock notification to user %d: %v", admin.ID, err)
+		}
+	}
+}
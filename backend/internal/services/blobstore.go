@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+	"io"
+)
+
+// IBlobStore keeps uploaded files under keys such as
+// "products/12/2b7e1516.jpg" and serves them from a public URL.
+type IBlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
@@ -0,0 +1,12 @@
+package services
+
+// ITableWriter writes an export a row at a time so that an export is never
+// held in memory as a whole.
+type ITableWriter interface {
+	// WriteRow takes strings, numbers, booleans, times, money and pointers to
+	// them, nil pointers are left empty.
+	WriteRow(values ...any) error
+	// Close completes the export, formats that cannot be streamed are only
+	// written out then.
+	Close() error
+}
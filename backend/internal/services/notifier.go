@@ -0,0 +1,23 @@
+package services
+
+import "context"
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Notification is addressed to an email, a phone number or both; a notifier
+// uses whichever channels it supports.
+type Notification struct {
+	Email       string
+	PhoneNumber string
+	Subject     string
+	Message     string
+	Attachments []Attachment
+}
+
+type INotifier interface {
+	Send(ctx context.Context, notification Notification) error
+}
@@ -1,6 +1,8 @@
 package services
 
+import "github.com/flexGURU/flower-haven/backend/pkg"
+
 type IPayStack interface {
-	InitializePayment(email string, amount int64) (string, string, error)
-	VerifyPayment(reference string, amount int64) (string, error)
+	InitializePayment(email string, amount pkg.Money) (string, string, error)
+	VerifyPayment(reference string, amount pkg.Money) (string, error)
 }
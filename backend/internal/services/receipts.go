@@ -0,0 +1,8 @@
+package services
+
+import "github.com/flexGURU/flower-haven/backend/internal/repository"
+
+type IReceipt interface {
+	OrderReceipt(invoice *repository.Invoice, order *repository.Order) ([]byte, error)
+	SubscriptionReceipt(invoice *repository.Invoice, payment *repository.Payment, userSubscription *repository.UserSubscription) ([]byte, error)
+}
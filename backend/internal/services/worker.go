@@ -1 +1,9 @@
 package services
+
+import "context"
+
+// IWorker runs jobs in the background so that the request queueing them does
+// not wait on their outcome.
+type IWorker interface {
+	Enqueue(name string, job func(ctx context.Context) error)
+}
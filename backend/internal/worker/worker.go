@@ -1 +1,64 @@
 package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/flexGURU/flower-haven/backend/internal/services"
+)
+
+// jobTimeout bounds a single run of a job.
+const jobTimeout = 5 * time.Minute
+
+var _ services.IWorker = (*Worker)(nil)
+
+type job struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Worker runs queued jobs one at a time in the order they were queued.
+type Worker struct {
+	jobs chan job
+	done chan struct{}
+}
+
+// NewWorker returns a worker queueing up to size jobs before Enqueue blocks.
+func NewWorker(size int) *Worker {
+	return &Worker{
+		jobs: make(chan job, size),
+		done: make(chan struct{}),
+	}
+}
+
+func (w *Worker) Start() {
+	go func() {
+		defer close(w.done)
+
+		for j := range w.jobs {
+			ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+			if err := j.run(ctx); err != nil {
+				log.Printf("error running %s: %v", j.name, err)
+			}
+			cancel()
+		}
+	}()
+}
+
+func (w *Worker) Enqueue(name string, run func(ctx context.Context) error) {
+	w.jobs <- job{name: name, run: run}
+}
+
+// Stop stops taking jobs and waits for the queued ones until ctx is done. Jobs
+// must not be queued once it is called.
+func (w *Worker) Stop(ctx context.Context) error {
+	close(w.jobs)
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
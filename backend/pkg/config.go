@@ -21,6 +21,21 @@ type Config struct {
 	TOKEN_ISSUER            string        `mapstructure:"TOKEN_ISSUER"`
 	PAYSTACK_SECRET_KEY     string        `mapstructure:"PAYSTACK_SECRET_KEY"`
 	PAYSTACK_CALLBACK_URL   string        `mapstructure:"PAYSTACK_CALLBACK_URL"`
+	BUSINESS_NAME           string        `mapstructure:"BUSINESS_NAME"`
+	BUSINESS_DETAILS        string        `mapstructure:"BUSINESS_DETAILS"`
+	BLOB_STORE              string        `mapstructure:"BLOB_STORE"` // local or s3
+	UPLOAD_DIR              string        `mapstructure:"UPLOAD_DIR"`
+	UPLOAD_URL              string        `mapstructure:"UPLOAD_URL"`
+	UPLOAD_MAX_BYTES        int64         `mapstructure:"UPLOAD_MAX_BYTES"`
+	S3_ENDPOINT             string        `mapstructure:"S3_ENDPOINT"`
+	S3_ACCESS_KEY           string        `mapstructure:"S3_ACCESS_KEY"`
+	S3_SECRET_KEY           string        `mapstructure:"S3_SECRET_KEY"`
+	S3_BUCKET               string        `mapstructure:"S3_BUCKET"`
+	S3_REGION               string        `mapstructure:"S3_REGION"`
+	S3_USE_SSL              bool          `mapstructure:"S3_USE_SSL"`
+	S3_PUBLIC_URL           string        `mapstructure:"S3_PUBLIC_URL"`
+	STOCK_WRITE_OFF_CRON    string        `mapstructure:"STOCK_WRITE_OFF_CRON"` // cron schedule of the expired stock write-off
+	LOW_STOCK_CHECK_CRON    string        `mapstructure:"LOW_STOCK_CHECK_CRON"` // cron schedule of the low stock check
 }
 
 func LoadConfig(path string) (Config, error) {
@@ -58,4 +73,19 @@ func setDefaults() {
 	viper.SetDefault("TOKEN_ISSUER", "")
 	viper.SetDefault("PAYSTACK_SECRET_KEY", "")
 	viper.SetDefault("PAYSTACK_CALLBACK_URL", "")
+	viper.SetDefault("BUSINESS_NAME", "Flower Haven")
+	viper.SetDefault("BUSINESS_DETAILS", "")
+	viper.SetDefault("BLOB_STORE", "local")
+	viper.SetDefault("UPLOAD_DIR", "uploads")
+	viper.SetDefault("UPLOAD_URL", "/uploads")
+	viper.SetDefault("UPLOAD_MAX_BYTES", 5<<20)
+	viper.SetDefault("S3_ENDPOINT", "")
+	viper.SetDefault("S3_ACCESS_KEY", "")
+	viper.SetDefault("S3_SECRET_KEY", "")
+	viper.SetDefault("S3_BUCKET", "")
+	viper.SetDefault("S3_REGION", "")
+	viper.SetDefault("S3_USE_SSL", true)
+	viper.SetDefault("S3_PUBLIC_URL", "")
+	viper.SetDefault("STOCK_WRITE_OFF_CRON", "*/15 * * * *")
+	viper.SetDefault("LOW_STOCK_CHECK_CRON", "*/30 * * * *")
 }
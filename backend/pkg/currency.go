@@ -0,0 +1,55 @@
+package pkg
+
+import "strings"
+
+type Currency string
+
+const (
+	KES Currency = "KES"
+	NGN Currency = "NGN"
+	GHS Currency = "GHS"
+	ZAR Currency = "ZAR"
+	USD Currency = "USD"
+
+	DefaultCurrency = KES
+)
+
+// minorUnitExponents holds the number of decimal places of each currency's
+// subunit, i.e. the power of ten Paystack expects amounts to be scaled by.
+var minorUnitExponents = map[Currency]int32{
+	KES: 2,
+	NGN: 2,
+	GHS: 2,
+	ZAR: 2,
+	USD: 2,
+}
+
+func SupportedCurrencies() []Currency {
+	return []Currency{KES, NGN, GHS, ZAR, USD}
+}
+
+func ParseCurrency(s string) (Currency, error) {
+	if s == "" {
+		return DefaultCurrency, nil
+	}
+
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if !c.IsSupported() {
+		return "", Errorf(INVALID_ERROR, "unsupported currency: %s", s)
+	}
+
+	return c, nil
+}
+
+func (c Currency) IsSupported() bool {
+	_, ok := minorUnitExponents[c]
+	return ok
+}
+
+func (c Currency) Exponent() int32 {
+	return minorUnitExponents[c]
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
@@ -0,0 +1,322 @@
+package pkg
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	_ pgtype.NumericScanner = (*Money)(nil)
+	_ pgtype.NumericValuer  = Money{}
+)
+
+// Money is an exact decimal amount held as an integer count of the currency's
+// minor unit (e.g. cents), so prices never pass through float64.
+//
+// A Money decoded from JSON is in the default currency until WithCurrency is
+// called. Only the zero Money has no currency, and it is treated as
+// compatible with any other currency; amounts in two different currencies are
+// never added, subtracted or compared.
+type Money struct {
+	minor    int64
+	currency Currency
+}
+
+func NewMoney(minor int64, currency Currency) Money {
+	return Money{minor: minor, currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "1500.50" into Money. Fractional
+// digits beyond the currency's minor unit are rejected rather than rounded.
+func ParseMoney(s string, currency Currency) (Money, error) {
+	minor, err := parseMinorUnits(s, currency.exponent())
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{minor: minor, currency: currency}, nil
+}
+
+func NumericToMoney(n pgtype.Numeric, currency Currency) (Money, error) {
+	m := Money{currency: currency}
+	if err := m.ScanNumeric(n); err != nil {
+		return Money{}, err
+	}
+
+	return m, nil
+}
+
+func (m Money) MinorUnits() int64 {
+	return m.minor
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// WithCurrency moves the amount to currency, keeping its decimal value when
+// the currencies have a different number of decimal places.
+func (m Money) WithCurrency(currency Currency) Money {
+	if shift := int64(currency.exponent()) - int64(m.currency.exponent()); shift > 0 {
+		m.minor *= int64(math.Pow10(int(shift)))
+	} else if shift < 0 {
+		m.minor = divRoundHalfAway(big.NewInt(m.minor), big.NewInt(int64(math.Pow10(int(-shift))))).Int64()
+	}
+
+	m.currency = currency
+	return m
+}
+
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.minor > 0
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := m.commonCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+
+	sum := m.minor + o.minor
+	if (o.minor > 0 && sum < m.minor) || (o.minor < 0 && sum > m.minor) {
+		return Money{}, Errorf(INVALID_ERROR, "amount overflow adding %s and %s", m, o)
+	}
+
+	return Money{minor: sum, currency: currency}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(Money{minor: -o.minor, currency: o.currency})
+}
+
+func (m Money) Mul(n int64) (Money, error) {
+	return m.MulRatio(n, 1)
+}
+
+// MulRatio returns m * num / den rounded half away from zero to the nearest
+// minor unit. It is used for percentages (e.g. MulRatio(16, 100)).
+func (m Money) MulRatio(num, den int64) (Money, error) {
+	if den == 0 {
+		return Money{}, Errorf(INVALID_ERROR, "division by zero")
+	}
+
+	r := new(big.Int).Mul(big.NewInt(m.minor), big.NewInt(num))
+	r = divRoundHalfAway(r, big.NewInt(den))
+	if !r.IsInt64() {
+		return Money{}, Errorf(INVALID_ERROR, "amount overflow multiplying %s by %d/%d", m, num, den)
+	}
+
+	return Money{minor: r.Int64(), currency: m.currency}, nil
+}
+
+// Cmp compares the amounts of m and o, returning -1, 0 or +1. Amounts in
+// different currencies don't compare.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.commonCurrency(o); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.minor < o.minor:
+		return -1, nil
+	case m.minor > o.minor:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) Equal(o Money) bool {
+	_, err := m.commonCurrency(o)
+	return err == nil && m.minor == o.minor
+}
+
+// String formats the amount in major units with exactly the currency's number
+// of decimal places, without the currency code (e.g. "1500.50").
+func (m Money) String() string {
+	exp := m.currency.exponent()
+
+	sign := ""
+	abs := uint64(m.minor)
+	if m.minor < 0 {
+		sign = "-"
+		abs = uint64(-(m.minor + 1)) + 1
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(exp) {
+		digits = strings.Repeat("0", int(exp)-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-int(exp)] + "." + digits[len(digits)-int(exp):]
+}
+
+func (m Money) Numeric() pgtype.Numeric {
+	return pgtype.Numeric{
+		Int:   big.NewInt(m.minor),
+		Exp:   -m.currency.exponent(),
+		Valid: true,
+	}
+}
+
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	return m.Numeric(), nil
+}
+
+// ScanNumeric keeps the receiver's currency and rounds half away from zero if
+// the numeric has more decimal places than the currency's minor unit.
+func (m *Money) ScanNumeric(v pgtype.Numeric) error {
+	if !v.Valid {
+		return Errorf(INVALID_ERROR, "cannot scan NULL into money")
+	}
+	if v.NaN || v.InfinityModifier != pgtype.Finite {
+		return Errorf(INVALID_ERROR, "cannot scan non finite numeric into money")
+	}
+
+	shift := int64(v.Exp) + int64(m.currency.exponent())
+	r := new(big.Int).Set(v.Int)
+	if shift >= 0 {
+		r.Mul(r, new(big.Int).Exp(big.NewInt(10), big.NewInt(shift), nil))
+	} else {
+		r = divRoundHalfAway(r, new(big.Int).Exp(big.NewInt(10), big.NewInt(-shift), nil))
+	}
+
+	if !r.IsInt64() {
+		return Errorf(INVALID_ERROR, "numeric out of range for money")
+	}
+
+	m.minor = r.Int64()
+
+	return nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string. The amount keeps the
+// currency of the receiver, or takes the default currency when it has none;
+// callers set the actual one with WithCurrency.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	s := string(bytes.Trim(b, `"`))
+	minor, err := parseMinorUnits(s, m.currency.exponent())
+	if err != nil {
+		return err
+	}
+
+	m.minor = minor
+	if m.currency == "" {
+		m.currency = DefaultCurrency
+	}
+
+	return nil
+}
+
+func (m Money) commonCurrency(o Money) (Currency, error) {
+	switch {
+	case m.currency == o.currency || o.currency == "":
+		return m.currency, nil
+	case m.currency == "":
+		return o.currency, nil
+	default:
+		return "", Errorf(INVALID_ERROR, "currency mismatch: %s and %s", m.currency, o.currency)
+	}
+}
+
+// exponent falls back to the default currency so that amounts decoded without
+// a currency still use the right number of decimal places.
+func (c Currency) exponent() int32 {
+	if exp, ok := minorUnitExponents[c]; ok {
+		return exp
+	}
+
+	return minorUnitExponents[DefaultCurrency]
+}
+
+func divRoundHalfAway(n, d *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(new(big.Int).Abs(d)) >= 0 {
+		if (n.Sign() < 0) != (d.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
+
+// parseMinorUnits parses a decimal string into an integer scaled by 10^exp.
+// Non-zero digits beyond exp decimal places are an error.
+func parseMinorUnits(s string, exp int32) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, Errorf(INVALID_ERROR, "invalid amount: empty")
+	}
+	raw := s
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		// a sign or a point without digits, e.g. "-" or "."
+		return 0, Errorf(INVALID_ERROR, "invalid amount: %s", raw)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	if int32(len(fracPart)) > exp {
+		if strings.Trim(fracPart[exp:], "0") != "" {
+			return 0, Errorf(INVALID_ERROR, "invalid amount %s: at most %d decimal places allowed", raw, exp)
+		}
+		fracPart = fracPart[:exp]
+	}
+	fracPart += strings.Repeat("0", int(exp)-len(fracPart))
+
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return 0, Errorf(INVALID_ERROR, "invalid amount: %s", raw)
+		}
+	}
+
+	v, err := strconv.ParseUint(intPart+fracPart, 10, 64)
+	if err != nil || v > math.MaxInt64 {
+		return 0, Errorf(INVALID_ERROR, "invalid amount: %s", raw)
+	}
+
+	if negative {
+		return -int64(v), nil
+	}
+
+	return int64(v), nil
+}
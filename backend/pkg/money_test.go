@@ -0,0 +1,201 @@
+package pkg
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1500.50", want: 150050},
+		{in: "1500.5", want: 150050},
+		{in: "1500", want: 150000},
+		{in: " 12.30 ", want: 1230},
+		{in: ".5", want: 50},
+		{in: "5.", want: 500},
+		{in: "-0.01", want: -1},
+		{in: "+3", want: 300},
+		{in: "1.230", want: 123},
+		{in: "92233720368547758.07", want: 9223372036854775807},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "+", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "-.", wantErr: true},
+		{in: "1.234", wantErr: true},
+		{in: "1,000", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "92233720368547758.08", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in, KES)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMoney(%q) = %d, want an error", tt.in, got.MinorUnits())
+			} else if ErrorCode(err) != INVALID_ERROR {
+				t.Errorf("ParseMoney(%q) error code = %s, want %s", tt.in, ErrorCode(err), INVALID_ERROR)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseMoney(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got.MinorUnits() != tt.want || got.Currency() != KES {
+			t.Errorf("ParseMoney(%q) = %d %s, want %d KES", tt.in, got.MinorUnits(), got.Currency(), tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		minor int64
+		want  string
+	}{
+		{minor: 0, want: "0.00"},
+		{minor: 5, want: "0.05"},
+		{minor: 50, want: "0.50"},
+		{minor: 150050, want: "1500.50"},
+		{minor: -1, want: "-0.01"},
+		{minor: -150000, want: "-1500.00"},
+		{minor: -9223372036854775808, want: "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		if got := NewMoney(tt.minor, USD).String(); got != tt.want {
+			t.Errorf("NewMoney(%d).String() = %q, want %q", tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	kes := func(minor int64) Money { return NewMoney(minor, KES) }
+
+	tests := []struct {
+		name    string
+		op      func() (Money, error)
+		want    Money
+		wantErr bool
+	}{
+		{name: "add", op: func() (Money, error) { return kes(150).Add(kes(250)) }, want: kes(400)},
+		{name: "add to zero money", op: func() (Money, error) { return Money{}.Add(kes(250)) }, want: kes(250)},
+		{name: "add other currency", op: func() (Money, error) { return kes(150).Add(NewMoney(250, USD)) }, wantErr: true},
+		{name: "add overflow", op: func() (Money, error) { return kes(9223372036854775807).Add(kes(1)) }, wantErr: true},
+		{name: "sub", op: func() (Money, error) { return kes(150).Sub(kes(250)) }, want: kes(-100)},
+		{name: "sub other currency", op: func() (Money, error) { return kes(150).Sub(NewMoney(250, NGN)) }, wantErr: true},
+		{name: "mul", op: func() (Money, error) { return kes(1250).Mul(3) }, want: kes(3750)},
+		{name: "mul overflow", op: func() (Money, error) { return kes(9223372036854775807).Mul(2) }, wantErr: true},
+		{name: "ratio rounds half up", op: func() (Money, error) { return kes(5).MulRatio(1, 2) }, want: kes(3)},
+		{name: "ratio rounds half away from zero", op: func() (Money, error) { return kes(-5).MulRatio(1, 2) }, want: kes(-3)},
+		{name: "ratio rounds down", op: func() (Money, error) { return kes(1000).MulRatio(1, 3) }, want: kes(333)},
+		{name: "ratio by zero", op: func() (Money, error) { return kes(1000).MulRatio(1, 0) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.op()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s = %s %s, want an error", tt.name, got, got.Currency())
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s = %s %s, want %s %s", tt.name, got, got.Currency(), tt.want, tt.want.Currency())
+		}
+	}
+}
+
+func TestMoneyCmp(t *testing.T) {
+	tests := []struct {
+		m, o    Money
+		want    int
+		wantErr bool
+	}{
+		{m: NewMoney(100, KES), o: NewMoney(200, KES), want: -1},
+		{m: NewMoney(200, KES), o: NewMoney(100, KES), want: 1},
+		{m: NewMoney(100, KES), o: NewMoney(100, KES), want: 0},
+		{m: Money{}, o: NewMoney(100, USD), want: -1},
+		{m: NewMoney(100, KES), o: NewMoney(100, USD), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.m.Cmp(tt.o)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s %s Cmp %s %s = %d, want an error", tt.m, tt.m.Currency(), tt.o, tt.o.Currency(), got)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("%s %s Cmp %s %s = %d, %v, want %d", tt.m, tt.m.Currency(), tt.o, tt.o.Currency(), got, err, tt.want)
+		}
+	}
+
+	if NewMoney(100, KES).Equal(NewMoney(100, USD)) {
+		t.Errorf("amounts in different currencies are equal")
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: `{"amount": 12.5}`, want: NewMoney(1250, DefaultCurrency)},
+		{in: `{"amount": "1500.50"}`, want: NewMoney(150050, DefaultCurrency)},
+		{in: `{"amount": null}`, want: Money{}},
+		{in: `{}`, want: Money{}},
+		{in: `{"amount": "-"}`, wantErr: true},
+		{in: `{"amount": "."}`, wantErr: true},
+		{in: `{"amount": 1.005}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got struct {
+			Amount Money `json:"amount"`
+		}
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("decoding %s = %s, want an error", tt.in, got.Amount)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("decoding %s unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got.Amount != tt.want {
+			t.Errorf("decoding %s = %s %q, want %s %q", tt.in, got.Amount, got.Amount.Currency(), tt.want, tt.want.Currency())
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{Amount: NewMoney(-150050, USD)})
+	if err != nil || string(data) != `{"amount":-1500.50}` {
+		t.Errorf("encoding = %s, %v, want {\"amount\":-1500.50}", data, err)
+	}
+
+	// a decoded amount keeps the currency of the value it is decoded into
+	usd := NewMoney(0, USD)
+	if err := usd.UnmarshalJSON([]byte(`"2.5"`)); err != nil || usd != NewMoney(250, USD) {
+		t.Errorf("decoding into USD = %s %s, %v, want 2.50 USD", usd, usd.Currency(), err)
+	}
+}
@@ -11,6 +11,15 @@ type Pagination struct {
 	HasPrevious  bool   `json:"has_previous"`
 	NextPage     uint32 `json:"next_page"`
 	PreviousPage uint32 `json:"previous_page"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+
+	// Sort overrides the list's default order. With a Cursor the page starts
+	// after the cursor instead of at Page.
+	Sort   Sort    `json:"-"`
+	Cursor *Cursor `json:"-"`
+	// SkipTotal spares counting the whole list for callers that only follow
+	// NextCursor, Total and the page numbers are left at 0.
+	SkipTotal bool `json:"-"`
 }
 
 func Offset(page, pageSize uint32) int32 {
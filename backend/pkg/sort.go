@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// MaxSortFields caps the fields of a sort expression.
+const MaxSortFields = 2
+
+type SortField struct {
+	Name string
+	Desc bool
+}
+
+// Sort is an ordered list of fields parsed from a "price,-created_at" style
+// query parameter, a leading "-" sorts the field in descending order.
+type Sort []SortField
+
+// ParseSort parses raw and rejects fields that are not in allowed.
+func ParseSort(raw string, allowed ...string) (Sort, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > MaxSortFields {
+		return nil, Errorf(INVALID_ERROR, "sort accepts at most %d fields", MaxSortFields)
+	}
+
+	sort := make(Sort, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+
+		field := SortField{Name: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !slices.Contains(allowed, field.Name) {
+			return nil, Errorf(INVALID_ERROR, "cannot sort by %q, allowed fields are %s", field.Name, strings.Join(allowed, ", "))
+		}
+
+		if slices.ContainsFunc(sort, func(f SortField) bool { return f.Name == field.Name }) {
+			return nil, Errorf(INVALID_ERROR, "sort field %q is repeated", field.Name)
+		}
+
+		sort = append(sort, field)
+	}
+
+	return sort, nil
+}
+
+// Key returns the i-th field as it is written, e.g. "-price", or an empty
+// string when the sort has fewer fields.
+func (s Sort) Key(i int) string {
+	if i >= len(s) {
+		return ""
+	}
+
+	if s[i].Desc {
+		return "-" + s[i].Name
+	}
+
+	return s[i].Name
+}
+
+func (s Sort) String() string {
+	keys := make([]string, len(s))
+	for i := range s {
+		keys[i] = s.Key(i)
+	}
+
+	return strings.Join(keys, ",")
+}
+
+// Cursor points just past the last row of a page for keyset pagination. Keys
+// are the text form of the row's sort columns as returned by the list query,
+// ending with its id which breaks ties. The list query casts them back to the
+// columns' types, so no precision is lost on the way.
+type Cursor struct {
+	Sort string   `json:"s"`
+	Keys []string `json:"k"`
+}
+
+// Encode returns the opaque form handed to clients.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode. A cursor only applies to
+// the sort it was created with.
+func DecodeCursor(raw string, sort Sort) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, Errorf(INVALID_ERROR, "invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || len(cursor.Keys) == 0 {
+		return nil, Errorf(INVALID_ERROR, "invalid cursor")
+	}
+
+	if cursor.Sort != sort.String() {
+		return nil, Errorf(INVALID_ERROR, "cursor was created for a different sort")
+	}
+
+	return &cursor, nil
+}
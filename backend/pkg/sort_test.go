@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"price", "created_at", "name"}
+
+	tests := []struct {
+		raw     string
+		want    Sort
+		wantErr bool
+	}{
+		{raw: "", want: nil},
+		{raw: "  ", want: nil},
+		{raw: "price", want: Sort{{Name: "price"}}},
+		{raw: "-created_at", want: Sort{{Name: "created_at", Desc: true}}},
+		{raw: "price, -created_at", want: Sort{{Name: "price"}, {Name: "created_at", Desc: true}}},
+		{raw: "price,name,created_at", wantErr: true},
+		{raw: "stock", wantErr: true},
+		{raw: "price,-price", wantErr: true},
+		{raw: "price,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSort(tt.raw, allowed...)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSort(%q) = %v, want an error", tt.raw, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseSort(%q) unexpected error: %v", tt.raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSort(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+		if tt.want != nil && got.String() != strings.ReplaceAll(tt.raw, " ", "") {
+			t.Errorf("ParseSort(%q).String() = %q", tt.raw, got.String())
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	sort := Sort{{Name: "price"}, {Name: "created_at", Desc: true}}
+	valid := Cursor{Sort: sort.String(), Keys: []string{"KES", "1500.50", "2024-01-02 03:04:05+00", "42"}}
+
+	tests := []struct {
+		name    string
+		raw     string
+		sort    Sort
+		want    *Cursor
+		wantErr bool
+	}{
+		{name: "round trip", raw: valid.Encode(), sort: sort, want: &valid},
+		{name: "default sort", raw: Cursor{Keys: []string{"42"}}.Encode(), sort: nil, want: &Cursor{Keys: []string{"42"}}},
+		{name: "other sort", raw: valid.Encode(), sort: Sort{{Name: "price"}}, wantErr: true},
+		{name: "other direction", raw: valid.Encode(), sort: Sort{{Name: "price", Desc: true}, {Name: "created_at", Desc: true}}, wantErr: true},
+		{name: "no keys", raw: Cursor{Sort: sort.String()}.Encode(), sort: sort, wantErr: true},
+		{name: "not base64", raw: "%%%", sort: sort, wantErr: true},
+		{name: "not json", raw: base64.RawURLEncoding.EncodeToString([]byte("price")), sort: sort, wantErr: true},
+		{name: "keys not strings", raw: base64.RawURLEncoding.EncodeToString([]byte(`{"s":"price,-created_at","k":[1,2]}`)), sort: sort, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := DecodeCursor(tt.raw, tt.sort)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: DecodeCursor = %+v, want an error", tt.name, got)
+			} else if ErrorCode(err) != INVALID_ERROR {
+				t.Errorf("%s: DecodeCursor error code = %s, want %s", tt.name, ErrorCode(err), INVALID_ERROR)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: DecodeCursor unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: DecodeCursor = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
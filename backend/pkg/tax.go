@@ -0,0 +1,25 @@
+package pkg
+
+// MaxTaxRateBps is a 100% tax rate expressed in basis points.
+const MaxTaxRateBps = 10000
+
+// SplitInclusiveTax splits a tax inclusive amount into its net and tax parts
+// for a rate in basis points (1600 = 16%). The tax is rounded to the nearest
+// minor unit and the net is whatever remains, so net + tax always equals gross.
+func SplitInclusiveTax(gross Money, rateBps uint32) (Money, Money, error) {
+	if rateBps > MaxTaxRateBps {
+		return Money{}, Money{}, Errorf(INVALID_ERROR, "tax rate %d bps is above %d bps", rateBps, MaxTaxRateBps)
+	}
+
+	tax, err := gross.MulRatio(int64(rateBps), int64(MaxTaxRateBps+rateBps))
+	if err != nil {
+		return Money{}, Money{}, err
+	}
+
+	net, err := gross.Sub(tax)
+	if err != nil {
+		return Money{}, Money{}, err
+	}
+
+	return net, tax, nil
+}
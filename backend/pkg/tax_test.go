@@ -0,0 +1,45 @@
+package pkg
+
+import "testing"
+
+func TestSplitInclusiveTax(t *testing.T) {
+	tests := []struct {
+		gross    int64
+		rateBps  uint32
+		net, tax int64
+		wantErr  bool
+	}{
+		{gross: 11600, rateBps: 1600, net: 10000, tax: 1600},
+		{gross: 100, rateBps: 1600, net: 86, tax: 14},
+		{gross: 1, rateBps: 1600, net: 1, tax: 0},
+		{gross: 999, rateBps: 0, net: 999, tax: 0},
+		{gross: 2000, rateBps: MaxTaxRateBps, net: 1000, tax: 1000},
+		{gross: 0, rateBps: 1600, net: 0, tax: 0},
+		{gross: -11600, rateBps: 1600, net: -10000, tax: -1600},
+		{gross: 100, rateBps: MaxTaxRateBps + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		net, tax, err := SplitInclusiveTax(NewMoney(tt.gross, KES), tt.rateBps)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("SplitInclusiveTax(%d, %d) = %s, %s, want an error", tt.gross, tt.rateBps, net, tax)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("SplitInclusiveTax(%d, %d) unexpected error: %v", tt.gross, tt.rateBps, err)
+			continue
+		}
+		if net.MinorUnits() != tt.net || tax.MinorUnits() != tt.tax {
+			t.Errorf("SplitInclusiveTax(%d, %d) = %d, %d, want %d, %d", tt.gross, tt.rateBps, net.MinorUnits(), tax.MinorUnits(), tt.net, tt.tax)
+		}
+		if net.MinorUnits()+tax.MinorUnits() != tt.gross {
+			t.Errorf("SplitInclusiveTax(%d, %d) parts don't add up to the gross", tt.gross, tt.rateBps)
+		}
+		if net.Currency() != KES || tax.Currency() != KES {
+			t.Errorf("SplitInclusiveTax(%d, %d) changed the currency", tt.gross, tt.rateBps)
+		}
+	}
+}
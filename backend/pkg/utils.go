@@ -2,7 +2,9 @@ package pkg
 
 import (
 	"log"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -10,27 +12,11 @@ import (
 
 const timeFormat = "2006-01-02"
 
-func PgTypeNumericToFloat64(n pgtype.Numeric) float64 {
-	f, err := n.Float64Value()
-	if err != nil {
-		log.Println("not float")
-		return 0
-	}
-
-	return f.Float64
-}
+// phoneNumberRegex accepts an optional leading + followed by 9 to 15 digits,
+// which may be grouped with spaces or dashes.
+var phoneNumberRegex = regexp.MustCompile(`^\+?[0-9](?:[ -]?[0-9]){8,14}$`)
 
-func Float64ToPgTypeNumeric(f float64) pgtype.Numeric {
-	var amount pgtype.Numeric
-	if err := amount.Scan(strconv.FormatFloat(f, 'f', -1, 64)); err != nil {
-		log.Println("not float")
-		return pgtype.Numeric{
-			Valid: false,
-		}
-	}
-
-	return amount
-}
+var slugSeparatorRegex = regexp.MustCompile(`[^a-z0-9]+`)
 
 func PgTypeArrayToString(a pgtype.Array[string]) []string {
 	return a.Elements
@@ -87,3 +73,17 @@ func StringToFloat64(s string) (float64, error) {
 func DayOfWeekToInt(day time.Weekday) int16 {
 	return int16(day)
 }
+
+func ValidatePhoneNumber(field, phoneNumber string) error {
+	if !phoneNumberRegex.MatchString(phoneNumber) {
+		return Errorf(INVALID_ERROR, "invalid %s: %q", field, phoneNumber)
+	}
+
+	return nil
+}
+
+// Slugify lowercases s and joins its letters and digits with dashes, so
+// "Valentine's Day" becomes "valentine-s-day".
+func Slugify(s string) string {
+	return strings.Trim(slugSeparatorRegex.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}